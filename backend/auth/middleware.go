@@ -17,6 +17,12 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// Keys under which AdminOrUserAuthMiddleware stores the authenticated user in the gin context
+const (
+	UsernameContextKey = "username"
+	RoleContextKey     = "role"
+)
+
 type jwtWebconsoleClaims struct {
 	jwt.RegisteredClaims
 	Username string `json:"username"`
@@ -46,6 +52,8 @@ func AdminOrUserAuthMiddleware(jwtSecret []byte) gin.HandlerFunc {
 			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: admin or user access required"})
 			c.Abort()
 		}
+		c.Set(UsernameContextKey, claims.Username)
+		c.Set(RoleContextKey, claims.Role)
 		c.Next()
 	}
 }
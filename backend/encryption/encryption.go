@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+// Package encryption implements the envelope encryption of the subscribers' secrets at rest.
+//
+// Every value is encrypted with a random data key using AES-256-GCM and the data key is
+// in turn encrypted (wrapped) with a key encryption key loaded from a file or an
+// environment variable. The identifier of the key encryption key is stored alongside the
+// ciphertext so that keys can be rotated:
+//
+//	enc:v1:<key id>:<wrapped data key>:<ciphertext>
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+const (
+	envelopePrefix = "enc:v1:"
+	keySize        = 32
+)
+
+var keyIdPattern = regexp.MustCompile("^[A-Za-z0-9_-]{1,64}$")
+
+// SubscriberKeyRing encrypts the subscribers' K and OPc. It is nil when encryption is disabled.
+var SubscriberKeyRing *KeyRing
+
+type KeyRing struct {
+	activeKeyId string
+	keys        map[string][]byte
+}
+
+// InitSubscriberKeyRing loads the subscriber key encryption keys configured in factory.WebUIConfig
+func InitSubscriberKeyRing() error {
+	SubscriberKeyRing = nil
+	if factory.WebUIConfig == nil || factory.WebUIConfig.Configuration == nil || factory.WebUIConfig.Configuration.SubscriberKeyEncryption == nil {
+		logger.InitLog.Infoln("subscriber key encryption is disabled")
+		return nil
+	}
+	keyRing, err := NewKeyRing(factory.WebUIConfig.Configuration.SubscriberKeyEncryption)
+	if err != nil {
+		return err
+	}
+	SubscriberKeyRing = keyRing
+	logger.InitLog.Infof("subscriber key encryption is enabled with active key %s", keyRing.activeKeyId)
+	logger.InitLog.Warnln("WARNING: subscriber key encryption is enabled. The UDR and UDM cannot decrypt the K, OPc and TOPc " +
+		"stored by the webconsole: the subscribers whose keys are encrypted cannot authenticate")
+	return nil
+}
+
+func NewKeyRing(config *factory.SubscriberKeyEncryption) (*KeyRing, error) {
+	keyRing := &KeyRing{
+		activeKeyId: config.ActiveKeyId,
+		keys:        make(map[string][]byte),
+	}
+	for _, key := range config.Keys {
+		if !keyIdPattern.MatchString(key.Id) {
+			return nil, fmt.Errorf("invalid encryption key id %q", key.Id)
+		}
+		if _, exists := keyRing.keys[key.Id]; exists {
+			return nil, fmt.Errorf("duplicate encryption key id %s", key.Id)
+		}
+		material, err := loadKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keyRing.keys[key.Id] = material
+	}
+	if _, exists := keyRing.keys[keyRing.activeKeyId]; !exists {
+		return nil, fmt.Errorf("active encryption key %q is not configured", keyRing.activeKeyId)
+	}
+	return keyRing, nil
+}
+
+func loadKey(key factory.EncryptionKey) ([]byte, error) {
+	var encoded string
+	if key.File != "" {
+		content, err := os.ReadFile(key.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key %s: %w", key.Id, err)
+		}
+		encoded = string(content)
+	} else {
+		value, ok := os.LookupEnv(key.EnvVar)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s of encryption key %s is not set", key.EnvVar, key.Id)
+		}
+		encoded = value
+	}
+	encoded = strings.TrimSpace(encoded)
+	if material, err := hex.DecodeString(encoded); err == nil && len(material) == keySize {
+		return material, nil
+	}
+	if material, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(material) == keySize {
+		return material, nil
+	}
+	return nil, fmt.Errorf("encryption key %s must be %d bytes, hex or base64 encoded", key.Id, keySize)
+}
+
+func (keyRing *KeyRing) ActiveKeyId() string {
+	return keyRing.activeKeyId
+}
+
+// IsEncrypted reports whether value is an encrypted envelope
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// KeyId returns the identifier of the key which encrypted value, or an empty string
+// if value is not encrypted
+func KeyId(value string) string {
+	if !IsEncrypted(value) {
+		return ""
+	}
+	keyId, _, _ := strings.Cut(strings.TrimPrefix(value, envelopePrefix), ":")
+	return keyId
+}
+
+// Encrypt seals plaintext in an envelope encrypted with the active key
+func (keyRing *KeyRing) Encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrappedKey, err := seal(keyRing.keys[keyRing.activeKeyId], dataKey, []byte(keyRing.activeKeyId))
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dataKey, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return envelopePrefix + keyRing.activeKeyId + ":" +
+		base64.RawURLEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt opens an envelope created by Encrypt. Values which are not encrypted are
+// returned unchanged, so that records written before encryption was enabled can be read.
+func (keyRing *KeyRing) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(value, envelopePrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	keyId := parts[0]
+	key, exists := keyRing.keys[keyId]
+	if !exists {
+		return "", fmt.Errorf("encryption key %s is not configured", keyId)
+	}
+	wrappedKey, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	dataKey, err := open(key, wrappedKey, []byte(keyId))
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key with key %s: %w", keyId, err)
+	}
+	plaintext, err := open(dataKey, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption reports whether value is not encrypted with the active key
+func (keyRing *KeyRing) NeedsReencryption(value string) bool {
+	return KeyId(value) != keyRing.activeKeyId
+}
+
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package encryption
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/omec-project/webconsole/backend/factory"
+)
+
+const (
+	testKey1 = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testKey2 = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+)
+
+func newTestKeyRing(t *testing.T, activeKeyId string) *KeyRing {
+	t.Helper()
+	t.Setenv("TEST_SUBSCRIBER_KEY_1", testKey1)
+	keyFile := filepath.Join(t.TempDir(), "key2")
+	if err := os.WriteFile(keyFile, []byte(testKey2+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	keyRing, err := NewKeyRing(&factory.SubscriberKeyEncryption{
+		ActiveKeyId: activeKeyId,
+		Keys: []factory.EncryptionKey{
+			{Id: "key1", EnvVar: "TEST_SUBSCRIBER_KEY_1"},
+			{Id: "key2", File: keyFile},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create key ring: %v", err)
+	}
+	return keyRing
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyRing := newTestKeyRing(t, "key1")
+	plaintext := "8baf473f2f8fd09487cccbd7097c6862"
+
+	encrypted, err := keyRing.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, plaintext) {
+		t.Errorf("expected an encrypted envelope, got %s", encrypted)
+	}
+	if KeyId(encrypted) != "key1" {
+		t.Errorf("expected key id key1, got %s", KeyId(encrypted))
+	}
+	decrypted, err := keyRing.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decrypted != plaintext {
+		t.Errorf("expected %s, got %s", plaintext, decrypted)
+	}
+	other, err := keyRing.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == encrypted {
+		t.Errorf("expected a different envelope for each encryption")
+	}
+}
+
+func TestDecryptPlaintextIsUnchanged(t *testing.T) {
+	keyRing := newTestKeyRing(t, "key1")
+	decrypted, err := keyRing.Decrypt("8baf473f2f8fd09487cccbd7097c6862")
+	if err != nil || decrypted != "8baf473f2f8fd09487cccbd7097c6862" {
+		t.Errorf("expected plaintext value to be unchanged, got %s, %v", decrypted, err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKeyRing := newTestKeyRing(t, "key1")
+	encrypted, err := oldKeyRing.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	newKeyRing := newTestKeyRing(t, "key2")
+	if !newKeyRing.NeedsReencryption(encrypted) {
+		t.Errorf("expected value encrypted with the previous key to need re-encryption")
+	}
+	decrypted, err := newKeyRing.Decrypt(encrypted)
+	if err != nil || decrypted != "secret" {
+		t.Errorf("expected value encrypted with the previous key to be decrypted, got %s, %v", decrypted, err)
+	}
+	reencrypted, err := newKeyRing.Encrypt(decrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if newKeyRing.NeedsReencryption(reencrypted) {
+		t.Errorf("expected value encrypted with the active key not to need re-encryption")
+	}
+}
+
+func TestDecryptFailures(t *testing.T) {
+	keyRing := newTestKeyRing(t, "key1")
+	encrypted, err := keyRing.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{"unknown key", strings.Replace(encrypted, "key1", "key3", 1)},
+		{"tampered ciphertext", encrypted[:len(encrypted)-2] + "AA"},
+		{"malformed envelope", "enc:v1:key1:abc"},
+		{"wrapped key of another key id", strings.Replace(encrypted, "key1", "key2", 1)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyRing.Decrypt(tc.value); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewKeyRingInvalidConfig(t *testing.T) {
+	t.Setenv("TEST_SHORT_KEY", "0001")
+	testCases := []struct {
+		name   string
+		config factory.SubscriberKeyEncryption
+	}{
+		{"missing active key", factory.SubscriberKeyEncryption{ActiveKeyId: "key2", Keys: []factory.EncryptionKey{{Id: "key1", EnvVar: "TEST_SHORT_KEY"}}}},
+		{"short key", factory.SubscriberKeyEncryption{ActiveKeyId: "key1", Keys: []factory.EncryptionKey{{Id: "key1", EnvVar: "TEST_SHORT_KEY"}}}},
+		{"unset environment variable", factory.SubscriberKeyEncryption{ActiveKeyId: "key1", Keys: []factory.EncryptionKey{{Id: "key1", EnvVar: "TEST_UNSET_KEY"}}}},
+		{"invalid key id", factory.SubscriberKeyEncryption{ActiveKeyId: "key:1", Keys: []factory.EncryptionKey{{Id: "key:1", EnvVar: "TEST_SHORT_KEY"}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewKeyRing(&tc.config); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package factory
 
 import (
+	"time"
+
 	"github.com/omec-project/util/logger"
 )
 
@@ -39,6 +41,36 @@ type Configuration struct {
 	EnableAuthentication    bool      `yaml:"enableAuthentication,omitempty"`
 	SendPebbleNotifications bool      `yaml:"send-pebble-notifications,omitempty"`
 	CfgPort                 int       `yaml:"cfgport,omitempty"`
+	// SubscriberKeyEncryption enables the encryption at rest of the subscribers' K and OPc.
+	// The UDR and UDM read the authentication subscriptions directly and cannot decrypt
+	// them: enabling it breaks the authentication of the subscribers it encrypts.
+	SubscriberKeyEncryption *SubscriberKeyEncryption `yaml:"subscriberKeyEncryption,omitempty"`
+	// SubscriberReconciliation periodically scans the subscription data for orphaned records
+	SubscriberReconciliation *SubscriberReconciliation `yaml:"subscriberReconciliation,omitempty"`
+}
+
+type SubscriberReconciliation struct {
+	// Interval between two reconciliation runs, e.g. 1h
+	Interval time.Duration `yaml:"interval"`
+	// Repair deletes the orphaned records found. They are only reported otherwise
+	Repair bool `yaml:"repair,omitempty"`
+}
+
+type SubscriberKeyEncryption struct {
+	// ActiveKeyId is the identifier of the key used to encrypt new values
+	ActiveKeyId string `yaml:"activeKeyId"`
+	// Keys lists the active key and the previous keys still needed to decrypt existing values
+	Keys []EncryptionKey `yaml:"keys"`
+	// AcknowledgeCoreCannotDecrypt must be set to confirm that the 5G core does not read the
+	// encrypted keys, as it has no way to decrypt them
+	AcknowledgeCoreCannotDecrypt bool `yaml:"acknowledgeCoreCannotDecrypt"`
+}
+
+// EncryptionKey is a 256-bit key, hex or base64 encoded, read from a file or an environment variable
+type EncryptionKey struct {
+	Id     string `yaml:"id"`
+	File   string `yaml:"file,omitempty"`
+	EnvVar string `yaml:"envVar,omitempty"`
 }
 
 type TLS struct {
@@ -65,6 +65,14 @@ func InitConfigFactory(f string) error {
 		}
 	}
 
+	if err = validateSubscriberKeyEncryption(WebUIConfig.Configuration.SubscriberKeyEncryption); err != nil {
+		return err
+	}
+
+	if reconciliation := WebUIConfig.Configuration.SubscriberReconciliation; reconciliation != nil && reconciliation.Interval <= 0 {
+		return fmt.Errorf("[Configuration] subscriber reconciliation interval must be positive")
+	}
+
 	if WebUIConfig.Configuration.RocEnd != nil {
 		if WebUIConfig.Configuration.RocEnd.Enabled && WebUIConfig.Configuration.RocEnd.SyncUrl == "" {
 			return fmt.Errorf("[Configuration] if RocEnd enabled, SyncUrl must be set")
@@ -74,6 +82,24 @@ func InitConfigFactory(f string) error {
 	return nil
 }
 
+func validateSubscriberKeyEncryption(keyEncryption *SubscriberKeyEncryption) error {
+	if keyEncryption == nil {
+		return nil
+	}
+	if !keyEncryption.AcknowledgeCoreCannotDecrypt {
+		return fmt.Errorf("[Configuration] subscriber key encryption makes the subscribers' keys unreadable by the UDR and UDM: set acknowledgeCoreCannotDecrypt to enable it")
+	}
+	for _, key := range keyEncryption.Keys {
+		if key.Id == "" {
+			return fmt.Errorf("[Configuration] subscriber key encryption key id must be set")
+		}
+		if (key.File == "") == (key.EnvVar == "") {
+			return fmt.Errorf("[Configuration] subscriber key encryption key %s must set exactly one of file or envVar", key.Id)
+		}
+	}
+	return nil
+}
+
 func SetLogLevelsFromConfig(cfg *Config) {
 	cfgLogger := cfg.Logger
 	if cfgLogger == nil {
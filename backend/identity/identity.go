@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+// Package identity parses, validates and normalizes the subscription permanent
+// identifiers (SUPI) used as UE IDs, as specified in TS 23.003 and TS 29.571.
+//
+// Two SUPI types are supported:
+//
+//	imsi-<6 to 15 digits>
+//	nai-<username>@<realm>
+//
+// The type prefix is case insensitive and an IMSI may be given without its prefix.
+// The normalized form always has a lower case prefix and, for a NAI, a lower case realm.
+package identity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	ImsiType = "imsi"
+	NaiType  = "nai"
+
+	MIN_IMSI_LENGTH = 6
+	MAX_IMSI_LENGTH = 15
+)
+
+var (
+	imsiPattern          = regexp.MustCompile("^[0-9]+$")
+	naiUsernamePattern   = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+/=?^_`{|}~.-]+$")
+	naiRealmLabelPattern = regexp.MustCompile("^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$")
+)
+
+// Supi is a validated subscription permanent identifier
+type Supi struct {
+	Type  string
+	Value string
+}
+
+// Plmn is a PLMN identifier against which the home network of an IMSI is checked
+type Plmn struct {
+	Mcc string
+	Mnc string
+}
+
+func (plmn Plmn) String() string {
+	return plmn.Mcc + plmn.Mnc
+}
+
+// Parse validates ueId and returns its normalized SUPI
+func Parse(ueId string) (Supi, error) {
+	supiType, value, found := strings.Cut(ueId, "-")
+	if !found {
+		if imsiPattern.MatchString(ueId) {
+			return parseImsi(ueId)
+		}
+		return Supi{}, fmt.Errorf("invalid SUPI %q: expected imsi-<digits> or nai-<username>@<realm>", ueId)
+	}
+	switch strings.ToLower(supiType) {
+	case ImsiType:
+		return parseImsi(value)
+	case NaiType:
+		return parseNai(value)
+	default:
+		return Supi{}, fmt.Errorf("invalid SUPI %q: unsupported type %q, expected %s or %s", ueId, supiType, ImsiType, NaiType)
+	}
+}
+
+// ParseImsi validates an IMSI, with or without its imsi- prefix. The digits of the IMSI
+// are the Value of the returned SUPI.
+func ParseImsi(value string) (Supi, error) {
+	supi, err := Parse(value)
+	if err != nil {
+		return Supi{}, err
+	}
+	if !supi.IsImsi() {
+		return Supi{}, fmt.Errorf("invalid IMSI %q: expected %d to %d digits", value, MIN_IMSI_LENGTH, MAX_IMSI_LENGTH)
+	}
+	return supi, nil
+}
+
+func parseImsi(digits string) (Supi, error) {
+	if !imsiPattern.MatchString(digits) || len(digits) < MIN_IMSI_LENGTH || len(digits) > MAX_IMSI_LENGTH {
+		return Supi{}, fmt.Errorf("invalid IMSI %q: expected %d to %d digits", digits, MIN_IMSI_LENGTH, MAX_IMSI_LENGTH)
+	}
+	return Supi{Type: ImsiType, Value: digits}, nil
+}
+
+func parseNai(nai string) (Supi, error) {
+	username, realm, found := strings.Cut(nai, "@")
+	if !found || !naiUsernamePattern.MatchString(username) {
+		return Supi{}, fmt.Errorf("invalid NAI %q: expected <username>@<realm>", nai)
+	}
+	for _, label := range strings.Split(realm, ".") {
+		if !naiRealmLabelPattern.MatchString(label) {
+			return Supi{}, fmt.Errorf("invalid NAI %q: invalid realm %q", nai, realm)
+		}
+	}
+	return Supi{Type: NaiType, Value: username + "@" + strings.ToLower(realm)}, nil
+}
+
+// String returns the normalized SUPI, as stored in the ueId field of the database
+func (supi Supi) String() string {
+	return supi.Type + "-" + supi.Value
+}
+
+func (supi Supi) IsImsi() bool {
+	return supi.Type == ImsiType
+}
+
+// CheckPlmn verifies that the MCC and MNC of an IMSI match one of plmns. NAIs and
+// empty PLMN lists are not checked.
+func (supi Supi) CheckPlmn(plmns []Plmn) error {
+	if !supi.IsImsi() || len(plmns) == 0 {
+		return nil
+	}
+	for _, plmn := range plmns {
+		if strings.HasPrefix(supi.Value, plmn.String()) {
+			return nil
+		}
+	}
+	configured := make([]string, 0, len(plmns))
+	for _, plmn := range plmns {
+		configured = append(configured, plmn.String())
+	}
+	return fmt.Errorf("IMSI %s does not match any configured PLMN (%s)", supi.Value, strings.Join(configured, ", "))
+}
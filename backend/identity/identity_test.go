@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package identity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		ueId          string
+		expected      string
+		expectedError string
+	}{
+		{name: "IMSI", ueId: "imsi-208930100007487", expected: "imsi-208930100007487"},
+		{name: "IMSI with upper case prefix", ueId: "IMSI-208930100007487", expected: "imsi-208930100007487"},
+		{name: "IMSI without prefix", ueId: "208930100007487", expected: "imsi-208930100007487"},
+		{name: "Short IMSI", ueId: "imsi-208930", expected: "imsi-208930"},
+		{name: "NAI", ueId: "nai-user.1@Example.COM", expected: "nai-user.1@example.com"},
+		{name: "IMSI too long", ueId: "imsi-2089301000074870", expectedError: "invalid IMSI"},
+		{name: "IMSI too short", ueId: "imsi-20893", expectedError: "invalid IMSI"},
+		{name: "IMSI with letters", ueId: "imsi-20893010000748a", expectedError: "invalid IMSI"},
+		{name: "Empty IMSI", ueId: "imsi-", expectedError: "invalid IMSI"},
+		{name: "NAI without realm", ueId: "nai-user", expectedError: "invalid NAI"},
+		{name: "NAI with invalid realm", ueId: "nai-user@exa_mple.com", expectedError: "invalid NAI"},
+		{name: "NAI with empty realm label", ueId: "nai-user@example..com", expectedError: "invalid NAI"},
+		{name: "Unsupported type", ueId: "gci-208930100007487", expectedError: "unsupported type"},
+		{name: "Arbitrary string", ueId: "some-subs", expectedError: "unsupported type"},
+		{name: "Empty", ueId: "", expectedError: "invalid SUPI"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			supi, err := Parse(tc.ueId)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if supi.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, supi.String())
+			}
+		})
+	}
+}
+
+func TestParseImsi(t *testing.T) {
+	supi, err := ParseImsi("imsi-001010000000001")
+	if err != nil || supi.Value != "001010000000001" {
+		t.Errorf("expected 001010000000001, got %q (%v)", supi.Value, err)
+	}
+	supi, err = ParseImsi("001010000000001")
+	if err != nil || supi.Value != "001010000000001" {
+		t.Errorf("expected 001010000000001, got %q (%v)", supi.Value, err)
+	}
+	if _, err = ParseImsi("nai-user@example.com"); err == nil {
+		t.Errorf("expected error for a NAI")
+	}
+}
+
+func TestCheckPlmn(t *testing.T) {
+	plmns := []Plmn{{Mcc: "208", Mnc: "93"}, {Mcc: "001", Mnc: "001"}}
+	tests := []struct {
+		name        string
+		ueId        string
+		plmns       []Plmn
+		expectError bool
+	}{
+		{name: "Matching 2 digit MNC", ueId: "imsi-208930100007487", plmns: plmns},
+		{name: "Matching 3 digit MNC", ueId: "imsi-001001000000001", plmns: plmns},
+		{name: "Unknown PLMN", ueId: "imsi-310260000000001", plmns: plmns, expectError: true},
+		{name: "No configured PLMN", ueId: "imsi-310260000000001"},
+		{name: "NAI is not checked", ueId: "nai-user@example.com", plmns: plmns},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			supi, err := Parse(tc.ueId)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = supi.CheckPlmn(tc.plmns)
+			if tc.expectError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package identity
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ImsiRange is a validated contiguous range of IMSIs of the same length. Only its bounds
+// are kept so that large ranges are never materialized.
+type ImsiRange struct {
+	first  uint64
+	count  uint64
+	digits int
+}
+
+// NewImsiRange validates the range starting at start and ending either at end or after
+// count IMSIs. Exactly one of end and count must be set.
+func NewImsiRange(start string, end string, count uint64) (ImsiRange, error) {
+	startSupi, err := ParseImsi(start)
+	if err != nil {
+		return ImsiRange{}, fmt.Errorf("invalid IMSI range start: %w", err)
+	}
+	digits := len(startSupi.Value)
+	first, err := strconv.ParseUint(startSupi.Value, 10, 64)
+	if err != nil {
+		return ImsiRange{}, fmt.Errorf("invalid IMSI range start: %w", err)
+	}
+	switch {
+	case end != "" && count != 0:
+		return ImsiRange{}, fmt.Errorf("IMSI range starting at %s must set either end or count", start)
+	case end != "":
+		endSupi, err := ParseImsi(end)
+		if err != nil {
+			return ImsiRange{}, fmt.Errorf("invalid IMSI range end: %w", err)
+		}
+		if len(endSupi.Value) != digits {
+			return ImsiRange{}, fmt.Errorf("IMSI range %s-%s: start and end must have the same length", startSupi.Value, endSupi.Value)
+		}
+		last, err := strconv.ParseUint(endSupi.Value, 10, 64)
+		if err != nil {
+			return ImsiRange{}, fmt.Errorf("invalid IMSI range end: %w", err)
+		}
+		if last < first {
+			return ImsiRange{}, fmt.Errorf("IMSI range %s-%s: end is lower than start", startSupi.Value, endSupi.Value)
+		}
+		count = last - first + 1
+	case count != 0:
+		if count-1 > maxImsiValue(digits)-first {
+			return ImsiRange{}, fmt.Errorf("IMSI range starting at %s: %d IMSIs exceed %d digits", startSupi.Value, count, digits)
+		}
+	default:
+		return ImsiRange{}, fmt.Errorf("IMSI range starting at %s must set either end or count", start)
+	}
+	return ImsiRange{first: first, count: count, digits: digits}, nil
+}
+
+func maxImsiValue(digits int) uint64 {
+	value := uint64(1)
+	for range digits {
+		value *= 10
+	}
+	return value - 1
+}
+
+func (r ImsiRange) format(value uint64) string {
+	return fmt.Sprintf("%0*d", r.digits, value)
+}
+
+// Start returns the first IMSI of the range
+func (r ImsiRange) Start() string {
+	return r.format(r.first)
+}
+
+// End returns the last IMSI of the range
+func (r ImsiRange) End() string {
+	return r.format(r.first + r.count - 1)
+}
+
+// Len returns the number of IMSIs in the range
+func (r ImsiRange) Len() uint64 {
+	return r.count
+}
+
+// Contains reports whether imsi, given without its imsi- prefix, belongs to the range
+func (r ImsiRange) Contains(imsi string) bool {
+	if len(imsi) != r.digits || !imsiPattern.MatchString(imsi) {
+		return false
+	}
+	value, err := strconv.ParseUint(imsi, 10, 64)
+	if err != nil {
+		return false
+	}
+	return value >= r.first && value-r.first < r.count
+}
+
+// Intersect returns the IMSIs belonging to both ranges, and false if they have none in common
+func (r ImsiRange) Intersect(other ImsiRange) (ImsiRange, bool) {
+	if r.digits != other.digits {
+		return ImsiRange{}, false
+	}
+	first := max(r.first, other.first)
+	last := min(r.first+r.count-1, other.first+other.count-1)
+	if first > last {
+		return ImsiRange{}, false
+	}
+	return ImsiRange{first: first, count: last - first + 1, digits: r.digits}, true
+}
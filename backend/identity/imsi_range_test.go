@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package identity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewImsiRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         string
+		end           string
+		count         uint64
+		expectedEnd   string
+		expectedLen   uint64
+		expectedError string
+	}{
+		{name: "Start and end", start: "208930100000001", end: "208930100000100", expectedEnd: "208930100000100", expectedLen: 100},
+		{name: "Start and count", start: "imsi-208930100000001", count: 100, expectedEnd: "208930100000100", expectedLen: 100},
+		{name: "Single IMSI", start: "208930100000001", end: "208930100000001", expectedEnd: "208930100000001", expectedLen: 1},
+		{name: "Leading zeros", start: "001010000000001", count: 9, expectedEnd: "001010000000009", expectedLen: 9},
+		{name: "Up to the last IMSI", start: "999999999999990", count: 10, expectedEnd: "999999999999999", expectedLen: 10},
+		{name: "Both end and count", start: "208930100000001", end: "208930100000100", count: 100, expectedError: "either end or count"},
+		{name: "Neither end nor count", start: "208930100000001", expectedError: "either end or count"},
+		{name: "Invalid start", start: "20893010000000a", count: 1, expectedError: "invalid IMSI range start"},
+		{name: "Invalid end", start: "208930100000001", end: "20893010000010a", expectedError: "invalid IMSI range end"},
+		{name: "Different lengths", start: "208930100000001", end: "20893010000100", expectedError: "same length"},
+		{name: "End lower than start", start: "208930100000100", end: "208930100000001", expectedError: "lower than start"},
+		{name: "Count exceeding the digits", start: "999999999999990", count: 11, expectedError: "exceed 15 digits"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			imsiRange, err := NewImsiRange(tc.start, tc.end, tc.count)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if imsiRange.Start() != strings.TrimPrefix(tc.start, "imsi-") {
+				t.Errorf("expected start %s, got %s", tc.start, imsiRange.Start())
+			}
+			if imsiRange.End() != tc.expectedEnd {
+				t.Errorf("expected end %s, got %s", tc.expectedEnd, imsiRange.End())
+			}
+			if imsiRange.Len() != tc.expectedLen {
+				t.Errorf("expected %d IMSIs, got %d", tc.expectedLen, imsiRange.Len())
+			}
+		})
+	}
+}
+
+func TestImsiRangeContains(t *testing.T) {
+	imsiRange, err := NewImsiRange("001010000000010", "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		imsi     string
+		expected bool
+	}{
+		{imsi: "001010000000010", expected: true},
+		{imsi: "001010000000015", expected: true},
+		{imsi: "001010000000019", expected: true},
+		{imsi: "001010000000009", expected: false},
+		{imsi: "001010000000020", expected: false},
+		{imsi: "00101000000001", expected: false},
+		{imsi: "0010100000000150", expected: false},
+		{imsi: "00101000000001a", expected: false},
+	}
+	for _, tc := range tests {
+		if imsiRange.Contains(tc.imsi) != tc.expected {
+			t.Errorf("expected Contains(%s) to be %t", tc.imsi, tc.expected)
+		}
+	}
+}
+
+func TestImsiRangeIntersect(t *testing.T) {
+	imsiRange, err := NewImsiRange("001010000000010", "001010000000019", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name          string
+		start         string
+		end           string
+		expectedStart string
+		expectedEnd   string
+	}{
+		{name: "Overlapping start", start: "001010000000005", end: "001010000000012", expectedStart: "001010000000010", expectedEnd: "001010000000012"},
+		{name: "Contained", start: "001010000000015", end: "001010000000015", expectedStart: "001010000000015", expectedEnd: "001010000000015"},
+		{name: "Overlapping end", start: "001010000000019", end: "001010000000030", expectedStart: "001010000000019", expectedEnd: "001010000000019"},
+		{name: "Disjoint", start: "001010000000020", end: "001010000000030"},
+		{name: "Different length", start: "00101000000001", end: "00101000000001"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			other, err := NewImsiRange(tc.start, tc.end, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			shared, overlaps := imsiRange.Intersect(other)
+			if overlaps != (tc.expectedStart != "") {
+				t.Fatalf("expected overlap %t, got %t", tc.expectedStart != "", overlaps)
+			}
+			if overlaps && (shared.Start() != tc.expectedStart || shared.End() != tc.expectedEnd) {
+				t.Errorf("expected %s-%s, got %s-%s", tc.expectedStart, tc.expectedEnd, shared.Start(), shared.End())
+			}
+		})
+	}
+}
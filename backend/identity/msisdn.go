@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package identity
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	MsisdnType = "msisdn"
+
+	MIN_MSISDN_LENGTH = 5
+	MAX_MSISDN_LENGTH = 15
+)
+
+// ParseMsisdn validates an MSISDN, with or without its msisdn- prefix and a leading +,
+// and returns it as a GPSI of the form msisdn-<digits>
+func ParseMsisdn(value string) (string, error) {
+	digits := value
+	if gpsiType, msisdn, found := strings.Cut(value, "-"); found && strings.EqualFold(gpsiType, MsisdnType) {
+		digits = msisdn
+	}
+	digits = strings.TrimPrefix(digits, "+")
+	if len(digits) < MIN_MSISDN_LENGTH || len(digits) > MAX_MSISDN_LENGTH || !imsiPattern.MatchString(digits) {
+		return "", fmt.Errorf("invalid MSISDN %q: expected %d to %d digits", value, MIN_MSISDN_LENGTH, MAX_MSISDN_LENGTH)
+	}
+	return MsisdnType + "-" + digits, nil
+}
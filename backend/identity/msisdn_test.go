@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package identity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMsisdn(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      string
+		expectedError string
+	}{
+		{name: "Digits", value: "0900000001", expected: "msisdn-0900000001"},
+		{name: "GPSI", value: "msisdn-0900000001", expected: "msisdn-0900000001"},
+		{name: "International format", value: "+33612345678", expected: "msisdn-33612345678"},
+		{name: "Upper case prefix", value: "MSISDN-+33612345678", expected: "msisdn-33612345678"},
+		{name: "Too short", value: "1234", expectedError: "invalid MSISDN"},
+		{name: "Too long", value: "1234567890123456", expectedError: "invalid MSISDN"},
+		{name: "Not digits", value: "09000000a1", expectedError: "invalid MSISDN"},
+		{name: "Other GPSI type", value: "extid-0900000001", expectedError: "invalid MSISDN"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gpsi, err := ParseMsisdn(tc.value)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gpsi != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, gpsi)
+			}
+		})
+	}
+}
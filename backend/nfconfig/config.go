@@ -6,6 +6,7 @@
 package nfconfig
 
 import (
+	"cmp"
 	"fmt"
 	"slices"
 	"sort"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/omec-project/openapi/v2/nfConfigApi"
 	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/configapi"
 	"github.com/omec-project/webconsole/configmodels"
@@ -30,9 +32,23 @@ type accessAndMobilityKey struct {
 }
 
 type imsiQosConfig struct {
-	imsis []string
-	dnn   string
-	qos   []nfConfigApi.ImsiQos
+	imsis      []string
+	imsiRanges []identity.ImsiRange
+	dnn        string
+	qos        []nfConfigApi.ImsiQos
+}
+
+// containsImsi reports whether imsi is listed or belongs to one of the IMSI ranges
+func (c imsiQosConfig) containsImsi(imsi string) bool {
+	if slices.Contains(c.imsis, imsi) {
+		return true
+	}
+	for _, imsiRange := range c.imsiRanges {
+		if imsiRange.Contains(imsi) {
+			return true
+		}
+	}
+	return false
 }
 
 type inMemoryConfig struct {
@@ -64,15 +80,27 @@ var defaultPccRule = nfConfigApi.NewPccRule(
 	255,
 )
 
+// sync rebuilds the configuration of every endpoint from the network slices and device groups
+func (c *inMemoryConfig) sync(slices []configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) {
+	c.syncPlmn(slices)
+	c.syncPlmnSnssai(slices)
+	c.syncAccessAndMobility(slices)
+	c.syncSessionManagement(slices, deviceGroups)
+	c.syncPolicyControl(slices, deviceGroups)
+	c.syncImsiQos(deviceGroups)
+}
+
 func (c *inMemoryConfig) syncPlmn(slices []configmodels.Slice) {
 	plmnSet := make(map[string]struct{})
 	newPlmnConfig := []nfConfigApi.PlmnId{}
 	for _, s := range slices {
-		plmn := *nfConfigApi.NewPlmnId(s.SiteInfo.Plmn.Mcc, s.SiteInfo.Plmn.Mnc)
-		plmnKey := plmn.GetMcc() + ":" + plmn.GetMnc()
-		if _, exists := plmnSet[plmnKey]; !exists {
-			plmnSet[plmnKey] = struct{}{}
-			newPlmnConfig = append(newPlmnConfig, plmn)
+		for _, slicePlmn := range s.SiteInfo.PlmnList() {
+			plmn := *nfConfigApi.NewPlmnId(slicePlmn.Mcc, slicePlmn.Mnc)
+			plmnKey := plmn.GetMcc() + ":" + plmn.GetMnc()
+			if _, exists := plmnSet[plmnKey]; !exists {
+				plmnSet[plmnKey] = struct{}{}
+				newPlmnConfig = append(newPlmnConfig, plmn)
+			}
 		}
 	}
 
@@ -90,11 +118,12 @@ func (c *inMemoryConfig) syncPlmn(slices []configmodels.Slice) {
 func (c *inMemoryConfig) syncPlmnSnssai(slices []configmodels.Slice) {
 	plmnMap := make(map[configmodels.SliceSiteInfoPlmn]map[configmodels.SliceSliceId]struct{})
 	for _, s := range slices {
-		plmn := s.SiteInfo.Plmn
-		if plmnMap[plmn] == nil {
-			plmnMap[plmn] = map[configmodels.SliceSliceId]struct{}{}
+		for _, plmn := range s.SiteInfo.PlmnList() {
+			if plmnMap[plmn] == nil {
+				plmnMap[plmn] = map[configmodels.SliceSliceId]struct{}{}
+			}
+			plmnMap[plmn][s.SliceId] = struct{}{}
 		}
-		plmnMap[plmn][s.SliceId] = struct{}{}
 	}
 
 	c.plmnSnssai = convertPlmnMapToSortedList(plmnMap)
@@ -168,26 +197,37 @@ func sortPlmnSnssaiConfig(plmnSnssai []nfConfigApi.PlmnSnssai) {
 
 func (c *inMemoryConfig) syncAccessAndMobility(networkSlices []configmodels.Slice) {
 	plmnSnssaiTacsMap := map[accessAndMobilityKey]map[string]struct{}{}
+	equivalentPlmnsMap := map[accessAndMobilityKey][]configmodels.SliceSiteInfoPlmn{}
 	for _, s := range networkSlices {
-		accessAndMobilityTmp := accessAndMobilityKey{
-			plmn:    s.SiteInfo.Plmn,
-			sliceId: s.SliceId,
-		}
-		if plmnSnssaiTacsMap[accessAndMobilityTmp] != nil {
-			logger.NfConfigLog.Warnf("Found duplicate Network slice `%+v` for PLMN `%+v`, merging TACs for Access and Mobility", s.SliceId, s.SiteInfo.Plmn)
-		} else {
-			plmnSnssaiTacsMap[accessAndMobilityTmp] = map[string]struct{}{}
-		}
-		for _, g := range s.SiteInfo.GNodeBs {
-			tac := strconv.Itoa(int(g.Tac))
-			plmnSnssaiTacsMap[accessAndMobilityTmp][tac] = struct{}{}
+		for _, plmn := range s.SiteInfo.PlmnList() {
+			accessAndMobilityTmp := accessAndMobilityKey{
+				plmn:    plmn,
+				sliceId: s.SliceId,
+			}
+			if plmnSnssaiTacsMap[accessAndMobilityTmp] != nil {
+				logger.NfConfigLog.Warnf("Found duplicate Network slice `%+v` for PLMN `%+v`, merging TACs for Access and Mobility", s.SliceId, plmn)
+			} else {
+				plmnSnssaiTacsMap[accessAndMobilityTmp] = map[string]struct{}{}
+			}
+			for _, g := range s.SiteInfo.GNodeBs {
+				tac := strconv.Itoa(int(g.Tac))
+				plmnSnssaiTacsMap[accessAndMobilityTmp][tac] = struct{}{}
+			}
+			for _, equivalentPlmn := range s.SiteInfo.EquivalentPlmns {
+				if equivalentPlmn != plmn && !slices.Contains(equivalentPlmnsMap[accessAndMobilityTmp], equivalentPlmn) {
+					equivalentPlmnsMap[accessAndMobilityTmp] = append(equivalentPlmnsMap[accessAndMobilityTmp], equivalentPlmn)
+				}
+			}
 		}
 	}
-	c.accessAndMobility = convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiTacsMap)
+	c.accessAndMobility = convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiTacsMap, equivalentPlmnsMap)
 	logger.NfConfigLog.Debugf("Updated Access and Mobility in-memory configuration. New configuration: %+v", c.accessAndMobility)
 }
 
-func convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiMap map[accessAndMobilityKey]map[string]struct{}) []nfConfigApi.AccessAndMobility {
+// convertPlmnSnssaiTacsMapToSortedList builds the Access and Mobility configuration of every
+// PLMN and S-NSSAI pair. The equivalent PLMNs of a pair, if any, are listed in its
+// equivalentPlmns property.
+func convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiMap map[accessAndMobilityKey]map[string]struct{}, equivalentPlmnsMap map[accessAndMobilityKey][]configmodels.SliceSiteInfoPlmn) []nfConfigApi.AccessAndMobility {
 	newAccessAndMobilityConfig := []nfConfigApi.AccessAndMobility{}
 	for plmnSliceId, tacSet := range plmnSnssaiMap {
 		plmnId := nfConfigApi.NewPlmnId(plmnSliceId.plmn.Mcc, plmnSliceId.plmn.Mnc)
@@ -202,12 +242,30 @@ func convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiMap map[accessAndMobilityKey
 			tacList = append(tacList, tac)
 		}
 		accessAndMobility.Tacs = tacList
+		if equivalentPlmns := equivalentPlmnsMap[plmnSliceId]; len(equivalentPlmns) > 0 {
+			accessAndMobility.AdditionalProperties = map[string]any{"equivalentPlmns": convertPlmns(equivalentPlmns)}
+		}
 		newAccessAndMobilityConfig = append(newAccessAndMobilityConfig, *accessAndMobility)
 	}
 	sortAccessAndMobilityConfig(newAccessAndMobilityConfig)
 	return newAccessAndMobilityConfig
 }
 
+// convertPlmns returns plmns as PLMN IDs sorted by MCC and MNC
+func convertPlmns(plmns []configmodels.SliceSiteInfoPlmn) []nfConfigApi.PlmnId {
+	plmnIds := make([]nfConfigApi.PlmnId, 0, len(plmns))
+	for _, plmn := range plmns {
+		plmnIds = append(plmnIds, *nfConfigApi.NewPlmnId(plmn.Mcc, plmn.Mnc))
+	}
+	sort.Slice(plmnIds, func(i, j int) bool {
+		if plmnIds[i].GetMcc() != plmnIds[j].GetMcc() {
+			return plmnIds[i].GetMcc() < plmnIds[j].GetMcc()
+		}
+		return plmnIds[i].GetMnc() < plmnIds[j].GetMnc()
+	})
+	return plmnIds
+}
+
 func sortAccessAndMobilityConfig(accessAndMobility []nfConfigApi.AccessAndMobility) {
 	sort.Slice(accessAndMobility, func(i, j int) bool {
 		if accessAndMobility[i].PlmnId.GetMcc() != accessAndMobility[j].PlmnId.GetMcc() {
@@ -233,20 +291,39 @@ func (c *inMemoryConfig) syncSessionManagement(slices []configmodels.Slice, devi
 	sessionConfigs := make([]nfConfigApi.SessionManagement, 0, len(slices))
 
 	for _, slice := range slices {
-		session, ok := buildSessionManagementConfig(slice, deviceGroupMap)
-		if ok {
-			sessionConfigs = append(sessionConfigs, *session)
-		}
+		sessionConfigs = append(sessionConfigs, buildSessionManagementConfigs(slice, deviceGroupMap)...)
 	}
 
 	sort.Slice(sessionConfigs, func(i, j int) bool {
-		return sessionConfigs[i].GetSliceName() < sessionConfigs[j].GetSliceName()
+		if sessionConfigs[i].GetSliceName() != sessionConfigs[j].GetSliceName() {
+			return sessionConfigs[i].GetSliceName() < sessionConfigs[j].GetSliceName()
+		}
+		if sessionConfigs[i].PlmnId.GetMcc() != sessionConfigs[j].PlmnId.GetMcc() {
+			return sessionConfigs[i].PlmnId.GetMcc() < sessionConfigs[j].PlmnId.GetMcc()
+		}
+		return sessionConfigs[i].PlmnId.GetMnc() < sessionConfigs[j].PlmnId.GetMnc()
 	})
 
 	c.sessionManagement = sessionConfigs
 	logger.NfConfigLog.Debugf("updated Session Management configuration with %d slices: %+v", len(sessionConfigs), c.sessionManagement)
 }
 
+// buildSessionManagementConfigs returns the Session Management configuration of slice in
+// each of its PLMNs
+func buildSessionManagementConfigs(slice configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups) []nfConfigApi.SessionManagement {
+	session, ok := buildSessionManagementConfig(slice, deviceGroupMap)
+	if !ok {
+		return nil
+	}
+	sessions := []nfConfigApi.SessionManagement{}
+	for _, plmn := range slice.SiteInfo.PlmnList() {
+		plmnSession := *session
+		plmnSession.SetPlmnId(*nfConfigApi.NewPlmnId(plmn.Mcc, plmn.Mnc))
+		sessions = append(sessions, plmnSession)
+	}
+	return sessions
+}
+
 func buildSessionManagementConfig(slice configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups) (*nfConfigApi.SessionManagement, bool) {
 	plmn := nfConfigApi.NewPlmnId(slice.SiteInfo.Plmn.Mcc, slice.SiteInfo.Plmn.Mnc)
 
@@ -261,7 +338,9 @@ func buildSessionManagementConfig(slice configmodels.Slice, deviceGroupMap map[s
 		session.SetIpDomain(ipDomains)
 	}
 
-	if upf := extractUpf(slice); upf != nil {
+	if len(slice.SiteInfo.Upfs) > 0 {
+		setUpfs(session, slice)
+	} else if upf := extractUpf(slice); upf != nil {
 		session.SetUpf(*upf)
 	}
 
@@ -291,55 +370,91 @@ func extractIpDomains(groupNames []string, deviceGroupMap map[string]configmodel
 			if ipDomainExp.PcscfPrimary != "" {
 				ip.SetPcscfIpv4(ipDomainExp.PcscfPrimary)
 			}
+			setIpv6Domain(ip, ipDomainExp)
 			ipDomains = append(ipDomains, *ip)
 		}
 	}
 	return ipDomains
 }
 
+// setIpv6Domain adds the IPv6 UE pool, DNS and P-CSCF and the PDU session types of
+// ipDomainExp to ip. The IP domain model has no such fields, so they are carried as
+// additional properties, only set when the DNN is not IPv4 only.
+func setIpv6Domain(ip *nfConfigApi.IpDomain, ipDomainExp configmodels.DeviceGroupsIpDomainExpanded) {
+	properties := make(map[string]any)
+	if ipDomainExp.UeIpv6Pool != "" {
+		properties["ueSubnetIpv6"] = ipDomainExp.UeIpv6Pool
+	}
+	if ipDomainExp.DnsPrimaryIpv6 != "" {
+		properties["dnsIpv6"] = ipDomainExp.DnsPrimaryIpv6
+	}
+	if ipDomainExp.PcscfPrimaryIpv6 != "" {
+		properties["pcscfIpv6"] = ipDomainExp.PcscfPrimaryIpv6
+	}
+	if len(ipDomainExp.PduSessionTypes) > 0 {
+		properties["pduSessionTypes"] = ipDomainExp.AllowedPduSessionTypes()
+	}
+	if len(properties) > 0 {
+		ip.AdditionalProperties = properties
+	}
+}
+
 func extractUpf(slice configmodels.Slice) *nfConfigApi.Upf {
-	upfMap := slice.SiteInfo.Upf
-	if upfMap == nil {
+	sliceUpf := slice.SiteInfo.Upf
+	if sliceUpf == nil {
 		logger.NfConfigLog.Warnf("no UPF defined for slice %s", slice.SliceName)
 		return nil
 	}
-	hostnameRaw, ok := upfMap["upf-name"]
-	if !ok {
+	if sliceUpf.UpfName == "" {
 		logger.NfConfigLog.Warnf("missing UPF hostname for slice %s", slice.SliceName)
 		return nil
 	}
-	hostname, ok := hostnameRaw.(string)
-	if !ok || hostname == "" {
-		logger.NfConfigLog.Warnf("invalid UPF hostname for slice %s: %v", slice.SliceName, hostnameRaw)
-		return nil
+	upf := nfConfigApi.NewUpf(sliceUpf.UpfName)
+	if sliceUpf.UpfPort != "" {
+		if port, err := strconv.ParseUint(string(sliceUpf.UpfPort), 10, 16); err == nil {
+			upf.SetPort(int32(port))
+		} else {
+			logger.NfConfigLog.Warnf("invalid UPF port for slice %s: %+v", slice.SliceName, err)
+		}
 	}
-	upf := nfConfigApi.NewUpf(hostname)
+	return upf
+}
 
-	if portRaw, ok := upfMap["upf-port"]; ok {
-		switch v := portRaw.(type) {
-		case string:
-			if port, err := strconv.ParseUint(v, 10, 16); err == nil {
+// setUpfs sets the UPFs of the network slice, sorted by priority then decreasing weight, as
+// the upfs additional property of session, the first one also being its UPF. The UPF model
+// has no priority, weight or DNN fields, so they are carried as additional properties.
+func setUpfs(session *nfConfigApi.SessionManagement, slice configmodels.Slice) {
+	sliceUpfs := slices.Clone(slice.SiteInfo.Upfs)
+	slices.SortStableFunc(sliceUpfs, func(a, b configmodels.SliceSiteInfoUpf) int {
+		return cmp.Or(
+			cmp.Compare(a.Priority, b.Priority),
+			cmp.Compare(b.Weight, a.Weight),
+			cmp.Compare(a.UpfName, b.UpfName),
+		)
+	})
+	upfs := make([]nfConfigApi.Upf, 0, len(sliceUpfs))
+	for _, sliceUpf := range sliceUpfs {
+		upf := nfConfigApi.NewUpf(sliceUpf.UpfName)
+		if sliceUpf.UpfPort != "" {
+			if port, err := strconv.ParseUint(sliceUpf.UpfPort, 10, 16); err == nil {
 				upf.SetPort(int32(port))
 			} else {
-				logger.NfConfigLog.Warnf("invalid UPF port for slice %s: %+v", slice.SliceName, err)
-			}
-		case float64:
-			if v >= 0 && v <= 65535 {
-				upf.SetPort(int32(v))
-			} else {
-				logger.NfConfigLog.Warnf("UPF port out of valid range (0-65535) for slice %s: %v", slice.SliceName, v)
-			}
-		case int:
-			if v >= 0 && v <= 65535 {
-				upf.SetPort(int32(v))
-			} else {
-				logger.NfConfigLog.Warnf("UPF port out of valid range (0-65535) for slice %s: %v", slice.SliceName, v)
+				logger.NfConfigLog.Warnf("invalid port of UPF %s for slice %s: %+v", sliceUpf.UpfName, slice.SliceName, err)
 			}
-		default:
-			logger.NfConfigLog.Warnf("UPF port should be a string or number for slice %s, got: %T", slice.SliceName, v)
 		}
+		if len(upfs) == 0 {
+			session.SetUpf(*upf)
+		}
+		upf.AdditionalProperties = map[string]any{
+			"priority": sliceUpf.Priority,
+			"weight":   sliceUpf.Weight,
+		}
+		if len(sliceUpf.Dnns) > 0 {
+			upf.AdditionalProperties["dnns"] = sliceUpf.Dnns
+		}
+		upfs = append(upfs, *upf)
 	}
-	return upf
+	session.AdditionalProperties = map[string]any{"upfs": upfs}
 }
 
 func extractGnbNames(slice configmodels.Slice) []string {
@@ -355,10 +470,7 @@ func (c *inMemoryConfig) syncPolicyControl(slices []configmodels.Slice, deviceGr
 	policyControlConfigs := []nfConfigApi.PolicyControl{}
 
 	for _, slice := range slices {
-		policyControl, ok := buildPolicyControlConfig(slice, deviceGroupMap)
-		if ok {
-			policyControlConfigs = append(policyControlConfigs, *policyControl)
-		}
+		policyControlConfigs = append(policyControlConfigs, buildPolicyControlConfigs(slice, deviceGroupMap)...)
 	}
 	sortPolicyControl(policyControlConfigs)
 	c.policyControl = policyControlConfigs
@@ -383,19 +495,31 @@ func sortPolicyControl(policyControl []nfConfigApi.PolicyControl) {
 	})
 }
 
-func buildPolicyControlConfig(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) (*nfConfigApi.PolicyControl, bool) {
-	plmn := nfConfigApi.NewPlmnId(slice.SiteInfo.Plmn.Mcc, slice.SiteInfo.Plmn.Mnc)
-
+// buildPolicyControlConfigs returns the Policy Control configuration of slice in each of its
+// PLMNs. The session AMBR of the default QoS of slice is given as the sessionAmbr property.
+func buildPolicyControlConfigs(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) []nfConfigApi.PolicyControl {
 	snssai, err := parseSnssaiFromSlice(slice.SliceId)
 	if err != nil {
 		logger.NfConfigLog.Errorf("invalid SNSSAI for slice %s: %+v", slice.SliceName, err)
-		return nil, false
+		return nil
 	}
 	pccRules := buildSlicePccRules(slice)
 	dnns := getSupportedDnns(slice, deviceGroups)
-	policyControl := nfConfigApi.NewPolicyControl(*plmn, snssai, dnns, pccRules)
-
-	return policyControl, true
+	policyControls := []nfConfigApi.PolicyControl{}
+	for _, plmn := range slice.SiteInfo.PlmnList() {
+		plmnId := nfConfigApi.NewPlmnId(plmn.Mcc, plmn.Mnc)
+		policyControl := nfConfigApi.NewPolicyControl(*plmnId, snssai, dnns, pccRules)
+		if slice.Qos != nil && (slice.Qos.Uplink != 0 || slice.Qos.Downlink != 0) {
+			policyControl.AdditionalProperties = map[string]any{
+				"sessionAmbr": map[string]string{
+					"uplink":   configapi.ConvertToString(uint64(slice.Qos.Uplink)),
+					"downlink": configapi.ConvertToString(uint64(slice.Qos.Downlink)),
+				},
+			}
+		}
+		policyControls = append(policyControls, *policyControl)
+	}
+	return policyControls
 }
 
 func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
@@ -413,7 +537,7 @@ func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
 
 	// If slice has no PCC rules, add a default one
 	if len(pccRules) == 0 {
-		pccRules = append(pccRules, *defaultPccRule)
+		pccRules = append(pccRules, sliceDefaultPccRule(slice))
 	}
 	sort.Slice(pccRules, func(i, j int) bool {
 		if pccRules[i].Precedence != pccRules[j].Precedence {
@@ -424,6 +548,30 @@ func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
 	return pccRules
 }
 
+// sliceDefaultPccRule returns defaultPccRule with the 5QI, ARP and session AMBR of the
+// default QoS of slice, if it has one
+func sliceDefaultPccRule(slice configmodels.Slice) nfConfigApi.PccRule {
+	if slice.Qos == nil || slice.Qos.TrafficClass == nil {
+		return *defaultPccRule
+	}
+	pccRule := *defaultPccRule
+	pccRule.Qos = *nfConfigApi.NewPccQos(
+		slice.Qos.TrafficClass.Qci,
+		*nfConfigApi.NewArp(
+			slice.Qos.TrafficClass.Arp,
+			nfConfigApi.PREEMPTCAP_MAY_PREEMPT,
+			nfConfigApi.PREEMPTVULN_PREEMPTABLE,
+		),
+	)
+	if slice.Qos.Uplink != 0 {
+		pccRule.Qos.SetMaxBrUl(configapi.ConvertToString(uint64(slice.Qos.Uplink)))
+	}
+	if slice.Qos.Downlink != 0 {
+		pccRule.Qos.SetMaxBrDl(configapi.ConvertToString(uint64(slice.Qos.Downlink)))
+	}
+	return pccRule
+}
+
 func buildPccFlows(ruleConfig configmodels.SliceApplicationFilteringRules) []nfConfigApi.PccFlow {
 	pccFlows := []nfConfigApi.PccFlow{}
 
@@ -514,6 +662,15 @@ func (c *inMemoryConfig) syncImsiQos(deviceGroupMap map[string]configmodels.Devi
 		if len(dg.IpDomainsExpanded) == 0 {
 			continue
 		}
+		var imsiRanges []identity.ImsiRange
+		for _, imsiRange := range dg.ImsiRanges {
+			parsedRange, err := imsiRange.Parse()
+			if err != nil {
+				logger.NfConfigLog.Warnf("skipping invalid IMSI range of device group %s: %+v", dg.DeviceGroupName, err)
+				continue
+			}
+			imsiRanges = append(imsiRanges, parsedRange)
+		}
 
 		for _, ipDom := range dg.IpDomainsExpanded {
 			imsiQos, ok := extractQosConfigFromIpDomain(ipDom)
@@ -522,9 +679,10 @@ func (c *inMemoryConfig) syncImsiQos(deviceGroupMap map[string]configmodels.Devi
 			}
 
 			imsiQosConfigs = append(imsiQosConfigs, imsiQosConfig{
-				imsis: dg.Imsis,
-				dnn:   ipDom.Dnn,
-				qos:   []nfConfigApi.ImsiQos{imsiQos},
+				imsis:      dg.Imsis,
+				imsiRanges: imsiRanges,
+				dnn:        ipDom.Dnn,
+				qos:        []nfConfigApi.ImsiQos{imsiQos},
 			})
 		}
 	}
@@ -150,6 +150,30 @@ func TestAccessAndMobilityConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Network slice with shared RAN and equivalent PLMNs",
+			networkSlices: []configmodels.Slice{
+				func() configmodels.Slice {
+					s := withSharedRanPlmns(makeAccessAndMobilityNetworkSlice("001", "01", "1", "01", []int32{1}), "002", "02")
+					s.SiteInfo.EquivalentPlmns = []configmodels.SliceSiteInfoPlmn{{Mcc: "003", Mnc: "03"}, {Mcc: "002", Mnc: "02"}}
+					return s
+				}(),
+			},
+			expectedAccessAndMobility: []nfConfigApi.AccessAndMobility{
+				{
+					PlmnId:               nfConfigApi.PlmnId{Mcc: "001", Mnc: "01"},
+					Snssai:               makeSnssaiWithSd(1, "01"),
+					Tacs:                 []string{"1"},
+					AdditionalProperties: map[string]any{"equivalentPlmns": []nfConfigApi.PlmnId{{Mcc: "002", Mnc: "02"}, {Mcc: "003", Mnc: "03"}}},
+				},
+				{
+					PlmnId:               nfConfigApi.PlmnId{Mcc: "002", Mnc: "02"},
+					Snssai:               makeSnssaiWithSd(1, "01"),
+					Tacs:                 []string{"1"},
+					AdditionalProperties: map[string]any{"equivalentPlmns": []nfConfigApi.PlmnId{{Mcc: "003", Mnc: "03"}}},
+				},
+			},
+		},
 		{
 			name:                      "Empty slices",
 			networkSlices:             []configmodels.Slice{},
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/configmodels"
 )
 
@@ -100,6 +101,41 @@ func TestSyncImsiQos(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IMSI ranges are kept without being expanded",
+			deviceGroups: []deviceGroupParams{
+				{
+					name:  "dg-1",
+					dnn:   "internet",
+					imsis: []string{"001010123456789"},
+					imsiRanges: []configmodels.ImsiRange{
+						{Start: "001010000000000", Count: 1000000},
+						{Start: "001010000000000"},
+					},
+					dnsPrimary: "8.8.8.8",
+					ueIpPool:   "10.1.1.0/24",
+					mtu:        1500,
+					qos: &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+						DnnMbrUplink:   20000000,
+						DnnMbrDownlink: 200000000,
+						TrafficClass: &configmodels.TrafficClassInfo{
+							Qci: 6,
+							Arp: 9,
+						},
+					},
+				},
+			},
+			expectedResponse: []imsiQosConfig{
+				{
+					imsis:      []string{"001010123456789"},
+					imsiRanges: []identity.ImsiRange{mustImsiRange(t, "001010000000000", "", 1000000)},
+					dnn:        "internet",
+					qos: []nfConfigApi.ImsiQos{
+						*nfConfigApi.NewImsiQos("20 Mbps", "200 Mbps", 6, 9),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,3 +155,12 @@ func TestSyncImsiQos(t *testing.T) {
 		})
 	}
 }
+
+func mustImsiRange(t *testing.T, start string, end string, count uint64) identity.ImsiRange {
+	t.Helper()
+	imsiRange, err := identity.NewImsiRange(start, end, count)
+	if err != nil {
+		t.Fatalf("invalid IMSI range: %v", err)
+	}
+	return imsiRange
+}
@@ -96,6 +96,28 @@ func TestSyncPlmnSnssaiConfig_Success(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Slice with shared RAN PLMNs is listed in each PLMN",
+			slices: []configmodels.Slice{
+				withSharedRanPlmns(makeNetworkSliceWithPlmnSnssai("123", "23", "1", "01234"), "456", "77"),
+				makeNetworkSliceWithPlmnSnssai("456", "77", "2", ""),
+			},
+			expectedPlmnSnssai: []nfConfigApi.PlmnSnssai{
+				{
+					PlmnId: nfConfigApi.PlmnId{Mcc: "123", Mnc: "23"},
+					SNssaiList: []nfConfigApi.Snssai{
+						makeSnssaiWithSd(1, "01234"),
+					},
+				},
+				{
+					PlmnId: nfConfigApi.PlmnId{Mcc: "456", Mnc: "77"},
+					SNssaiList: []nfConfigApi.Snssai{
+						makeSnssaiWithSd(1, "01234"),
+						*nfConfigApi.NewSnssai(2),
+					},
+				},
+			},
+		},
 		{
 			name: "One slice no SD",
 			slices: []configmodels.Slice{
@@ -82,6 +82,17 @@ func TestSyncPlmnConfig_Success(t *testing.T) {
 				{Mcc: "999", Mnc: "455"},
 			},
 		},
+		{
+			name: "Shared RAN PLMNs of a slice are added",
+			slices: []configmodels.Slice{
+				withSharedRanPlmns(makeNetworkSliceWithPlmn("123", "23"), "456", "77", "123", "23"),
+				makeNetworkSliceWithPlmn("456", "77"),
+			},
+			expectedPlmn: []nfConfigApi.PlmnId{
+				{Mcc: "123", Mnc: "23"},
+				{Mcc: "456", Mnc: "77"},
+			},
+		},
 		{
 			name:         "Empty slices",
 			slices:       []configmodels.Slice{},
@@ -264,6 +264,69 @@ func TestSyncPolicyControl(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Network Slice with shared RAN PLMNs produces Policy Control config for each PLMN",
+			networkSlices: []configmodels.Slice{
+				withSharedRanPlmns(makePolicyControlNetworkSlice("128", "01", fmt.Sprintf("%d", testSst), testSd, []string{"testDG"}, []configmodels.SliceApplicationFilteringRules{}), "001", "01"),
+			},
+			deviceGroups: testDeviceGroups,
+			expectedResponse: []nfConfigApi.PolicyControl{
+				{
+					PlmnId:   *nfConfigApi.NewPlmnId("001", "01"),
+					Snssai:   makeSnssaiWithSd(testSst, testSd),
+					Dnns:     []string{testDnnName},
+					PccRules: []nfConfigApi.PccRule{*defaultPccRule},
+				},
+				{
+					PlmnId:   *nfConfigApi.NewPlmnId("128", "01"),
+					Snssai:   makeSnssaiWithSd(testSst, testSd),
+					Dnns:     []string{testDnnName},
+					PccRules: []nfConfigApi.PccRule{*defaultPccRule},
+				},
+			},
+		},
+		{
+			name: "Network Slice with default QoS produces default PCC rule with its 5QI, ARP and session AMBR",
+			networkSlices: []configmodels.Slice{
+				func() configmodels.Slice {
+					s := makePolicyControlNetworkSlice("001", "01", fmt.Sprintf("%d", testSst), testSd, []string{"testDG"}, []configmodels.SliceApplicationFilteringRules{})
+					s.Qos = &configmodels.SliceQos{
+						Uplink:       45000,
+						Downlink:     12000,
+						TrafficClass: &configmodels.TrafficClassInfo{Qci: testRuleQci, Arp: 6},
+					}
+					return s
+				}(),
+			},
+			deviceGroups: testDeviceGroups,
+			expectedResponse: []nfConfigApi.PolicyControl{
+				{
+					PlmnId: *nfConfigApi.NewPlmnId("001", "01"),
+					Snssai: makeSnssaiWithSd(testSst, testSd),
+					Dnns:   []string{testDnnName},
+					PccRules: []nfConfigApi.PccRule{
+						{
+							RuleId: defaultPccRule.RuleId,
+							Flows:  defaultPccRule.Flows,
+							Qos: nfConfigApi.PccQos{
+								FiveQi:  testRuleQci,
+								MaxBrUl: &testMaxBrUl2,
+								MaxBrDl: &testMaxBrDl2,
+								Arp: nfConfigApi.Arp{
+									PriorityLevel: 6,
+									PreemptCap:    nfConfigApi.PREEMPTCAP_MAY_PREEMPT,
+									PreemptVuln:   nfConfigApi.PREEMPTVULN_PREEMPTABLE,
+								},
+							},
+							Precedence: defaultPccRule.Precedence,
+						},
+					},
+					AdditionalProperties: map[string]any{
+						"sessionAmbr": map[string]string{"uplink": testMaxBrUl2, "downlink": testMaxBrDl2},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/omec-project/openapi/v2"
+	"github.com/omec-project/openapi/v2/models"
 	"github.com/omec-project/openapi/v2/nfConfigApi"
 	"github.com/omec-project/webconsole/configmodels"
 )
@@ -21,17 +22,19 @@ type networkSliceParams struct {
 	sst          string
 	sd           string
 	deviceGroups []string
-	upfHostname  any
-	upfPort      any
+	upfHostname  string
+	upfPort      string
 	gnbNames     []string
+	plmns        []configmodels.SliceSiteInfoPlmn
 }
 
 func prepareNetworkSlice(p networkSliceParams) configmodels.Slice {
-	upf := map[string]any{
-		"upf-name": p.upfHostname,
-	}
-	if p.upfPort != "" {
-		upf["upf-port"] = p.upfPort
+	var upf *configmodels.SliceSiteInfoUpfReference
+	if p.upfHostname != "" {
+		upf = &configmodels.SliceSiteInfoUpfReference{
+			UpfName: p.upfHostname,
+			UpfPort: configmodels.UpfPort(p.upfPort),
+		}
 	}
 
 	var gnbs []configmodels.SliceSiteInfoGNodeBs
@@ -55,6 +58,7 @@ func prepareNetworkSlice(p networkSliceParams) configmodels.Slice {
 				Mcc: p.mcc,
 				Mnc: p.mnc,
 			},
+			Plmns:   p.plmns,
 			GNodeBs: gnbs,
 			Upf:     upf,
 		},
@@ -412,53 +416,24 @@ func TestSyncSessionManagement(t *testing.T) {
 			},
 		},
 		{
-			name: "invalid upf hostname (non-string)",
+			name: "slice in several PLMNs",
 			sliceParams: []networkSliceParams{
 				{
-					sliceName:    "slice-4",
-					mcc:          "001",
-					mnc:          "01",
-					sst:          "1",
-					sd:           "010203",
-					upfHostname:  1234,
-					deviceGroups: []string{"dg-1"},
-					gnbNames:     []string{"gnb-1"},
-				},
-			},
-			expectedResponse: []nfConfigApi.SessionManagement{
-				{
-					SliceName: "slice-4",
-					PlmnId: nfConfigApi.PlmnId{
-						Mcc: "001",
-						Mnc: "01",
-					},
-					Snssai: nfConfigApi.Snssai{
-						Sst: 1,
-						Sd:  sharedSd,
+					sliceName:   "slice-1",
+					mcc:         "001",
+					mnc:         "01",
+					sst:         "1",
+					sd:          "010203",
+					upfHostname: "upf.local",
+					plmns: []configmodels.SliceSiteInfoPlmn{
+						{Mcc: "001", Mnc: "02"},
+						{Mcc: "001", Mnc: "01"},
 					},
-					Upf:      nil,
-					GnbNames: []string{"gnb-1"},
-				},
-			},
-		},
-		{
-			name: "int upf port is valid",
-			sliceParams: []networkSliceParams{
-				{
-					sliceName:    "slice-4",
-					mcc:          "001",
-					mnc:          "01",
-					sst:          "1",
-					sd:           "010203",
-					upfHostname:  "hostname.com",
-					upfPort:      5677,
-					deviceGroups: []string{"dg-1"},
-					gnbNames:     []string{"gnb-1"},
 				},
 			},
 			expectedResponse: []nfConfigApi.SessionManagement{
 				{
-					SliceName: "slice-4",
+					SliceName: "slice-1",
 					PlmnId: nfConfigApi.PlmnId{
 						Mcc: "001",
 						Mnc: "01",
@@ -468,44 +443,22 @@ func TestSyncSessionManagement(t *testing.T) {
 						Sd:  sharedSd,
 					},
 					Upf: &nfConfigApi.Upf{
-						Hostname: "hostname.com",
-						Port:     ptr(int32(5677)),
+						Hostname: "upf.local",
 					},
-					GnbNames: []string{"gnb-1"},
-				},
-			},
-		},
-		{
-			name: "float upf port is valid",
-			sliceParams: []networkSliceParams{
-				{
-					sliceName:    "slice-4",
-					mcc:          "001",
-					mnc:          "01",
-					sst:          "1",
-					sd:           "010203",
-					upfHostname:  "hostname.com",
-					upfPort:      1234.00,
-					deviceGroups: []string{"dg-1"},
-					gnbNames:     []string{"gnb-1"},
 				},
-			},
-			expectedResponse: []nfConfigApi.SessionManagement{
 				{
-					SliceName: "slice-4",
+					SliceName: "slice-1",
 					PlmnId: nfConfigApi.PlmnId{
 						Mcc: "001",
-						Mnc: "01",
+						Mnc: "02",
 					},
 					Snssai: nfConfigApi.Snssai{
 						Sst: 1,
 						Sd:  sharedSd,
 					},
 					Upf: &nfConfigApi.Upf{
-						Hostname: "hostname.com",
-						Port:     ptr(int32(1234)),
+						Hostname: "upf.local",
 					},
-					GnbNames: []string{"gnb-1"},
 				},
 			},
 		},
@@ -560,3 +513,97 @@ func TestSyncSessionManagement(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractIpDomains_DualStack(t *testing.T) {
+	deviceGroupMap := map[string]configmodels.DeviceGroups{
+		"dg-1": {
+			IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
+				{
+					Dnn:              "internet",
+					DnsPrimary:       "8.8.8.8",
+					UeIpPool:         "10.1.1.0/24",
+					UeIpv6Pool:       "2001:db8:1::/48",
+					DnsPrimaryIpv6:   "2001:4860:4860::8888",
+					PcscfPrimaryIpv6: "2001:db8::10",
+					PduSessionTypes:  []models.PduSessionType{models.PDUSESSIONTYPE_IPV4_V6, models.PDUSESSIONTYPE_IPV4},
+					Mtu:              1500,
+				},
+				{
+					Dnn:        "ims",
+					DnsPrimary: "8.8.4.4",
+					UeIpPool:   "10.1.2.0/24",
+					Mtu:        1400,
+				},
+			},
+		},
+	}
+	expected := []nfConfigApi.IpDomain{
+		{
+			DnnName:  "internet",
+			DnsIpv4:  "8.8.8.8",
+			UeSubnet: "10.1.1.0/24",
+			Mtu:      1500,
+			AdditionalProperties: map[string]any{
+				"ueSubnetIpv6":    "2001:db8:1::/48",
+				"dnsIpv6":         "2001:4860:4860::8888",
+				"pcscfIpv6":       "2001:db8::10",
+				"pduSessionTypes": []models.PduSessionType{models.PDUSESSIONTYPE_IPV4_V6, models.PDUSESSIONTYPE_IPV4},
+			},
+		},
+		{
+			DnnName:  "ims",
+			DnsIpv4:  "8.8.4.4",
+			UeSubnet: "10.1.2.0/24",
+			Mtu:      1400,
+		},
+	}
+	ipDomains := extractIpDomains([]string{"dg-1"}, deviceGroupMap)
+	if !reflect.DeepEqual(ipDomains, expected) {
+		t.Errorf("expected IP domains %+v, got %+v", expected, ipDomains)
+	}
+}
+
+func TestBuildSessionManagementConfig_UpfList(t *testing.T) {
+	slice := prepareNetworkSlice(networkSliceParams{
+		sliceName: "slice-1",
+		mcc:       "001",
+		mnc:       "01",
+		sst:       "1",
+		sd:        "010203",
+	})
+	slice.SiteInfo.Upf = nil
+	slice.SiteInfo.Upfs = []configmodels.SliceSiteInfoUpf{
+		{UpfName: "upf-backup.example.com", UpfPort: "8805", Priority: 20, Weight: 100},
+		{UpfName: "upf-2.example.com", UpfPort: "8805", Priority: 10, Weight: 30, Dnns: []string{"internet"}},
+		{UpfName: "upf-1.example.com", UpfPort: "8806", Priority: 10, Weight: 70},
+	}
+
+	session, ok := buildSessionManagementConfig(slice, map[string]configmodels.DeviceGroups{})
+	if !ok {
+		t.Fatalf("expected the session management configuration to be built")
+	}
+	expectedUpf := nfConfigApi.Upf{Hostname: "upf-1.example.com", Port: openapi.PtrInt32(8806)}
+	if !reflect.DeepEqual(session.Upf, &expectedUpf) {
+		t.Errorf("expected the preferred UPF %+v, got %+v", expectedUpf, session.Upf)
+	}
+	expectedUpfs := []nfConfigApi.Upf{
+		{
+			Hostname:             "upf-1.example.com",
+			Port:                 openapi.PtrInt32(8806),
+			AdditionalProperties: map[string]any{"priority": int32(10), "weight": int32(70)},
+		},
+		{
+			Hostname:             "upf-2.example.com",
+			Port:                 openapi.PtrInt32(8805),
+			AdditionalProperties: map[string]any{"priority": int32(10), "weight": int32(30), "dnns": []string{"internet"}},
+		},
+		{
+			Hostname:             "upf-backup.example.com",
+			Port:                 openapi.PtrInt32(8805),
+			AdditionalProperties: map[string]any{"priority": int32(20), "weight": int32(100)},
+		},
+	}
+	if !reflect.DeepEqual(session.AdditionalProperties["upfs"], expectedUpfs) {
+		t.Errorf("expected UPFs %+v, got %+v", expectedUpfs, session.AdditionalProperties["upfs"])
+	}
+}
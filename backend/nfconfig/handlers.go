@@ -7,11 +7,10 @@ package nfconfig
 
 import (
 	"net/http"
-	"slices"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 )
 
@@ -42,11 +41,17 @@ func (n *NFConfigServer) GetSessionManagementConfig(c *gin.Context) {
 
 func (n *NFConfigServer) GetImsiQosConfig(c *gin.Context) {
 	dnn := c.Param("dnn")
-	imsi := strings.TrimPrefix(c.Param("imsi"), "imsi-")
+	supi, err := identity.ParseImsi(c.Param("imsi"))
+	if err != nil {
+		logger.NfConfigLog.Errorf("invalid IMSI in QoS config request: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	imsi := supi.Value
 	logger.NfConfigLog.Debugf("Handling GET request for QoS config for IMSI %s", imsi)
 	imsiQos := []nfConfigApi.ImsiQos{}
 	for _, imsiQosConfig := range n.inMemoryConfig.imsiQos {
-		if imsiQosConfig.dnn == dnn && slices.Contains(imsiQosConfig.imsis, imsi) {
+		if imsiQosConfig.dnn == dnn && imsiQosConfig.containsImsi(imsi) {
 			imsiQos = imsiQosConfig.qos
 			break
 		}
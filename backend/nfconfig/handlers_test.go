@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/identity"
 )
 
 func TestGetImsiQosConfig(t *testing.T) {
@@ -88,6 +89,54 @@ func TestGetImsiQosConfig(t *testing.T) {
 			expectedCode: http.StatusNotFound,
 			expectedData: []nfConfigApi.ImsiQos{},
 		},
+		{
+			name: "imsi found in an imsi range",
+			imsi: "imsi-001010000000500",
+			inMemoryData: []imsiQosConfig{
+				{
+					dnn:        "internet",
+					imsis:      []string{"999990000000000"},
+					imsiRanges: []identity.ImsiRange{mustImsiRange(t, "001010000000001", "001010000001000", 0)},
+					qos: []nfConfigApi.ImsiQos{
+						{
+							MbrUplink:        "20 Kbps",
+							MbrDownlink:      "100 Kbps",
+							FiveQi:           7,
+							ArpPriorityLevel: 32,
+						},
+					},
+				},
+			},
+			expectedCode: http.StatusOK,
+			expectedData: []nfConfigApi.ImsiQos{
+				{
+					MbrUplink:        "20 Kbps",
+					MbrDownlink:      "100 Kbps",
+					FiveQi:           7,
+					ArpPriorityLevel: 32,
+				},
+			},
+		},
+		{
+			name: "imsi outside of the imsi range",
+			imsi: "imsi-001010000001001",
+			inMemoryData: []imsiQosConfig{
+				{
+					dnn:        "internet",
+					imsiRanges: []identity.ImsiRange{mustImsiRange(t, "001010000000001", "001010000001000", 0)},
+					qos: []nfConfigApi.ImsiQos{
+						{
+							MbrUplink:        "20 Kbps",
+							MbrDownlink:      "100 Kbps",
+							FiveQi:           7,
+							ArpPriorityLevel: 32,
+						},
+					},
+				},
+			},
+			expectedCode: http.StatusNotFound,
+			expectedData: []nfConfigApi.ImsiQos{},
+		},
 		{
 			name:         "empty in memory config",
 			imsi:         "imsi-999990000000000",
@@ -136,3 +185,21 @@ func TestGetImsiQosConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImsiQosConfig_MalformedImsi(t *testing.T) {
+	router := gin.New()
+	nfServer := &NFConfigServer{Router: router}
+	nfServer.setupRoutes()
+
+	for _, imsi := range []string{"imsi-00101", "imsi-00101000000000a", "nai-user@example.com"} {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/nfconfig/qos/internet/"+imsi, nil)
+		if err != nil {
+			t.Fatalf("fail create a request %v", err)
+		}
+		nfServer.Router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v for %s, got %v", http.StatusBadRequest, imsi, w.Code)
+		}
+	}
+}
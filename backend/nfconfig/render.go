@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package nfconfig
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/configmodels"
+)
+
+// imsiQosEntry is the QoS served on /qos/:dnn/:imsi for the IMSIs of a device group
+type imsiQosEntry struct {
+	Dnn        string                `json:"dnn"`
+	Imsis      []string              `json:"imsis,omitempty"`
+	ImsiRanges []string              `json:"imsi-ranges,omitempty"`
+	Qos        []nfConfigApi.ImsiQos `json:"qos"`
+}
+
+// RenderConfig returns the configuration every endpoint would serve for networkSlices and
+// deviceGroups, keyed by endpoint. The in-memory configuration is not modified.
+func RenderConfig(networkSlices []configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) map[string]any {
+	var c inMemoryConfig
+	c.sync(networkSlices, deviceGroups)
+	return map[string]any{
+		"/nfconfig/access-mobility":    c.accessAndMobility,
+		"/nfconfig/plmn":               c.plmn,
+		"/nfconfig/plmn-snssai":        c.plmnSnssai,
+		"/nfconfig/policy-control":     c.policyControl,
+		"/nfconfig/session-management": c.sessionManagement,
+		"/nfconfig/qos":                c.imsiQosEntries(),
+	}
+}
+
+// imsiQosEntries lists the IMSI QoS configuration, sorted by DNN and IMSIs
+func (c *inMemoryConfig) imsiQosEntries() []imsiQosEntry {
+	entries := make([]imsiQosEntry, 0, len(c.imsiQos))
+	for _, config := range c.imsiQos {
+		entry := imsiQosEntry{
+			Dnn:   config.dnn,
+			Imsis: config.imsis,
+			Qos:   config.qos,
+		}
+		for _, imsiRange := range config.imsiRanges {
+			entry.ImsiRanges = append(entry.ImsiRanges, imsiRange.Start()+"-"+imsiRange.End())
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortStableFunc(entries, func(a, b imsiQosEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Dnn, b.Dnn),
+			cmp.Compare(strings.Join(a.Imsis, ","), strings.Join(b.Imsis, ",")),
+			cmp.Compare(strings.Join(a.ImsiRanges, ","), strings.Join(b.ImsiRanges, ",")),
+		)
+	})
+	return entries
+}
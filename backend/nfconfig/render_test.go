@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nfconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/configmodels"
+)
+
+func TestRenderConfig(t *testing.T) {
+	qos := &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		DnnMbrUplink:   20000000,
+		DnnMbrDownlink: 200000000,
+		TrafficClass: &configmodels.TrafficClassInfo{
+			Qci: 6,
+			Arp: 9,
+		},
+	}
+	deviceGroups := make(map[string]configmodels.DeviceGroups)
+	for _, p := range []deviceGroupParams{
+		{name: "dg-2", dnn: "internet", imsis: []string{"001010123456790"}, ueIpPool: "10.1.2.0/24", qos: qos},
+		{name: "dg-1", dnn: "internet", imsis: []string{"001010123456789"}, ueIpPool: "10.1.1.0/24", qos: qos},
+	} {
+		name, group := makeDeviceGroup(p)
+		deviceGroups[name] = group
+	}
+	networkSlice := makeNetworkSliceWithPlmnSnssai("001", "01", "1", "010203")
+	networkSlice.SiteDeviceGroup = []string{"dg-1", "dg-2"}
+
+	config := RenderConfig([]configmodels.Slice{networkSlice}, deviceGroups)
+
+	expectedEndpoints := []string{
+		"/nfconfig/access-mobility",
+		"/nfconfig/plmn",
+		"/nfconfig/plmn-snssai",
+		"/nfconfig/policy-control",
+		"/nfconfig/session-management",
+		"/nfconfig/qos",
+	}
+	if len(config) != len(expectedEndpoints) {
+		t.Fatalf("expected %d endpoints, got %+v", len(expectedEndpoints), config)
+	}
+	for _, endpoint := range expectedEndpoints {
+		if _, ok := config[endpoint]; !ok {
+			t.Errorf("expected the configuration of %s", endpoint)
+		}
+	}
+	expectedPlmn := []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}
+	if !reflect.DeepEqual(config["/nfconfig/plmn"], expectedPlmn) {
+		t.Errorf("expected %+v, got %+v", expectedPlmn, config["/nfconfig/plmn"])
+	}
+	expectedQos := []imsiQosEntry{
+		{Dnn: "internet", Imsis: []string{"001010123456789"}, Qos: []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("20 Mbps", "200 Mbps", 6, 9)}},
+		{Dnn: "internet", Imsis: []string{"001010123456790"}, Qos: []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("20 Mbps", "200 Mbps", 6, 9)}},
+	}
+	if !reflect.DeepEqual(config["/nfconfig/qos"], expectedQos) {
+		t.Errorf("expected %+v, got %+v", expectedQos, config["/nfconfig/qos"])
+	}
+}
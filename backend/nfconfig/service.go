@@ -191,12 +191,7 @@ func (n *NFConfigServer) syncInMemoryConfig() error {
 	}
 	logger.NfConfigLog.Debugf("Parsed %d device groups", len(deviceGroups))
 
-	n.inMemoryConfig.syncPlmn(slices)
-	n.inMemoryConfig.syncPlmnSnssai(slices)
-	n.inMemoryConfig.syncAccessAndMobility(slices)
-	n.inMemoryConfig.syncSessionManagement(slices, deviceGroups)
-	n.inMemoryConfig.syncPolicyControl(slices, deviceGroups)
-	n.inMemoryConfig.syncImsiQos(deviceGroups)
+	n.inMemoryConfig.sync(slices, deviceGroups)
 	logger.NfConfigLog.Infoln("Updated NF in-memory configuration")
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 type deviceGroupParams struct {
 	name         string
 	imsis        []string
+	imsiRanges   []configmodels.ImsiRange
 	dnn          string
 	dnsPrimary   string
 	pcscfPrimary string
@@ -22,7 +23,8 @@ type deviceGroupParams struct {
 
 func makeDeviceGroup(p deviceGroupParams) (string, configmodels.DeviceGroups) {
 	return p.name, configmodels.DeviceGroups{
-		Imsis: p.imsis,
+		Imsis:      p.imsis,
+		ImsiRanges: p.imsiRanges,
 		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
 			{
 				Dnn:          p.dnn,
@@ -36,6 +38,15 @@ func makeDeviceGroup(p deviceGroupParams) (string, configmodels.DeviceGroups) {
 	}
 }
 
+// withSharedRanPlmns returns networkSlice also served in the PLMNs given as MCC and MNC pairs
+func withSharedRanPlmns(networkSlice configmodels.Slice, mccMncs ...string) configmodels.Slice {
+	networkSlice.SiteInfo.Plmns = nil
+	for i := 0; i+1 < len(mccMncs); i += 2 {
+		networkSlice.SiteInfo.Plmns = append(networkSlice.SiteInfo.Plmns, configmodels.SliceSiteInfoPlmn{Mcc: mccMncs[i], Mnc: mccMncs[i+1]})
+	}
+	return networkSlice
+}
+
 func makeSnssaiWithSd(sst int32, sd string) nfConfigApi.Snssai {
 	s := nfConfigApi.NewSnssai(sst)
 	s.SetSd(sd)
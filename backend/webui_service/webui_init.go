@@ -110,6 +110,10 @@ func (webui *WEBUI) Start(ctx context.Context, syncChan chan<- struct{}) {
 		}
 	}()
 
+	if reconciliation := factory.WebUIConfig.Configuration.SubscriberReconciliation; reconciliation != nil {
+		go configapi.RunSubscriberReconciliation(ctx, reconciliation.Interval, reconciliation.Repair)
+	}
+
 	self := webui_context.WEBUI_Self()
 	self.UpdateNfProfiles()
 
@@ -15,12 +15,14 @@ package configapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
@@ -96,6 +98,7 @@ func GetDeviceGroupByName(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve device group"})
 		return
 	}
+	deviceGroup.ConvertLegacyMsisdns()
 	if deviceGroup.DeviceGroupName == "" {
 		c.JSON(http.StatusNotFound, nil)
 	} else {
@@ -108,6 +111,7 @@ func GetDeviceGroupByName(c *gin.Context) {
 // @Description  Delete an existing device group
 // @Tags         Device Groups
 // @Param        deviceGroupName    path    string    true    " "
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "Device group deleted successfully"
 // @Failure      400  {object}  nil  "Bad request"
@@ -127,8 +131,20 @@ func DeviceGroupGroupNameDelete(c *gin.Context) {
 		})
 		return
 	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
 	logger.WebUILog.Debugf("Request ID: %s Attempting to delete device group: %s", requestID, groupName)
-	if err := deviceGroupDeleteHelper(groupName); err != nil {
+	var report *configmodels.DryRunReport
+	if dryRun {
+		report, err = deviceGroupDeleteDryRun(groupName)
+	} else {
+		err = deviceGroupDeleteHelper(groupName)
+	}
+	if err != nil {
 		logger.WebUILog.Errorf("Request ID: %s Device group delete failed: %+v", requestID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":      fmt.Sprintf("Failed to delete device group %s with error: %+v.", groupName, err),
@@ -137,6 +153,10 @@ func DeviceGroupGroupNameDelete(c *gin.Context) {
 		})
 		return
 	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{})
 }
 
@@ -188,8 +208,28 @@ func DeviceGroupGroupNamePut(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
 		return
 	}
+	allowDuplicates, err := allowDuplicateImsis(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
 
-	if statusCode, err := deviceGroupPostHelper(requestDeviceGroup, groupName); err != nil {
+	var report *configmodels.DryRunReport
+	var conflicts []configmodels.ImsiConflict
+	var statusCode int
+	if dryRun {
+		report, statusCode, err = deviceGroupDryRun(requestDeviceGroup, groupName, allowDuplicates)
+	} else {
+		conflicts, statusCode, err = deviceGroupPostHelper(requestDeviceGroup, groupName, allowDuplicates)
+	}
+	if err != nil {
 		logger.WebUILog.Errorf("Device group update failed: %+v", err)
 		c.JSON(statusCode, gin.H{
 			"error":      fmt.Sprintf("Failed to update device group %s with error: %+v.", groupName, err),
@@ -198,7 +238,11 @@ func DeviceGroupGroupNamePut(c *gin.Context) {
 		})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{})
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	respondImsiConflicts(c, conflicts)
 }
 
 // DeviceGroupGroupNamePost godoc
@@ -207,11 +251,14 @@ func DeviceGroupGroupNamePut(c *gin.Context) {
 // @Tags         Device Groups
 // @Param        deviceGroupName    path    string                       true    " "
 // @Param        content            body    configmodels.DeviceGroups    true    " "
+// @Param        allow-duplicate-imsis    query    bool    false    "Accept IMSIs belonging to another device group and report them"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "Device group created"
 // @Failure      400  {object}  nil  "Invalid device group content"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "IMSIs already belong to another device group, MSISDNs already assigned or UE IP pool overlap"
 // @Failure      500  {object}  nil  "Error creating device group"
 // @Router       /config/v1/device-group/{deviceGroupName}  [post]
 func DeviceGroupGroupNamePost(c *gin.Context) {
@@ -262,8 +309,28 @@ func DeviceGroupGroupNamePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
 		return
 	}
+	allowDuplicates, err := allowDuplicateImsis(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
 
-	if statusCode, err := deviceGroupPostHelper(requestDeviceGroup, groupName); err != nil {
+	var report *configmodels.DryRunReport
+	var conflicts []configmodels.ImsiConflict
+	var statusCode int
+	if dryRun {
+		report, statusCode, err = deviceGroupDryRun(requestDeviceGroup, groupName, allowDuplicates)
+	} else {
+		conflicts, statusCode, err = deviceGroupPostHelper(requestDeviceGroup, groupName, allowDuplicates)
+	}
+	if err != nil {
 		logger.WebUILog.Errorf("Device group create failed: %+v", err)
 		c.JSON(statusCode, gin.H{
 			"error":      fmt.Sprintf("Failed to create device group %s with error: %+v.", groupName, err),
@@ -272,9 +339,152 @@ func DeviceGroupGroupNamePost(c *gin.Context) {
 		})
 		return
 	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	respondImsiConflicts(c, conflicts)
+}
+
+// DeviceGroupMemberPost godoc
+//
+// @Description  Add an IMSI, and optionally its MSISDN, to an existing device group. Only the subscription data of this IMSI is updated.
+// @Tags         Device Groups
+// @Param        deviceGroupName    path    string                            true    " "
+// @Param        content            body    configmodels.DeviceGroupMember    true    " "
+// @Param        allow-duplicate-imsis    query    bool    false    "Add the IMSI even if it belongs to another device group"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "IMSI added to the device group"
+// @Failure      400  {object}  nil  "Invalid IMSI or MSISDN"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Device group not found"
+// @Failure      409  {object}  nil  "IMSI already belongs to this or another device group, or MSISDN already assigned"
+// @Failure      500  {object}  nil  "Error adding the IMSI"
+// @Router       /config/v1/device-group/{deviceGroupName}/imsis  [post]
+func DeviceGroupMemberPost(c *gin.Context) {
+	requestID := uuid.New().String()
+	logger.WebUILog.Debugln("DeviceGroupMemberPost")
+	groupName := c.Param("group-name")
+	var member configmodels.DeviceGroupMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		err = fmt.Errorf("JSON bind error: %w", err)
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	supi, err := identity.ParseImsi(member.Imsi)
+	if err == nil {
+		err = supi.CheckPlmn(getConfiguredPlmns())
+	}
+	if err != nil {
+		logger.ConfigLog.Errorf("Request ID: %s invalid IMSI %s: %+v", requestID, member.Imsi, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	member.Imsi = supi.Value
+	if member.Msisdn != "" {
+		if member.Msisdn, err = identity.ParseMsisdn(member.Msisdn); err != nil {
+			logger.ConfigLog.Errorf("Request ID: %s invalid MSISDN: %+v", requestID, err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+			return
+		}
+	}
+	allowDuplicates, err := allowDuplicateImsis(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	if dryRun {
+		report, err := deviceGroupMemberAddDryRun(groupName, member, allowDuplicates)
+		if err != nil {
+			deviceGroupMemberError(c, requestID, groupName, err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	conflicts, err := deviceGroupMemberAdd(groupName, member, allowDuplicates)
+	if err != nil {
+		deviceGroupMemberError(c, requestID, groupName, err)
+		return
+	}
+	respondImsiConflicts(c, conflicts)
+}
+
+// DeviceGroupMemberDelete godoc
+//
+// @Description  Remove an IMSI, and its MSISDN, from a device group. Only the subscription data of this IMSI is updated.
+// @Tags         Device Groups
+// @Param        deviceGroupName    path    string    true    " "
+// @Param        imsi               path    string    true    " "
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "IMSI removed from the device group"
+// @Failure      400  {object}  nil  "Invalid IMSI"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Device group not found or IMSI not a member of it"
+// @Failure      409  {object}  nil  "IMSI belongs to an IMSI range of the device group"
+// @Failure      500  {object}  nil  "Error removing the IMSI"
+// @Router       /config/v1/device-group/{deviceGroupName}/imsis/{imsi}  [delete]
+func DeviceGroupMemberDelete(c *gin.Context) {
+	requestID := uuid.New().String()
+	logger.WebUILog.Debugln("DeviceGroupMemberDelete")
+	groupName := c.Param("group-name")
+	supi, err := identity.ParseImsi(c.Param("imsi"))
+	if err != nil {
+		logger.ConfigLog.Errorf("Request ID: %s invalid IMSI %s: %+v", requestID, c.Param("imsi"), err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	if dryRun {
+		report, err := deviceGroupMemberRemoveDryRun(groupName, supi.Value)
+		if err != nil {
+			deviceGroupMemberError(c, requestID, groupName, err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	if err = deviceGroupMemberRemove(groupName, supi.Value); err != nil {
+		deviceGroupMemberError(c, requestID, groupName, err)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{})
 }
 
+func deviceGroupMemberError(c *gin.Context, requestID string, groupName string, err error) {
+	switch {
+	case errors.Is(err, errDeviceGroupNotFound), errors.Is(err, errDeviceGroupMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error(), "request_id": requestID})
+	case errors.Is(err, errDeviceGroupMemberExists), errors.Is(err, errDeviceGroupMemberInRange), errors.Is(err, errImsiConflict),
+		errors.Is(err, errMsisdnConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "request_id": requestID})
+	default:
+		logger.WebUILog.Errorf("Request ID: %s device group %s membership update failed: %+v", requestID, groupName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      fmt.Sprintf("Failed to update the members of device group %s.", groupName),
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+	}
+}
+
 // GetNetworkSlices godoc
 //
 // @Description  Return the list of network slices
@@ -352,6 +562,7 @@ func GetNetworkSliceByName(c *gin.Context) {
 // @Tags         Network Slices
 // @Produce      json
 // @Param        sliceName    path    string    true    " "
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      202  {object}  nil  "Network slice deleted successfully"
 // @Failure      400  {object}  nil  "Invalid network slice name provided"
@@ -382,7 +593,19 @@ func NetworkSliceSliceNameDelete(c *gin.Context) {
 		})
 		return
 	}
-	if err := networkSliceDeleteHelper(sliceName); err != nil {
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	var report *configmodels.DryRunReport
+	if dryRun {
+		report, err = networkSliceDeleteDryRun(sliceName)
+	} else {
+		err = networkSliceDeleteHelper(sliceName)
+	}
+	if err != nil {
 		logger.WebUILog.Errorf("Network slice delete failed: %+v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":      fmt.Sprintf("Failed to delete network slice %s with error: %+v.", sliceName, err),
@@ -391,6 +614,10 @@ func NetworkSliceSliceNameDelete(c *gin.Context) {
 		})
 		return
 	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{})
 }
 
@@ -400,11 +627,13 @@ func NetworkSliceSliceNameDelete(c *gin.Context) {
 // @Tags         Network Slices
 // @Param        sliceName    path    string                true    " "
 // @Param        content      body    configmodels.Slice    true    " "
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "Network slice created"
-// @Failure      400  {object}  nil  "Invalid network slice content"
+// @Failure      400  {object}  nil  "Invalid network slice content, with the list of invalid fields"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "UE IP pools of device groups served by the same UPF overlap"
 // @Failure      500  {object}  nil  "Error creating network slice"
 // @Router       /config/v1/network-slice/{sliceName}  [post]
 func NetworkSliceSliceNamePost(c *gin.Context) {
@@ -431,13 +660,28 @@ func NetworkSliceSliceNamePost(c *gin.Context) {
 		})
 		return
 	}
-	statusCode, err := networkSlicePostHelper(c, sliceName)
+	dryRun, err := dryRunRequested(c)
 	if err != nil {
-		c.JSON(statusCode, gin.H{
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	report, statusCode, err := networkSlicePostHelper(c, sliceName, dryRun)
+	if err != nil {
+		response := gin.H{
 			"error":      fmt.Sprintf("Failed to create network slice %s with error: %+v", sliceName, err),
 			"request_id": requestID,
 			"message":    "Please refer to the log with the provided Request ID for details",
-		})
+		}
+		var validationErr *sliceValidationError
+		if errors.As(err, &validationErr) {
+			response["errors"] = validationErr.problems
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
@@ -467,13 +711,28 @@ func NetworkSliceSliceNamePut(c *gin.Context) {
 		})
 		return
 	}
-	statusCode, err := networkSlicePostHelper(c, sliceName)
+	dryRun, err := dryRunRequested(c)
 	if err != nil {
-		c.JSON(statusCode, gin.H{
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	report, statusCode, err := networkSlicePostHelper(c, sliceName, dryRun)
+	if err != nil {
+		response := gin.H{
 			"error":      fmt.Sprintf("Failed to update network slice %s with error: %+v.", sliceName, err),
 			"request_id": requestID,
 			"message":    "Please refer to the log with the provided Request ID for details",
-		})
+		}
+		var validationErr *sliceValidationError
+		if errors.As(err, &validationErr) {
+			response["errors"] = validationErr.problems
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
@@ -66,6 +66,7 @@ func GetGnbs(c *gin.Context) {
 // @Tags        gNBs
 // @Produce     json
 // @Param       gnb    body    configmodels.PostGnbRequest    true    "Name and TAC of the gNB"
+// @Param       dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security    BearerAuth
 // @Success     201  {object}  nil  "gNB successfully created"
 // @Failure     409  {object}  nil  "Resource Conflict"
@@ -98,6 +99,26 @@ func PostGnb(c *gin.Context) {
 		}
 	}
 	gnb := configmodels.Gnb(postGnbParams)
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		if inventoryExists(configmodels.GnbDataColl, bson.M{"name": gnb.Name}) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "gNB already exists"})
+			return
+		}
+		report, err := inventoryDryRun(sliceHasGnb(gnb.Name), setGnbTac(gnb))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of gNB %s: %+v", gnb.Name, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create gNB"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	if err := executeGnbTransaction(c.Request.Context(), gnb, updateGnbInNetworkSlices, postGnbOperation); err != nil {
 		if strings.Contains(err.Error(), "E11000") {
 			logger.WebUILog.Errorf("duplicate gNB name found error: %+v", err)
@@ -125,6 +146,7 @@ func postGnbOperation(sc context.Context, gnb configmodels.Gnb) error {
 // @Produce     json
 // @Param       gnb-name    path    string                        true    "Name of the gNB"
 // @Param       tac         body    configmodels.PutGnbRequest    true    "TAC of the gNB"
+// @Param       dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security    BearerAuth
 // @Success     201  {object}  nil  "gNB successfully created"
 // @Failure     400  {object}  nil  "Bad request"
@@ -158,6 +180,22 @@ func PutGnb(c *gin.Context) {
 		Name: gnbName,
 		Tac:  &putGnbParams.Tac,
 	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		report, err := inventoryDryRun(sliceHasGnb(gnbName), setGnbTac(putGnb))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of PUT gNB %s: %+v", gnbName, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT gNB"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	if err := executeGnbTransaction(c.Request.Context(), putGnb, updateGnbInNetworkSlices, putGnbOperation); err != nil {
 		logger.WebUILog.Errorf("failed to PUT gNB name: %s error: %+v", gnbName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT gNB"})
@@ -178,13 +216,7 @@ func updateGnbInNetworkSlices(gnb configmodels.Gnb) error {
 	filterByGnb := bson.M{
 		"site-info.gNodeBs.name": gnb.Name,
 	}
-	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, func(networkSlice *configmodels.Slice) {
-		for i := range networkSlice.SiteInfo.GNodeBs {
-			if networkSlice.SiteInfo.GNodeBs[i].Name == gnb.Name {
-				networkSlice.SiteInfo.GNodeBs[i].Tac = *gnb.Tac
-			}
-		}
-	})
+	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, setGnbTac(gnb))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to update gNB in network slices: %+v", err)
 	}
@@ -198,6 +230,7 @@ func updateGnbInNetworkSlices(gnb configmodels.Gnb) error {
 // @Tags         gNBs
 // @Produce      json
 // @Param        gnb-name    path    string    true    "Name of the gNB"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "gNB deleted"
 // @Failure      400  {object}  nil  "Bad request"
@@ -218,7 +251,23 @@ func DeleteGnb(c *gin.Context) {
 	gnb := configmodels.Gnb{
 		Name: gnbName,
 	}
-	err := executeGnbTransaction(c.Request.Context(), gnb, removeGnbFromNetworkSlices, deleteGnbOperation)
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		report, err := inventoryDryRun(sliceHasGnb(gnbName), removeGnb(gnb))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of deleting gNB %s: %+v", gnbName, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete gNB"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	err = executeGnbTransaction(c.Request.Context(), gnb, removeGnbFromNetworkSlices, deleteGnbOperation)
 	if err != nil {
 		logger.WebUILog.Errorf("failed to delete GNB with name %s error: %+v", gnbName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete gNB"})
@@ -237,11 +286,7 @@ func removeGnbFromNetworkSlices(gnb configmodels.Gnb) error {
 	filterByGnb := bson.M{
 		"site-info.gNodeBs.name": gnb.Name,
 	}
-	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, func(networkSlice *configmodels.Slice) {
-		networkSlice.SiteInfo.GNodeBs = slices.DeleteFunc(networkSlice.SiteInfo.GNodeBs, func(existingGnb configmodels.SliceSiteInfoGNodeBs) bool {
-			return gnb.Name == existingGnb.Name
-		})
-	})
+	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, removeGnb(gnb))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to remove gNB from network slices: %+v", err)
 	}
@@ -327,6 +372,7 @@ func GetUpfs(c *gin.Context) {
 // @Tags         UPFs
 // @Produce      json
 // @Param        upf  body  configmodels.PostUpfRequest  true  "Hostname and port of the UPF to create"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      201  {object}  nil  "UPF successfully created"
 // @Failure      400  {object}  nil  "Bad request"
@@ -358,6 +404,26 @@ func PostUpf(c *gin.Context) {
 		return
 	}
 	upf := configmodels.Upf(postUpfParams)
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		if inventoryExists(configmodels.UpfDataColl, bson.M{"hostname": upf.Hostname}) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "UPF already exists"})
+			return
+		}
+		report, err := inventoryDryRun(sliceHasUpf(upf.Hostname), setUpf(upf))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of UPF %s: %+v", upf.Hostname, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create UPF"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	if err = executeUpfTransaction(c.Request.Context(), upf, updateUpfInNetworkSlices, postUpfOperation); err != nil {
 		if strings.Contains(err.Error(), "E11000") {
 			logger.WebUILog.Errorf("duplicate hostname found with error: %+v", err)
@@ -388,6 +454,7 @@ func postUpfOperation(sc context.Context, upf configmodels.Upf) error {
 // @Produce      json
 // @Param        upf-hostname   path    string                       true    "Name of the UPF to update"
 // @Param        port           body    configmodels.PutUpfRequest   true    "Port of the UPF to update"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "UPF successfully updated"
 // @Failure      400  {object}  nil  "Bad request"
@@ -422,6 +489,22 @@ func PutUpf(c *gin.Context) {
 		Hostname: hostname,
 		Port:     putUpfParams.Port,
 	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		report, err := inventoryDryRun(sliceHasUpf(hostname), setUpf(putUpf))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of PUT UPF %s: %+v", hostname, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT UPF"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	if err := executeUpfTransaction(c.Request.Context(), putUpf, updateUpfInNetworkSlices, putUpfOperation); err != nil {
 		logger.WebUILog.Errorf("failed to PUT UPF with hostname: %s with error: %+v", hostname, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT UPF"})
@@ -442,13 +525,7 @@ func putUpfOperation(sc context.Context, upf configmodels.Upf) error {
 }
 
 func updateUpfInNetworkSlices(upf configmodels.Upf) error {
-	filterByUpf := bson.M{"site-info.upf.upf-name": upf.Hostname}
-	statusCode, err := updateInventoryInNetworkSlices(filterByUpf, func(networkSlice *configmodels.Slice) {
-		networkSlice.SiteInfo.Upf = map[string]any{
-			"upf-name": upf.Hostname,
-			"upf-port": upf.Port,
-		}
-	})
+	statusCode, err := updateInventoryInNetworkSlices(sliceUpfFilter(upf.Hostname), setUpf(upf))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to update UPF in network slices: %+v", err)
 	}
@@ -462,6 +539,7 @@ func updateUpfInNetworkSlices(upf configmodels.Upf) error {
 // @Tags         UPFs
 // @Produce      json
 // @Param        upf-hostname    path    string    true    "Name of the UPF"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "UPF deleted"
 // @Failure      400  {object}  nil  "Bad request"
@@ -482,6 +560,22 @@ func DeleteUpf(c *gin.Context) {
 	upf := configmodels.Upf{
 		Hostname: hostname,
 	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dryRun {
+		report, err := inventoryDryRun(sliceHasUpf(hostname), removeUpf(hostname))
+		if err != nil {
+			logger.WebUILog.Errorf("failed to compute the effect of deleting UPF %s: %+v", hostname, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete UPF"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
 	if err := executeUpfTransaction(c.Request.Context(), upf, removeUpfFromNetworkSlices, deleteUpfOperation); err != nil {
 		logger.WebUILog.Errorf("failed to delete UPF with hostname: %s with error: %+v", hostname, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete UPF"})
@@ -497,10 +591,7 @@ func deleteUpfOperation(sc context.Context, upf configmodels.Upf) error {
 }
 
 func removeUpfFromNetworkSlices(upf configmodels.Upf) error {
-	filterByUpf := bson.M{"site-info.upf.upf-name": upf.Hostname}
-	statusCode, err := updateInventoryInNetworkSlices(filterByUpf, func(networkSlice *configmodels.Slice) {
-		networkSlice.SiteInfo.Upf = nil
-	})
+	statusCode, err := updateInventoryInNetworkSlices(sliceUpfFilter(upf.Hostname), removeUpf(upf.Hostname))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to remove UPF from network slices: %+v", err)
 	}
@@ -565,3 +656,90 @@ func updateInventoryInNetworkSlices(filter bson.M, updateFunc func(*configmodels
 	}
 	return http.StatusOK, nil
 }
+
+// setGnbTac sets the TAC of gnb in a network slice
+func setGnbTac(gnb configmodels.Gnb) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
+		for i := range networkSlice.SiteInfo.GNodeBs {
+			if networkSlice.SiteInfo.GNodeBs[i].Name == gnb.Name {
+				networkSlice.SiteInfo.GNodeBs[i].Tac = *gnb.Tac
+			}
+		}
+	}
+}
+
+// removeGnb removes gnb from a network slice
+func removeGnb(gnb configmodels.Gnb) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
+		networkSlice.SiteInfo.GNodeBs = slices.DeleteFunc(networkSlice.SiteInfo.GNodeBs, func(existingGnb configmodels.SliceSiteInfoGNodeBs) bool {
+			return gnb.Name == existingGnb.Name
+		})
+	}
+}
+
+// setUpf sets the port of upf in a network slice it serves
+func setUpf(upf configmodels.Upf) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
+		if len(networkSlice.SiteInfo.Upfs) == 0 {
+			networkSlice.SiteInfo.Upf = &configmodels.SliceSiteInfoUpfReference{
+				UpfName: upf.Hostname,
+				UpfPort: configmodels.UpfPort(upf.Port),
+			}
+			return
+		}
+		for i := range networkSlice.SiteInfo.Upfs {
+			if networkSlice.SiteInfo.Upfs[i].UpfName == upf.Hostname {
+				networkSlice.SiteInfo.Upfs[i].UpfPort = upf.Port
+			}
+		}
+	}
+}
+
+// removeUpf removes the UPF hostname from a network slice
+func removeUpf(hostname string) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
+		if len(networkSlice.SiteInfo.Upfs) == 0 {
+			networkSlice.SiteInfo.Upf = nil
+			return
+		}
+		networkSlice.SiteInfo.Upfs = slices.DeleteFunc(networkSlice.SiteInfo.Upfs, func(upf configmodels.SliceSiteInfoUpf) bool {
+			return upf.UpfName == hostname
+		})
+	}
+}
+
+// sliceUpfFilter matches the network slices of the UPF hostname, as the single UPF or one
+// of the UPFs list
+func sliceUpfFilter(hostname string) bson.M {
+	return bson.M{"$or": []bson.M{
+		{"site-info.upf.upf-name": hostname},
+		{"site-info.upfs.upf-name": hostname},
+	}}
+}
+
+// sliceHasGnb matches the network slices of the gNB gnbName, as the site-info.gNodeBs.name
+// filter does
+func sliceHasGnb(gnbName string) func(*configmodels.Slice) bool {
+	return func(networkSlice *configmodels.Slice) bool {
+		return slices.ContainsFunc(networkSlice.SiteInfo.GNodeBs, func(gnb configmodels.SliceSiteInfoGNodeBs) bool {
+			return gnb.Name == gnbName
+		})
+	}
+}
+
+// sliceHasUpf matches the network slices of the UPF hostname, as sliceUpfFilter does
+func sliceHasUpf(hostname string) func(*configmodels.Slice) bool {
+	return func(networkSlice *configmodels.Slice) bool {
+		return slices.Contains(sliceUpfNames(networkSlice), hostname)
+	}
+}
+
+// inventoryExists reports whether a gNB or UPF matching filter is stored in collName
+func inventoryExists(collName string, filter bson.M) bool {
+	count, err := dbadapter.CommonDBClient.RestfulAPICount(collName, filter)
+	if err != nil {
+		logger.DbLog.Warnf("failed to count %s: %+v", collName, err)
+		return false
+	}
+	return count > 0
+}
@@ -840,3 +840,33 @@ func TestInventoryDeleteHandlers(t *testing.T) {
 		})
 	}
 }
+
+func TestSetAndRemoveUpf_UpfList(t *testing.T) {
+	networkSlice := configmodels.Slice{
+		SliceName: "slice1",
+		SiteInfo: configmodels.SliceSiteInfo{
+			Upfs: []configmodels.SliceSiteInfoUpf{
+				{UpfName: "upf1.example.com", UpfPort: "8805", Priority: 1},
+				{UpfName: "upf2.example.com", UpfPort: "8805", Priority: 2},
+			},
+		},
+	}
+	if !sliceHasUpf("upf2.example.com")(&networkSlice) || sliceHasUpf("upf3.example.com")(&networkSlice) {
+		t.Errorf("expected the network slice to be matched by the UPFs of its list only")
+	}
+
+	setUpf(configmodels.Upf{Hostname: "upf2.example.com", Port: "8806"})(&networkSlice)
+	expected := []configmodels.SliceSiteInfoUpf{
+		{UpfName: "upf1.example.com", UpfPort: "8805", Priority: 1},
+		{UpfName: "upf2.example.com", UpfPort: "8806", Priority: 2},
+	}
+	if networkSlice.SiteInfo.Upf != nil || !reflect.DeepEqual(networkSlice.SiteInfo.Upfs, expected) {
+		t.Errorf("expected UPFs %+v, got %+v and %+v", expected, networkSlice.SiteInfo.Upfs, networkSlice.SiteInfo.Upf)
+	}
+
+	removeUpf("upf1.example.com")(&networkSlice)
+	expected = expected[1:]
+	if !reflect.DeepEqual(networkSlice.SiteInfo.Upfs, expected) {
+		t.Errorf("expected UPFs %+v, got %+v", expected, networkSlice.SiteInfo.Upfs)
+	}
+}
@@ -10,14 +10,19 @@ package configapi
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/omec-project/openapi/v2"
 	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/auth"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/backend/webui_context"
 	"github.com/omec-project/webconsole/configmodels"
@@ -25,8 +30,25 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
 var httpsClient *http.Client
 
+// parseUeIdParam returns the normalized SUPI of the ueId path parameter. It responds
+// with 400 Bad Request and returns false when the ueId is malformed.
+func parseUeIdParam(c *gin.Context, requestID string) (identity.Supi, bool) {
+	supi, err := identity.Parse(c.Param("ueId"))
+	if err != nil {
+		logger.WebUILog.Errorf("invalid ueId: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return identity.Supi{}, false
+	}
+	return supi, true
+}
+
 func init() {
 	httpsClient = &http.Client{
 		Transport: &http.Transport{
@@ -246,11 +268,22 @@ func GetSampleJSON(c *gin.Context) {
 
 // GetSubscribers godoc
 //
-// @Description  Return the list of subscribers
+// @Description  Return the list of subscribers, sorted by IMSI (UE ID) unless another sort order is requested.
+// @Description  Subscribers served in several PLMNs are listed once per PLMN.
+// @Description  The total number of matching entries is returned in the X-Total-Count header, except when filtering on hasAuthData,
+// @Description  and, when more entries are available, the cursor of the next page in the X-Next-Cursor header.
 // @Tags         Subscribers
 // @Produce      json
+// @Param        limit        query    int     false  "Maximum number of subscribers to return (1-1000). All subscribers are returned when omitted"
+// @Param        cursor       query    string  false  "Cursor returned in the X-Next-Cursor header of the previous page"
+// @Param        sort         query    string  false  "Sort field: ueId or plmnID, prefixed with '-' for descending order"
+// @Param        imsiPrefix   query    string  false  "Only return subscribers whose IMSI starts with the given digits"
+// @Param        plmnID       query    string  false  "Only return subscribers of the given serving PLMN"
+// @Param        deviceGroup  query    string  false  "Only return subscribers belonging to the given device group"
+// @Param        hasAuthData  query    bool    false  "Only return subscribers with (true) or without (false) authentication data"
 // @Security     BearerAuth
-// @Success      200  {object}  configmodels.SubsListIE  "List of subscribers. Null if there are no subscribers"
+// @Success      200  {object}  configmodels.SubsListIE  "List of subscribers"
+// @Failure      400  {object}  nil                      "Invalid query parameters"
 // @Failure      401  {object}  nil                      "Authorization failed"
 // @Failure      403  {object}  nil                      "Forbidden"
 // @Failure      500  {object}  nil                      "Error retrieving subscribers"
@@ -260,8 +293,15 @@ func GetSubscribers(c *gin.Context) {
 
 	logger.WebUILog.Infoln("Get All Subscribers List")
 
+	query, err := parseSubscriberListQuery(c)
+	if err != nil {
+		logger.WebUILog.Errorf("invalid subscribers list query: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	subsList := make([]configmodels.SubsListIE, 0)
-	amDataList, errGetMany := dbadapter.CommonDBClient.RestfulAPIGetMany(amDataColl, bson.M{})
+	amDataList, total, nextCursor, errGetMany := getSubscribersPage(query)
 	if errGetMany != nil {
 		logger.DbLog.Errorf("failed to retrieve subscribers list with error: %+v", errGetMany)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve subscribers list"})
@@ -279,6 +319,13 @@ func GetSubscribers(c *gin.Context) {
 		subsList = append(subsList, tmp)
 	}
 
+	c.Header("Access-Control-Expose-Headers", totalCountHeader+", "+nextCursorHeader)
+	if total >= 0 {
+		c.Header(totalCountHeader, strconv.FormatInt(total, 10))
+	}
+	if nextCursor != "" {
+		c.Header(nextCursorHeader, nextCursor)
+	}
 	c.JSON(http.StatusOK, subsList)
 }
 
@@ -290,6 +337,7 @@ func GetSubscribers(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "Subscriber"
+// @Failure      400  {object}  nil  "Invalid UE ID"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
 // @Failure      404  {object}  nil  "Subscriber not found"
@@ -299,8 +347,13 @@ func GetSubscriberByID(c *gin.Context) {
 	setCorsHeader(c)
 
 	logger.WebUILog.Infoln("Get One Subscriber Data")
+	requestID := uuid.New().String()
 
-	ueId := c.Param("ueId")
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
+		return
+	}
+	ueId := supi.String()
 	filterUeIdOnly := bson.M{"ueId": ueId}
 
 	var subsData configmodels.SubsData
@@ -361,6 +414,11 @@ func GetSubscriberByID(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve subscriber"})
 			return
 		}
+		if err = decryptAuthenticationSubscription(&authSubsData); err != nil {
+			logger.WebUILog.Errorf("error decrypting authentication subscription data: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve subscriber"})
+			return
+		}
 	}
 
 	var amDataData models.AccessAndMobilitySubscriptionData
@@ -455,16 +513,19 @@ func PostSubscriberByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: failed to parse JSON.", "request_id": requestID})
 		return
 	}
-	logger.WebUILog.Infof("%+v", subsOverrideData)
 
-	ueId := c.Param("ueId")
-	if ueId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing ueId in request URL", "request_id": requestID})
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
 		return
 	}
+	if err := supi.CheckPlmn(getConfiguredPlmns()); err != nil {
+		logger.WebUILog.Errorf("Post One Subscriber Data - %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	ueId := supi.String()
 
 	logger.WebUILog.Infoln("Received Post Subscriber Data from Roc/Simapp:", ueId)
-	logger.WebUILog.Debugf("Override Data: %+v", subsOverrideData)
 
 	// Check if the IMSI already exists in the database
 	filter := bson.M{"ueId": ueId}
@@ -478,25 +539,14 @@ func PostSubscriberByID(c *gin.Context) {
 		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("subscriber %s already exists", ueId), "request_id": requestID})
 		return
 	}
-	if subsOverrideData.OPc == "" || subsOverrideData.Key == "" || subsOverrideData.SequenceNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required authentication data: OPc and Key must be provided", "request_id": requestID})
+	authSubsData, err := newAuthenticationSubscription(subsOverrideData)
+	if err != nil {
+		logger.WebUILog.Errorf("Post One Subscriber Data - invalid authentication data: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
 		return
 	}
 
-	authSubsData := models.AuthenticationSubscription{
-		AuthenticationManagementField: openapi.PtrString("8000"),
-		AuthenticationMethod:          "5G_AKA", // "5G_AKA", "EAP_AKA_PRIME"
-		EncOpcKey:                     openapi.PtrString(subsOverrideData.OPc),
-		EncPermanentKey:               openapi.PtrString(subsOverrideData.Key),
-		SequenceNumber: &models.SequenceNumber{
-			Sqn: openapi.PtrString(subsOverrideData.SequenceNumber),
-		},
-	}
-
-	logger.WebUILog.Infof("%+v", authSubsData)
-	logger.WebUILog.Infof("Using OPc: %s, Key: %s, SeqNo: %s", subsOverrideData.OPc, subsOverrideData.Key, subsOverrideData.SequenceNumber)
-
-	err = subscriberAuthenticationDataCreate(ueId, &authSubsData)
+	err = subscriberAuthenticationDataCreate(ueId, authSubsData)
 	if err != nil {
 		logger.WebUILog.Errorf("Failed to create subscriber %s: %+v request ID: %s", ueId, err, requestID)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -511,12 +561,161 @@ func PostSubscriberByID(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{})
 }
 
+// ImportSubscribers godoc
+//
+// @Description  Create subscribers in bulk from a JSON array or a CSV file with the columns ueId, opc, key, sqn and plmnID.
+// @Description  Every row is validated and a per-row report is returned. Valid rows are written in transactions of chunkSize rows.
+// @Description  The optional plmnID of a row must match the MCC and MNC of its IMSI.
+// @Tags         Subscribers
+// @Accept       json,text/csv
+// @Produce      json
+// @Param        content    body     []configmodels.SubsBulkImportRow  true   "Subscribers to create"
+// @Param        dryRun     query    bool                              false  "Validate the rows without writing them"
+// @Param        chunkSize  query    int                               false  "Number of rows written per transaction (all rows when 0)"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.SubsBulkImportReport  "Per-row import report"
+// @Failure      400  {object}  nil  "Invalid request"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      500  {object}  nil  "Error importing subscribers"
+// @Router       /api/subscriber-import  [post]
+func ImportSubscribers(c *gin.Context) {
+	setCorsHeader(c)
+	logger.WebUILog.Infoln("Import Subscribers")
+	requestID := uuid.New().String()
+
+	dryRun := false
+	if value := c.Query("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid dryRun value: %s", value), "request_id": requestID})
+			return
+		}
+		dryRun = parsed
+	}
+	chunkSize := 0
+	if value := c.Query("chunkSize"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid chunkSize value: %s", value), "request_id": requestID})
+			return
+		}
+		chunkSize = parsed
+	}
+
+	var rows []configmodels.SubsBulkImportRow
+	var err error
+	switch ct := strings.Split(c.GetHeader("Content-Type"), ";")[0]; ct {
+	case "application/json":
+		var body []byte
+		body, err = io.ReadAll(c.Request.Body)
+		if err == nil {
+			rows, err = parseSubscriberBulkImportJSON(body)
+		}
+	case csvContentType:
+		rows, err = parseSubscriberBulkImportCSV(c.Request.Body)
+	default:
+		err = fmt.Errorf("unsupported content-type: %s", ct)
+	}
+	if err != nil {
+		logger.WebUILog.Errorf("Import Subscribers - invalid request body: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request body: %s", err.Error()), "request_id": requestID})
+		return
+	}
+	if len(rows) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no subscribers provided", "request_id": requestID})
+		return
+	}
+
+	report, err := subscriberBulkImport(c.Request.Context(), rows, chunkSize, dryRun)
+	if err != nil {
+		logger.WebUILog.Errorf("Import Subscribers failed: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "Failed to import subscribers",
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	logger.WebUILog.Infof("Import Subscribers completed: %d rows, %d created, %d valid, %d failed (dry run: %t)",
+		report.Total, report.Created, report.Valid, report.Failed, dryRun)
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportSubscribers godoc
+//
+// @Description  Stream every subscriber with its authentication metadata, subscription data, device group and network slice.
+// @Description  Authentication keys are redacted unless includeKeys is set by an admin user.
+// @Tags         Subscribers
+// @Produce      application/x-ndjson,text/csv
+// @Param        format       query    string  false  "Export format: jsonl (default) or csv"
+// @Param        includeKeys  query    bool    false  "Include the authentication keys (admin only)"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.SubsExportData  "One subscriber per line"
+// @Failure      400  {object}  nil  "Invalid query parameters"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      500  {object}  nil  "Error exporting subscribers"
+// @Router       /api/subscriber-export  [get]
+func ExportSubscribers(c *gin.Context) {
+	setCorsHeader(c)
+	logger.WebUILog.Infoln("Export Subscribers")
+	requestID := uuid.New().String()
+
+	format, err := parseSubscriberExportFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	includeKeys := false
+	if value := c.Query("includeKeys"); value != "" {
+		includeKeys, err = strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid includeKeys value: %s", value), "request_id": requestID})
+			return
+		}
+	}
+	if includeKeys && !isAdminRequest(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: admin access required to export authentication keys", "request_id": requestID})
+		return
+	}
+
+	membership, err := getSubscriberMembership()
+	if err != nil {
+		logger.WebUILog.Errorf("Export Subscribers failed: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "Failed to export subscribers",
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	if format == exportFormatCSV {
+		contentType = csvContentType
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=subscribers.%s", format))
+	c.Status(http.StatusOK)
+	writer, err := newSubscriberExportWriter(c.Writer, format)
+	if err == nil {
+		var exported int
+		exported, err = streamSubscribersExport(c, writer, membership, includeKeys)
+		logger.WebUILog.Infof("Export Subscribers: %d subscribers exported (keys included: %t) request ID: %s", exported, includeKeys, requestID)
+	}
+	if err != nil {
+		// the status code has already been sent: the truncated export is only reported in the log
+		logger.WebUILog.Errorf("Export Subscribers interrupted: %+v request ID: %s", err, requestID)
+	}
+}
+
 // PutSubscriberByID godoc
 //
 // @Description  Update subscriber information by IMSI (UE ID)
 // @Tags         Subscribers
 // @Param        imsi       path    string                           true    "IMSI (UE ID)"
-// @Param        content    body    configmodels.SubsData            true    "Updated subscriber details"
+// @Param        content    body    configmodels.SubsOverrideData    true    "Updated subscriber details"
 // @Security     BearerAuth
 // @Success      204  {object}  nil  "Subscriber updated successfully"
 // @Failure      400  {object}  nil  "Invalid subscriber content"
@@ -537,7 +736,11 @@ func PutSubscriberByID(c *gin.Context) {
 		return
 	}
 
-	ueId := c.Param("ueId")
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
+		return
+	}
+	ueId := supi.String()
 	logger.WebUILog.Infoln("Received Put Subscriber Data from Roc/Simapp:", ueId)
 
 	filter := bson.M{"ueId": ueId}
@@ -552,21 +755,14 @@ func PutSubscriberByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("subscriber %s does not exist", ueId)})
 		return
 	}
-	if subsOverrideData.OPc == "" || subsOverrideData.Key == "" || subsOverrideData.SequenceNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required authentication data: OPc, Key and Sequence number must be provided", "request_id": requestID})
+	authSubsData, err := newAuthenticationSubscription(subsOverrideData)
+	if err != nil {
+		logger.WebUILog.Errorf("Put One Subscriber Data - invalid authentication data: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
 		return
 	}
-	authSubsData := models.AuthenticationSubscription{
-		AuthenticationManagementField: openapi.PtrString("8000"),
-		AuthenticationMethod:          "5G_AKA",
-		EncOpcKey:                     openapi.PtrString(subsOverrideData.OPc),
-		EncPermanentKey:               openapi.PtrString(subsOverrideData.Key),
-		SequenceNumber: &models.SequenceNumber{
-			Sqn: openapi.PtrString(subsOverrideData.SequenceNumber),
-		},
-	}
 
-	err = subscriberAuthenticationDataUpdate(ueId, &authSubsData)
+	err = subscriberAuthenticationDataUpdate(ueId, authSubsData)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":      fmt.Sprintf("Failed to update subscriber %s", ueId),
@@ -579,10 +775,226 @@ func PutSubscriberByID(c *gin.Context) {
 	c.JSON(http.StatusNoContent, gin.H{})
 }
 
-// Patch subscriber by IMSI(ueId) and PlmnID(servingPlmnId)
+// PatchSubscriberByID godoc
+//
+// @Description  Partially update the authentication subscription of a subscriber by IMSI (UE ID).
+// @Description  Accepts a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) document.
+// @Tags         Subscribers
+// @Accept       application/merge-patch+json,application/json-patch+json
+// @Param        imsi       path    string    true    "IMSI (UE ID)"
+// @Param        content    body    object    true    "Merge patch or JSON patch document"
+// @Security     BearerAuth
+// @Success      204  {object}  nil  "Subscriber updated successfully"
+// @Failure      400  {object}  nil  "Invalid patch document"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Subscriber not found"
+// @Failure      500  {object}  nil  "Error updating subscriber"
+// @Router       /api/subscriber/{imsi}  [patch]
 func PatchSubscriberByID(c *gin.Context) {
 	setCorsHeader(c)
 	logger.WebUILog.Infoln("Patch One Subscriber Data")
+	requestID := uuid.New().String()
+
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
+		return
+	}
+	ueId := supi.String()
+	ct := strings.Split(c.GetHeader("Content-Type"), ";")[0]
+	if ct != mergePatchContentType && ct != jsonPatchContentType {
+		err := fmt.Sprintf("unsupported content-type: %s", ct)
+		logger.WebUILog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err, "request_id": requestID})
+		return
+	}
+	patchJSON, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		logger.WebUILog.Errorf("Patch One Subscriber Data - failed to read body: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "request_id": requestID})
+		return
+	}
+
+	var patchData map[string]any
+	var patchOperations []dbadapter.PatchOperation
+	if ct == mergePatchContentType {
+		err = json.Unmarshal(patchJSON, &patchData)
+		if err == nil {
+			err = validateAuthSubscriptionMergePatch(patchData)
+		}
+	} else {
+		err = json.Unmarshal(patchJSON, &patchOperations)
+		if err == nil {
+			err = validateAuthSubscriptionJSONPatch(patchOperations)
+		}
+	}
+	if err != nil {
+		logger.WebUILog.Errorf("invalid patch document for subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid patch document: %s", err.Error()), "request_id": requestID})
+		return
+	}
+
+	filter := bson.M{"ueId": ueId}
+	authSubsData, err := dbadapter.AuthDBClient.RestfulAPIGetOne(authSubsDataColl, filter)
+	if err != nil {
+		logger.DbLog.Errorf("failed querying subscriber existence for IMSI: %s; Error: %+v", ueId, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to check subscriber: %s existence", ueId), "request_id": requestID})
+		return
+	}
+	if authSubsData == nil {
+		logger.WebUILog.Errorf("subscriber %s does not exist", ueId)
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("subscriber %s does not exist", ueId), "request_id": requestID})
+		return
+	}
+
+	if ct == mergePatchContentType {
+		err = subscriberAuthenticationDataMergePatch(ueId, patchData)
+	} else {
+		err = subscriberAuthenticationDataJSONPatch(c.Request.Context(), ueId, patchOperations)
+	}
+	if err != nil {
+		logger.WebUILog.Errorf("Failed to patch subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      fmt.Sprintf("Failed to update subscriber %s", ueId),
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	logger.WebUILog.Infof("Subscriber %s patched successfully", ueId)
+	c.JSON(http.StatusNoContent, gin.H{})
+}
+
+// ReconcileSubscribers godoc
+//
+// @Description  Scan the subscription data for orphaned records and inconsistencies.
+// @Description  The orphaned records are only reported unless repair is set by an admin user.
+// @Tags         Subscribers
+// @Produce      json
+// @Param        repair    query    bool    false  "Delete the orphaned records (admin only)"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.SubsReconciliationReport  "Reconciliation report"
+// @Failure      400  {object}  nil  "Invalid query parameters"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      500  {object}  nil  "Error reconciling subscribers"
+// @Router       /api/subscriber-reconciliation  [post]
+func ReconcileSubscribers(c *gin.Context) {
+	setCorsHeader(c)
+	logger.WebUILog.Infoln("Reconcile Subscribers")
+	requestID := uuid.New().String()
+
+	repair := false
+	if value := c.Query("repair"); value != "" {
+		var err error
+		repair, err = strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid repair value: %s", value), "request_id": requestID})
+			return
+		}
+	}
+	if repair && !isAdminRequest(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: admin access required to repair subscriber data", "request_id": requestID})
+		return
+	}
+
+	report, err := ReconcileSubscriberData(repair)
+	if err != nil {
+		logger.WebUILog.Errorf("Reconcile Subscribers failed: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "Failed to reconcile subscribers",
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetSubscriberReconciliationReport godoc
+//
+// @Description  Return the report of the last reconciliation of the subscription data
+// @Tags         Subscribers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.SubsReconciliationReport  "Reconciliation report"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "No reconciliation has run"
+// @Router       /api/subscriber-reconciliation  [get]
+func GetSubscriberReconciliationReport(c *gin.Context) {
+	setCorsHeader(c)
+	logger.WebUILog.Infoln("Get Subscriber Reconciliation Report")
+
+	report := getLastReconciliationReport()
+	if report == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no subscriber reconciliation has run"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ResyncSubscriberSequenceNumber godoc
+//
+// @Description  Resynchronise the sequence number of a subscriber by IMSI (UE ID) without changing its K and OPc.
+// @Description  Either the new sequence number or the RAND and AUTS of a synchronisation failure reported by the UE must be provided.
+// @Tags         Subscribers
+// @Accept       json
+// @Produce      json
+// @Param        imsi       path    string                             true    "IMSI (UE ID)"
+// @Param        content    body    configmodels.SubsSqnResync         true    "New sequence number, or RAND and AUTS"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.SubsSqnResyncRecord  "Sequence number resynchronised successfully"
+// @Failure      400  {object}  nil  "Invalid request or AUTS verification failed"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Subscriber not found"
+// @Failure      500  {object}  nil  "Error resynchronising sequence number"
+// @Router       /api/subscriber/{imsi}/sqn-resync  [post]
+func ResyncSubscriberSequenceNumber(c *gin.Context) {
+	setCorsHeader(c)
+	logger.WebUILog.Infoln("Resync Subscriber Sequence Number")
+	requestID := uuid.New().String()
+
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
+		return
+	}
+	ueId := supi.String()
+	var resync configmodels.SubsSqnResync
+	if err := c.ShouldBindJSON(&resync); err != nil {
+		logger.WebUILog.Errorf("Resync Subscriber Sequence Number - ShouldBindJSON failed: %+v request ID: %s", err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: failed to parse JSON.", "request_id": requestID})
+		return
+	}
+	method, err := validateSqnResync(&resync)
+	if err != nil {
+		logger.WebUILog.Errorf("invalid sequence number resynchronisation for subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+
+	record, err := subscriberSequenceNumberResync(ueId, method, resync, c.GetString(auth.UsernameContextKey))
+	switch {
+	case errors.Is(err, errSubscriberNotFound):
+		logger.WebUILog.Errorf("subscriber %s does not exist", ueId)
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("subscriber %s does not exist", ueId), "request_id": requestID})
+		return
+	case errors.Is(err, errSqnResyncMacMismatch):
+		logger.WebUILog.Errorf("failed to resynchronise sequence number of subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	case err != nil:
+		logger.WebUILog.Errorf("failed to resynchronise sequence number of subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      fmt.Sprintf("Failed to resynchronise sequence number of subscriber %s", ueId),
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	logger.WebUILog.Infof("Subscriber %s sequence number resynchronised from %s to %s", ueId, record.PreviousSequenceNumber, record.SequenceNumber)
+	c.JSON(http.StatusOK, record)
 }
 
 // DeleteSubscriberByID godoc
@@ -592,8 +1004,10 @@ func PatchSubscriberByID(c *gin.Context) {
 // @Param        imsi    path    string    true    "IMSI (UE ID)"
 // @Security     BearerAuth
 // @Success      204  {object}  nil  "Subscriber deleted successfully"
+// @Failure      400  {object}  nil  "Invalid UE ID"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Subscriber not found"
 // @Failure      500  {object}  nil  "Error deleting subscriber"
 // @Router       /api/subscriber/{imsi}  [delete]
 func DeleteSubscriberByID(c *gin.Context) {
@@ -601,22 +1015,20 @@ func DeleteSubscriberByID(c *gin.Context) {
 	logger.WebUILog.Infoln("Delete One Subscriber Data")
 	requestID := uuid.New().String()
 
-	ueId := c.Param("ueId")
-
-	imsi := strings.TrimPrefix(ueId, "imsi-")
-	statusCode, err := updateSubscriberInDeviceGroups(imsi)
-	if err != nil {
-		logger.WebUILog.Errorf("Failed to update subscriber: %+v request ID: %s", err, requestID)
-		c.JSON(statusCode, gin.H{"error": "error deleting subscriber. Please check the log for details.", "request_id": requestID})
+	supi, ok := parseUeIdParam(c, requestID)
+	if !ok {
 		return
 	}
-	if err = subscriberAuthenticationDataDelete(ueId); err != nil {
-		logger.WebUILog.Errorf("Error deleting subscriber: %s", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":      fmt.Sprintf("Failed to delete subscriber %s", ueId),
-			"request_id": requestID,
-			"message":    "Please refer to the log with the provided Request ID for details",
-		})
+	ueId := supi.String()
+
+	if err := subscriberDelete(supi); err != nil {
+		if errors.Is(err, errSubscriberNotFound) {
+			logger.WebUILog.Errorf("subscriber %s does not exist", ueId)
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("subscriber %s does not exist", ueId), "request_id": requestID})
+			return
+		}
+		logger.WebUILog.Errorf("Error deleting subscriber %s: %+v request ID: %s", ueId, err, requestID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting subscriber. Please check the log for details.", "request_id": requestID})
 		return
 	}
 	logger.WebUILog.Infof("Subscriber %s deleted successfully", ueId)
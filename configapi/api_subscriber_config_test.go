@@ -46,6 +46,15 @@ func (m *MockMongoClientOneSubscriber) RestfulAPIGetMany(coll string, filter bso
 	return results, nil
 }
 
+func (m *MockMongoClientOneSubscriber) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	return m.RestfulAPIGetMany(coll, filter)
+}
+
+func (m *MockMongoClientOneSubscriber) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	results, err := m.RestfulAPIGetMany(coll, filter)
+	return int64(len(results)), err
+}
+
 func (m *MockMongoClientOneSubscriber) RestfulAPIGetOne(collName string, filter bson.M) (map[string]any, error) {
 	m.postDataCommon = append(m.postDataCommon, map[string]any{
 		"coll":   collName,
@@ -70,6 +79,15 @@ func (m *MockMongoClientManySubscribers) RestfulAPIGetMany(coll string, filter b
 	return results, nil
 }
 
+func (m *MockMongoClientManySubscribers) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	return m.RestfulAPIGetMany(coll, filter)
+}
+
+func (m *MockMongoClientManySubscribers) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	results, err := m.RestfulAPIGetMany(coll, filter)
+	return int64(len(results)), err
+}
+
 type MockAuthDBClientEmpty struct {
 	dbadapter.DBInterface
 	postDataAuth []map[string]any
@@ -142,6 +160,15 @@ func (m *MockCommonDBClientEmpty) RestfulAPIGetMany(coll string, filter bson.M)
 	return nil, nil
 }
 
+func (m *MockCommonDBClientEmpty) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	return m.RestfulAPIGetMany(coll, filter)
+}
+
+func (m *MockCommonDBClientEmpty) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	results, err := m.RestfulAPIGetMany(coll, filter)
+	return int64(len(results)), err
+}
+
 type MockCommonDBClientWithData struct {
 	dbadapter.DBInterface
 	postDataCommon []map[string]any
@@ -623,6 +650,7 @@ func (db *AuthDBMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M
 type PostSubscriberMockDBClient struct {
 	dbadapter.DBInterface
 	subscribers         []string
+	slices              []configmodels.Slice
 	receivedGetData     []map[string]any
 	receivedPostData    []map[string]any
 	receivedPostOnDB    []map[string]any
@@ -649,6 +677,14 @@ func (db *PostSubscriberMockDBClient) RestfulAPIGetOne(collName string, filter b
 	return subscriber, nil
 }
 
+func (db *PostSubscriberMockDBClient) RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	for _, slice := range db.slices {
+		results = append(results, configmodels.ToBsonM(slice))
+	}
+	return results, nil
+}
+
 func (db *PostSubscriberMockDBClient) RestfulAPIPost(collName string, filter bson.M, postData map[string]any) (bool, error) {
 	db.receivedPostData = append(db.receivedPostData, map[string]any{
 		"coll":   collName,
@@ -798,12 +834,120 @@ func TestSubscriberPost(t *testing.T) {
 	}
 }
 
+func TestSubscriberPostAuthenticationOptions(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+
+	tests := []struct {
+		name           string
+		inputData      map[string]string
+		expectedCode   int
+		expectedMethod string
+		expectedAmf    string
+		expectedOpc    string
+	}{
+		{
+			name: "EAP-AKA' subscriber with custom AMF",
+			inputData: map[string]string{
+				"opc":                           "8e27b6af0e692e750f32667a3b14605d",
+				"key":                           "8baf473f2f8fd09487cccbd7097c6862",
+				"sequenceNumber":                "16f3b3f70fc2",
+				"authenticationMethod":          "EAP_AKA_PRIME",
+				"authenticationManagementField": "9001",
+			},
+			expectedCode:   http.StatusCreated,
+			expectedMethod: "EAP_AKA_PRIME",
+			expectedAmf:    "9001",
+			expectedOpc:    "8e27b6af0e692e750f32667a3b14605d",
+		},
+		{
+			name: "Subscriber provisioned with OP",
+			inputData: map[string]string{
+				"op":             "cdc202d5123e20f62b6d676ac72cb318",
+				"key":            "465b5ce8b199b49faa5f0a2ee238a6bc",
+				"sequenceNumber": "ff9bb4d0b607",
+			},
+			expectedCode:   http.StatusCreated,
+			expectedMethod: "5G_AKA",
+			expectedAmf:    "8000",
+			expectedOpc:    "cd63cb71954a9f4e48a5994e37a02baf",
+		},
+		{
+			name: "Invalid authentication method",
+			inputData: map[string]string{
+				"opc":                  "8e27b6af0e692e750f32667a3b14605d",
+				"key":                  "8baf473f2f8fd09487cccbd7097c6862",
+				"sequenceNumber":       "16f3b3f70fc2",
+				"authenticationMethod": "EAP_TLS",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid key length",
+			inputData: map[string]string{
+				"opc":            "8e27b6af0e692e750f32667a3b14605d",
+				"key":            "8baf473f",
+				"sequenceNumber": "16f3b3f70fc2",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			AddApiService(router)
+
+			origDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = origDBClient }()
+			mock := &PostSubscriberMockDBClient{}
+			dbadapter.CommonDBClient = mock
+
+			jsonData, err := json.Marshal(tc.inputData)
+			if err != nil {
+				t.Fatalf("failed to marshal input data to JSON: %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/api/subscriber/imsi-208930100007487", bytes.NewBuffer(jsonData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedCode != http.StatusCreated {
+				if len(mock.receivedPostOnDB) != 0 {
+					t.Errorf("expected no authentication subscription to be created")
+				}
+				return
+			}
+			if len(mock.receivedPostOnDB) != 1 {
+				t.Fatalf("expected 1 PostOnDB call, got %d", len(mock.receivedPostOnDB))
+			}
+			data := mock.receivedPostOnDB[0]["data"].(map[string]any)
+			if data["authenticationMethod"] != tc.expectedMethod {
+				t.Errorf("expected authentication method %s, got %v", tc.expectedMethod, data["authenticationMethod"])
+			}
+			if data["authenticationManagementField"] != tc.expectedAmf {
+				t.Errorf("expected AMF %s, got %v", tc.expectedAmf, data["authenticationManagementField"])
+			}
+			if data["encOpcKey"] != tc.expectedOpc {
+				t.Errorf("expected OPc %s, got %v", tc.expectedOpc, data["encOpcKey"])
+			}
+		})
+	}
+}
+
 type DeleteSubscriberMockDBClient struct {
 	dbadapter.DBInterface
 	deviceGroups      []configmodels.DeviceGroups
 	deleteData        []map[string]any
 	deleteOnDBData    []map[string]any
 	deleteWithCtxData []map[string]any
+	putWithCtxData    []map[string]any
 	err               error
 }
 
@@ -868,6 +1012,29 @@ func (db *DeleteSubscriberMockDBClient) RestfulAPIDeleteOneOnDB(ctx context.Cont
 	return nil
 }
 
+func (db *DeleteSubscriberMockDBClient) RestfulAPIDeleteManyWithContext(ctx context.Context, collName string, filter bson.M) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.deleteWithCtxData = append(db.deleteWithCtxData, map[string]any{
+		"coll":   collName,
+		"filter": filter,
+	})
+	return nil
+}
+
+func (db *DeleteSubscriberMockDBClient) RestfulAPIPutOneWithContext(ctx context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	db.putWithCtxData = append(db.putWithCtxData, map[string]any{
+		"coll":   collName,
+		"filter": filter,
+		"data":   putData,
+	})
+	return true, nil
+}
+
 func (db *DeleteSubscriberMockDBClient) RestfulAPIDeleteOneWithContext(ctx context.Context, collName string, filter bson.M) error {
 	if db.err != nil {
 		return db.err
@@ -886,7 +1053,9 @@ func TestSubscriberDelete(t *testing.T) {
 	tests := []struct {
 		name            string
 		commonDbAdapter dbadapter.DBInterface
+		authDbAdapter   dbadapter.DBInterface
 		expectedCode    int
+		expectedBody    string
 	}{
 		{
 			name: "Subscriber belongs to a device group",
@@ -895,14 +1064,25 @@ func TestSubscriberDelete(t *testing.T) {
 					deviceGroupWithImsis("group1", []string{"208930100007487"}),
 				},
 			},
-			expectedCode: http.StatusNoContent,
+			authDbAdapter: &AuthDBMockDBClient{},
+			expectedCode:  http.StatusNoContent,
 		},
 		{
 			name: "Subscriber does not belongs to any device group",
 			commonDbAdapter: &DeleteSubscriberMockDBClient{
 				deviceGroups: []configmodels.DeviceGroups{},
 			},
-			expectedCode: http.StatusNoContent,
+			authDbAdapter: &AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}},
+			expectedCode:  http.StatusNoContent,
+		},
+		{
+			name: "Subscriber does not exist",
+			commonDbAdapter: &DeleteSubscriberMockDBClient{
+				deviceGroups: []configmodels.DeviceGroups{},
+			},
+			authDbAdapter: &AuthDBMockDBClient{},
+			expectedCode:  http.StatusNotFound,
+			expectedBody:  "subscriber imsi-208930100007487 does not exist",
 		},
 	}
 	for _, tc := range tests {
@@ -917,10 +1097,9 @@ func TestSubscriberDelete(t *testing.T) {
 				dbadapter.AuthDBClient = origAuthDBClient
 			}()
 			dbadapter.CommonDBClient = tc.commonDbAdapter
-			dbadapter.AuthDBClient = &AuthDBMockDBClient{}
+			dbadapter.AuthDBClient = tc.authDbAdapter
 			route := "/api/subscriber/imsi-208930100007487"
 			expectedCode := tc.expectedCode
-			expectedBody := ""
 
 			req, err := http.NewRequest(http.MethodDelete, route, nil)
 			if err != nil {
@@ -934,8 +1113,11 @@ func TestSubscriberDelete(t *testing.T) {
 			if expectedCode != w.Code {
 				t.Errorf("expected status code `%v`, got `%v`", expectedCode, w.Code)
 			}
-			if expectedBody != w.Body.String() {
-				t.Errorf("expected body `%v`, got `%v`", expectedBody, w.Body.String())
+			if tc.expectedBody == "" && w.Body.String() != "" {
+				t.Errorf("expected empty body, got `%v`", w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body containing `%v`, got `%v`", tc.expectedBody, w.Body.String())
 			}
 		})
 	}
@@ -1007,3 +1189,236 @@ func deviceGroupWithImsis(name string, imsis []string) configmodels.DeviceGroups
 	}
 	return deviceGroup
 }
+
+type PatchSubscriberMockDBClient struct {
+	AuthDBMockDBClient
+	receivedMergePatch []map[string]any
+	receivedJSONPatch  [][]byte
+	patchErr           error
+}
+
+func (db *PatchSubscriberMockDBClient) RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]any) error {
+	if db.patchErr != nil {
+		return db.patchErr
+	}
+	db.receivedMergePatch = append(db.receivedMergePatch, patchData)
+	return nil
+}
+
+func (db *PatchSubscriberMockDBClient) RestfulAPIJSONPatchWithContext(ctx context.Context, collName string, filter bson.M, patchJSON []byte) error {
+	if db.patchErr != nil {
+		return db.patchErr
+	}
+	db.receivedJSONPatch = append(db.receivedJSONPatch, patchJSON)
+	return nil
+}
+
+func TestSubscriberPatch(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+
+	tests := []struct {
+		name               string
+		authDbAdapter      *PatchSubscriberMockDBClient
+		contentType        string
+		body               string
+		expectedCode       int
+		expectedBody       string
+		expectedMergePatch int
+		expectedJSONPatch  int
+	}{
+		{
+			name:               "Merge patch updates the OPc and SQN",
+			authDbAdapter:      &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:        "application/merge-patch+json",
+			body:               `{"encOpcKey": "8e27b6af0e692e750f32667a3b14605e", "sequenceNumber": {"sqn": "16f3b3f70fc3"}}`,
+			expectedCode:       http.StatusNoContent,
+			expectedMergePatch: 1,
+		},
+		{
+			name:              "JSON patch replaces the authentication method",
+			authDbAdapter:     &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:       "application/json-patch+json",
+			body:              `[{"op": "replace", "path": "/authenticationMethod", "value": "EAP_AKA_PRIME"}]`,
+			expectedCode:      http.StatusNoContent,
+			expectedJSONPatch: 1,
+		},
+		{
+			name:          "Merge patch with invalid key length is rejected",
+			authDbAdapter: &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:   "application/merge-patch+json",
+			body:          `{"encPermanentKey": "8baf473f"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  "Invalid patch document",
+		},
+		{
+			name:          "Merge patch on a non patchable field is rejected",
+			authDbAdapter: &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:   "application/merge-patch+json",
+			body:          `{"ueId": "imsi-208930100007488"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  "Invalid patch document",
+		},
+		{
+			name:          "JSON patch remove operation is rejected",
+			authDbAdapter: &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:   "application/json-patch+json",
+			body:          `[{"op": "remove", "path": "/encOpcKey"}]`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  "Invalid patch document",
+		},
+		{
+			name:          "Unsupported content type is rejected",
+			authDbAdapter: &PatchSubscriberMockDBClient{AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}},
+			contentType:   "application/json",
+			body:          `{"encOpcKey": "8e27b6af0e692e750f32667a3b14605e"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  "unsupported content-type",
+		},
+		{
+			name:          "Non existing subscriber returns not found",
+			authDbAdapter: &PatchSubscriberMockDBClient{},
+			contentType:   "application/merge-patch+json",
+			body:          `{"encOpcKey": "8e27b6af0e692e750f32667a3b14605e"}`,
+			expectedCode:  http.StatusNotFound,
+			expectedBody:  "subscriber imsi-208930100007487 does not exist",
+		},
+		{
+			name: "DB failure returns internal server error",
+			authDbAdapter: &PatchSubscriberMockDBClient{
+				AuthDBMockDBClient: AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}},
+				patchErr:           fmt.Errorf("mock error"),
+			},
+			contentType:  "application/merge-patch+json",
+			body:         `{"encOpcKey": "8e27b6af0e692e750f32667a3b14605e"}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "Failed to update subscriber imsi-208930100007487",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			AddApiService(router)
+			origAuthDBClient := dbadapter.AuthDBClient
+			defer func() { dbadapter.AuthDBClient = origAuthDBClient }()
+			dbadapter.AuthDBClient = tc.authDbAdapter
+
+			req, err := http.NewRequest(http.MethodPatch, "/api/subscriber/imsi-208930100007487", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tc.expectedBody) {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+			if len(tc.authDbAdapter.receivedMergePatch) != tc.expectedMergePatch {
+				t.Errorf("expected %d merge patch calls, got %d", tc.expectedMergePatch, len(tc.authDbAdapter.receivedMergePatch))
+			}
+			if len(tc.authDbAdapter.receivedJSONPatch) != tc.expectedJSONPatch {
+				t.Errorf("expected %d JSON patch calls, got %d", tc.expectedJSONPatch, len(tc.authDbAdapter.receivedJSONPatch))
+			}
+		})
+	}
+}
+
+func TestSubscriberHandlersMalformedUeId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+
+	body := `{"opc": "8e27b6af0e692e750f32667a3b14605d", "key": "8baf473f2f8fd09487cccbd7097c6862", "sequenceNumber": "16f3b3f70fc2"}`
+	tests := []struct {
+		method      string
+		contentType string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPost, contentType: "application/json"},
+		{method: http.MethodPut, contentType: "application/json"},
+		{method: http.MethodPatch, contentType: "application/merge-patch+json"},
+		{method: http.MethodDelete},
+	}
+	for _, tc := range tests {
+		for _, ueId := range []string{"imsi-20893", "imsi-20893010000748a", "some-subs", "nai-user"} {
+			t.Run(tc.method+" "+ueId, func(t *testing.T) {
+				req, err := http.NewRequest(tc.method, "/api/subscriber/"+ueId, strings.NewReader(body))
+				if err != nil {
+					t.Fatalf("failed to create request: %v", err)
+				}
+				if tc.contentType != "" {
+					req.Header.Set("Content-Type", tc.contentType)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				if w.Code != http.StatusBadRequest {
+					t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+				}
+			})
+		}
+	}
+}
+
+func TestSubscriberPostPlmnValidation(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+
+	tests := []struct {
+		name         string
+		ueId         string
+		expectedCode int
+		expectedUeId string
+	}{
+		{
+			name:         "IMSI of a configured PLMN",
+			ueId:         "IMSI-208930100007487",
+			expectedCode: http.StatusCreated,
+			expectedUeId: "imsi-208930100007487",
+		},
+		{
+			name:         "IMSI of an unknown PLMN",
+			ueId:         "imsi-310260000000001",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			AddApiService(router)
+
+			origDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = origDBClient }()
+			mock := &PostSubscriberMockDBClient{slices: []configmodels.Slice{networkSlice("slice1")}}
+			dbadapter.CommonDBClient = mock
+
+			body := `{"opc": "8e27b6af0e692e750f32667a3b14605d", "key": "8baf473f2f8fd09487cccbd7097c6862", "sequenceNumber": "16f3b3f70fc2"}`
+			req, err := http.NewRequest(http.MethodPost, "/api/subscriber/"+tc.ueId, strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedUeId == "" {
+				return
+			}
+			if len(mock.receivedPostOnDB) != 1 {
+				t.Fatalf("expected 1 PostOnDB call, got %d", len(mock.receivedPostOnDB))
+			}
+			expectedFilter := bson.M{"ueId": tc.expectedUeId}
+			if !reflect.DeepEqual(mock.receivedPostOnDB[0]["filter"], expectedFilter) {
+				t.Errorf("expected filter %v, got %v", expectedFilter, mock.receivedPostOnDB[0]["filter"])
+			}
+		})
+	}
+}
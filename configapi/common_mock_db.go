@@ -51,6 +51,14 @@ func (db *MockMongoClientDBError) RestfulAPIGetMany(coll string, filter bson.M)
 	return nil, errors.New("DB error")
 }
 
+func (db *MockMongoClientDBError) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	return nil, errors.New("DB error")
+}
+
+func (db *MockMongoClientDBError) RestfulAPIDistinct(coll string, fieldName string, filter bson.M) ([]any, error) {
+	return nil, errors.New("DB error")
+}
+
 func (db *MockMongoClientDBError) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	return false, errors.New("DB error")
 }
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/identity"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const allowDuplicateImsisParam = "allow-duplicate-imsis"
+
+var errImsiConflict = errors.New("IMSIs already belong to another device group")
+
+// allowDuplicateImsis returns the value of the allow-duplicate-imsis query parameter,
+// false when it is not set
+func allowDuplicateImsis(c *gin.Context) (bool, error) {
+	value := c.Query(allowDuplicateImsisParam)
+	if value == "" {
+		return false, nil
+	}
+	allow, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: expected true or false", allowDuplicateImsisParam, value)
+	}
+	return allow, nil
+}
+
+// appendDeviceGroupMembers appends the IMSIs of devGroup to members, each IMSI listed
+// individually being appended as a range of a single IMSI
+func appendDeviceGroupMembers(members []deviceGroupImsiRange, devGroup *configmodels.DeviceGroups) []deviceGroupImsiRange {
+	for _, imsi := range devGroup.Imsis {
+		imsiRange, err := identity.NewImsiRange(imsi, imsi, 0)
+		if err != nil {
+			logger.ConfigLog.Warnf("skipping invalid IMSI %s of device group %s: %+v", imsi, devGroup.DeviceGroupName, err)
+			continue
+		}
+		members = append(members, deviceGroupImsiRange{imsiRange: imsiRange, deviceGroup: devGroup.DeviceGroupName})
+	}
+	return appendDeviceGroupImsiRanges(members, devGroup)
+}
+
+// getDeviceGroups returns every device group except excludedGroup
+func getDeviceGroups(excludedGroup string) ([]configmodels.DeviceGroups, error) {
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch device groups: %w", err)
+	}
+	devGroups := make([]configmodels.DeviceGroups, 0, len(rawDeviceGroups))
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		devGroup, err := unmarshalDeviceGroup(rawDeviceGroup)
+		if err != nil {
+			return nil, err
+		}
+		if devGroup.DeviceGroupName != excludedGroup {
+			devGroups = append(devGroups, *devGroup)
+		}
+	}
+	return devGroups, nil
+}
+
+// findImsiConflicts returns the IMSIs shared by two different device groups. The ranges
+// are sorted and swept once, so that the IMSIs are compared without being expanded.
+func findImsiConflicts(members []deviceGroupImsiRange) []configmodels.ImsiConflict {
+	sorted := slices.Clone(members)
+	slices.SortFunc(sorted, func(a, b deviceGroupImsiRange) int {
+		return cmp.Or(
+			cmp.Compare(len(a.imsiRange.Start()), len(b.imsiRange.Start())),
+			strings.Compare(a.imsiRange.Start(), b.imsiRange.Start()),
+		)
+	})
+	conflicts := []configmodels.ImsiConflict{}
+	var active []deviceGroupImsiRange
+	for _, member := range sorted {
+		// the ranges ending before this one cannot overlap any later range
+		active = slices.DeleteFunc(active, func(a deviceGroupImsiRange) bool {
+			_, overlaps := a.imsiRange.Intersect(member.imsiRange)
+			return !overlaps
+		})
+		for _, a := range active {
+			if a.deviceGroup == member.deviceGroup {
+				continue
+			}
+			shared, _ := a.imsiRange.Intersect(member.imsiRange)
+			deviceGroups := []string{a.deviceGroup, member.deviceGroup}
+			slices.Sort(deviceGroups)
+			conflicts = append(conflicts, configmodels.ImsiConflict{
+				Start:        shared.Start(),
+				End:          shared.End(),
+				DeviceGroups: deviceGroups,
+			})
+		}
+		active = append(active, member)
+	}
+	return conflicts
+}
+
+// checkImsiConflicts returns the IMSIs of devGroup which already belong to one of
+// otherGroups. Unless allowDuplicates is set, the conflicts are returned as an errImsiConflict.
+func checkImsiConflicts(devGroup *configmodels.DeviceGroups, otherGroups []configmodels.DeviceGroups, allowDuplicates bool) ([]configmodels.ImsiConflict, error) {
+	var members []deviceGroupImsiRange
+	for i := range otherGroups {
+		members = appendDeviceGroupMembers(members, &otherGroups[i])
+	}
+	conflicts := findImsiConflicts(appendDeviceGroupMembers(members, devGroup))
+	conflicts = slices.DeleteFunc(conflicts, func(conflict configmodels.ImsiConflict) bool {
+		return !slices.Contains(conflict.DeviceGroups, devGroup.DeviceGroupName)
+	})
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		description := conflict.Start
+		if conflict.End != conflict.Start {
+			description += "-" + conflict.End
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", description, strings.Join(conflict.DeviceGroups, ", ")))
+	}
+	if !allowDuplicates {
+		return conflicts, fmt.Errorf("%w: %s", errImsiConflict, strings.Join(descriptions, "; "))
+	}
+	logger.ConfigLog.Warnf("device group %s shares IMSIs with other device groups: %s", devGroup.DeviceGroupName, strings.Join(descriptions, "; "))
+	return conflicts, nil
+}
+
+// GetImsiConflicts godoc
+//
+// @Description  Return the IMSIs belonging to more than one device group
+// @Tags         Device Groups
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.ImsiConflict  "IMSIs shared by device groups"
+// @Failure      401  {object}  nil                        "Authorization failed"
+// @Failure      403  {object}  nil                        "Forbidden"
+// @Failure      500  {object}  nil                        "Error retrieving device groups"
+// @Router       /config/v1/imsi-conflicts  [get]
+func GetImsiConflicts(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get IMSI conflicts")
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to retrieve device groups: %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "failed to retrieve device groups",
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	var members []deviceGroupImsiRange
+	for i := range devGroups {
+		members = appendDeviceGroupMembers(members, &devGroups[i])
+	}
+	c.JSON(http.StatusOK, findImsiConflicts(members))
+}
+
+// respondImsiConflicts reports the IMSIs shared with other device groups when they were allowed
+func respondImsiConflicts(c *gin.Context, conflicts []configmodels.ImsiConflict) {
+	if len(conflicts) == 0 {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"imsi-conflicts": conflicts})
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+)
+
+func TestFindImsiConflicts(t *testing.T) {
+	group1 := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100000500"})
+	group1.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100001000", Count: 100}}
+	group2 := deviceGroupWithImsis("group2", []string{"208930100007487", "20893010000050"})
+	group2.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100000001", End: "208930100001009"}}
+	group3 := deviceGroupWithImsis("group3", []string{"208930100007488"})
+
+	var members []deviceGroupImsiRange
+	for _, group := range []configmodels.DeviceGroups{group1, group2, group3} {
+		members = appendDeviceGroupMembers(members, &group)
+	}
+	expected := []configmodels.ImsiConflict{
+		{Start: "208930100000500", End: "208930100000500", DeviceGroups: []string{"group1", "group2"}},
+		{Start: "208930100001000", End: "208930100001009", DeviceGroups: []string{"group1", "group2"}},
+		{Start: "208930100007487", End: "208930100007487", DeviceGroups: []string{"group1", "group2"}},
+	}
+	conflicts := findImsiConflicts(members)
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("expected conflicts %+v, got %+v", expected, conflicts)
+	}
+}
+
+func TestDeviceGroupPostHandler_ImsiConflicts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		query        string
+		imsis        []string
+		expectedCode int
+		expectStored bool
+	}{
+		{name: "IMSIs of no other group", imsis: []string{"208930100007489"}, expectedCode: http.StatusOK, expectStored: true},
+		{name: "IMSI of another group is rejected", imsis: []string{"208930100007488"}, expectedCode: http.StatusConflict},
+		{name: "IMSI of another group is allowed", query: "?allow-duplicate-imsis=true", imsis: []string{"208930100007488"}, expectedCode: http.StatusOK, expectStored: true},
+		{name: "Invalid flag", query: "?allow-duplicate-imsis=maybe", imsis: []string{"208930100007488"}, expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &DeviceGroupMockDBClient{configuredDeviceGroups: []configmodels.DeviceGroups{deviceGroup("group2")}}
+			dbadapter.CommonDBClient = mock
+
+			newDeviceGroup := deviceGroup("group1")
+			newDeviceGroup.Imsis = tc.imsis
+			jsonBody, err := json.Marshal(newDeviceGroup)
+			if err != nil {
+				t.Fatalf("failed to marshal device group %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1"+tc.query, bytes.NewReader(jsonBody))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if tc.expectedCode != w.Code {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectStored != (len(mock.postData) > 0) {
+				t.Errorf("expected device group stored: %t, got %d posts", tc.expectStored, len(mock.postData))
+			}
+			if tc.query == "" || w.Code != http.StatusOK {
+				return
+			}
+			var response struct {
+				ImsiConflicts []configmodels.ImsiConflict `json:"imsi-conflicts"`
+			}
+			if err = json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			expected := []configmodels.ImsiConflict{{Start: "208930100007488", End: "208930100007488", DeviceGroups: []string{"group1", "group2"}}}
+			if !reflect.DeepEqual(response.ImsiConflicts, expected) {
+				t.Errorf("expected conflicts %+v, got %+v", expected, response.ImsiConflicts)
+			}
+		})
+	}
+}
+
+func TestGetImsiConflicts(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &DeviceGroupMockDBClient{
+		configuredDeviceGroups: []configmodels.DeviceGroups{deviceGroup("group1"), deviceGroup("group2")},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	req, err := http.NewRequest(http.MethodGet, "/config/v1/imsi-conflicts", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	expected := `[{"start":"208930100007487","end":"208930100007487","device-groups":["group1","group2"]},` +
+		`{"start":"208930100007488","end":"208930100007488","device-groups":["group1","group2"]}]`
+	if w.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, w.Body.String())
+	}
+}
@@ -6,6 +6,7 @@ package configapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/omec-project/openapi/v2"
 	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
@@ -72,47 +74,120 @@ func updateDeviceGroupInNetworkSlices(groupName string) error {
 	return nil
 }
 
-func deviceGroupPostHelper(requestDeviceGroup configmodels.DeviceGroups, groupName string) (int, error) {
+// deviceGroupPostHelper validates and stores the device group. The IMSIs it shares with other
+// device groups are rejected unless allowDuplicates is set, in which case they are returned.
+func deviceGroupPostHelper(requestDeviceGroup configmodels.DeviceGroups, groupName string, allowDuplicates bool) ([]configmodels.ImsiConflict, int, error) {
 	logger.ConfigLog.Infof("received device group: %s", groupName)
+	conflicts, statusCode, err := validateDeviceGroup(&requestDeviceGroup, groupName, allowDuplicates)
+	if err != nil {
+		return conflicts, statusCode, err
+	}
+
+	prevDevGroup := getDeviceGroupByName(groupName)
+	if prevDevGroup == nil {
+		logger.ConfigLog.Infof("creating new device group %s", groupName)
+		statusCode, err := createDG(&requestDeviceGroup)
+		if err != nil {
+			return nil, statusCode, err
+		}
+	} else {
+		statusCode, err := updateDG(&requestDeviceGroup, prevDevGroup)
+		if err != nil {
+			return nil, statusCode, err
+		}
+	}
+
+	return conflicts, http.StatusOK, nil
+}
+
+// validateDeviceGroup validates requestDeviceGroup and normalizes its IMSIs, MSISDNs and
+// bitrates, as checked by deviceGroupPostHelper
+func validateDeviceGroup(requestDeviceGroup *configmodels.DeviceGroups, groupName string, allowDuplicates bool) ([]configmodels.ImsiConflict, int, error) {
+
+	plmns := getConfiguredPlmns()
+	for i, imsi := range requestDeviceGroup.Imsis {
+		supi, err := identity.ParseImsi(imsi)
+		if err == nil {
+			err = supi.CheckPlmn(plmns)
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		requestDeviceGroup.Imsis[i] = supi.Value
+	}
+	for i := range requestDeviceGroup.ImsiRanges {
+		imsiRange := &requestDeviceGroup.ImsiRanges[i]
+		parsedRange, err := imsiRange.Parse()
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		for _, imsi := range []string{parsedRange.Start(), parsedRange.End()} {
+			if err = (identity.Supi{Type: identity.ImsiType, Value: imsi}).CheckPlmn(plmns); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+		}
+		imsiRange.Start = parsedRange.Start()
+		if imsiRange.End != "" {
+			imsiRange.End = parsedRange.End()
+		}
+	}
 
+	if err := validateIpDomains(requestDeviceGroup); err != nil {
+		if errors.Is(err, errUeIpPoolOverlap) {
+			return nil, http.StatusConflict, err
+		}
+		return nil, http.StatusBadRequest, err
+	}
+	if err := resolveIpDomainTrafficClasses(requestDeviceGroup.IpDomainsExpanded); err != nil {
+		if errors.Is(err, errInvalidTrafficClassReference) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
 	for i := range requestDeviceGroup.IpDomainsExpanded {
 		ipdomain := &requestDeviceGroup.IpDomainsExpanded[i]
 		logger.ConfigLog.Infof("IP Domain details [%d]: %+v", i, ipdomain)
 		logger.ConfigLog.Infof("DNN Name : %v", ipdomain.Dnn)
 		logger.ConfigLog.Infof("UE Pool  : %v", ipdomain.UeIpPool)
+		logger.ConfigLog.Infof("UE IPv6 Pool : %v", ipdomain.UeIpv6Pool)
+		logger.ConfigLog.Infof("PDU Session Types : %v", ipdomain.AllowedPduSessionTypes())
 		logger.ConfigLog.Infof("DNS Primary : %v", ipdomain.DnsPrimary)
 		logger.ConfigLog.Infof("DNS Secondary : %v", ipdomain.DnsSecondary)
 		logger.ConfigLog.Infof("IP MTU : %v", ipdomain.Mtu)
 		if ipdomain.UeDnnQos != nil {
-			ipdomain.UeDnnQos.DnnMbrDownlink = convertToBps(ipdomain.UeDnnQos.DnnMbrDownlink, ipdomain.UeDnnQos.BitrateUnit)
-			if ipdomain.UeDnnQos.DnnMbrDownlink < 0 {
-				ipdomain.UeDnnQos.DnnMbrDownlink = math.MaxInt64
-			}
+			convertUeDnnQosToBps(ipdomain.UeDnnQos)
 			logger.ConfigLog.Infof("MBR DownLink : %v", ipdomain.UeDnnQos.DnnMbrDownlink)
-			ipdomain.UeDnnQos.DnnMbrUplink = convertToBps(ipdomain.UeDnnQos.DnnMbrUplink, ipdomain.UeDnnQos.BitrateUnit)
-			if ipdomain.UeDnnQos.DnnMbrUplink < 0 {
-				ipdomain.UeDnnQos.DnnMbrUplink = math.MaxInt64
-			}
 			logger.ConfigLog.Infof("MBR UpLink : %v", ipdomain.UeDnnQos.DnnMbrUplink)
 		}
 	}
 
-	prevDevGroup := getDeviceGroupByName(groupName)
 	requestDeviceGroup.DeviceGroupName = groupName
-	if prevDevGroup == nil {
-		logger.ConfigLog.Infof("creating new device group %s", groupName)
-		statusCode, err := createDG(&requestDeviceGroup)
-		if err != nil {
-			return statusCode, err
-		}
-	} else {
-		statusCode, err := updateDG(&requestDeviceGroup, prevDevGroup)
-		if err != nil {
-			return statusCode, err
+	if err := normalizeDeviceGroupMsisdns(requestDeviceGroup); err != nil {
+		if errors.Is(err, errMsisdnConflict) {
+			return nil, http.StatusConflict, err
 		}
+		return nil, http.StatusBadRequest, err
 	}
-
-	return http.StatusOK, nil
+	otherGroups, err := getDeviceGroups(groupName)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err = checkMsisdnConflicts(requestDeviceGroup, otherGroups); err != nil {
+		return nil, http.StatusConflict, err
+	}
+	err = checkUeIpPoolOverlaps([]string{groupName}, getSlices(), slices.Concat(otherGroups, []configmodels.DeviceGroups{*requestDeviceGroup}))
+	if err != nil {
+		return nil, http.StatusConflict, err
+	}
+	warnUeIpPoolCapacity(requestDeviceGroup)
+	conflicts, err := checkImsiConflicts(requestDeviceGroup, otherGroups, allowDuplicates)
+	if errors.Is(err, errImsiConflict) {
+		return conflicts, http.StatusConflict, err
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return conflicts, http.StatusOK, nil
 }
 
 func createDG(devGroup *configmodels.DeviceGroups) (int, error) {
@@ -131,6 +206,19 @@ func updateDG(devGroup *configmodels.DeviceGroups, prevDevGroup *configmodels.De
 	return http.StatusOK, nil
 }
 
+// convertUeDnnQosToBps converts the maximum bitrates of qos from its bitrate unit to bps,
+// capping them to math.MaxInt64
+func convertUeDnnQosToBps(qos *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) {
+	qos.DnnMbrDownlink = convertToBps(qos.DnnMbrDownlink, qos.BitrateUnit)
+	if qos.DnnMbrDownlink < 0 {
+		qos.DnnMbrDownlink = math.MaxInt64
+	}
+	qos.DnnMbrUplink = convertToBps(qos.DnnMbrUplink, qos.BitrateUnit)
+	if qos.DnnMbrUplink < 0 {
+		qos.DnnMbrUplink = math.MaxInt64
+	}
+}
+
 func convertToBps(val int64, unit string) int64 {
 	switch strings.ToLower(unit) {
 	case "bps":
@@ -170,78 +258,272 @@ func handleDeviceGroupPost(devGroup *configmodels.DeviceGroups, prevDevGroup *co
 func syncDeviceGroupSubscriber(devGroup *configmodels.DeviceGroups, prevDevGroup *configmodels.DeviceGroups) (int, error) {
 	rwLock.Lock()
 	defer rwLock.Unlock()
-	slice := findSliceByDeviceGroup(devGroup.DeviceGroupName)
-	if slice == nil {
+	networkSlices := findSlicesByDeviceGroup(devGroup.DeviceGroupName)
+	if len(networkSlices) == 0 {
 		logger.WebUILog.Infof("Device group %s not associated with any slice — skipping sync", devGroup.DeviceGroupName)
 		return http.StatusOK, nil
 	}
-	logger.WebUILog.Infof("Device group %s is part of slice %s", devGroup.DeviceGroupName, slice.SliceName)
-	if slice.SliceId.Sst == "" {
-		err := fmt.Errorf("missing SST in slice %s", slice.SliceName)
+	subscriberData, err := newDeviceGroupSubscriberData(devGroup, networkSlices)
+	if err != nil {
 		logger.DbLog.Errorln(err)
 		return http.StatusBadRequest, err
 	}
+	var errorOccured bool
+	for _, imsi := range devGroup.Imsis {
+		/* update all current IMSIs */
+		if subscriberAuthenticationDataGet("imsi-"+imsi) != nil {
+			if err = subscriberData.update(imsi, devGroup.ImsiMsisdns[imsi]); err != nil {
+				logger.DbLog.Errorf("updatePolicyAndProvisionedData failed for IMSI %s: %+v", imsi, err)
+				errorOccured = true
+			}
+		}
+	}
+	rangeImsis, err := provisionedRangeImsis(devGroup)
+	if err != nil {
+		logger.DbLog.Errorf("failed to fetch the subscribers of the IMSI ranges of %s: %+v", devGroup.DeviceGroupName, err)
+		return http.StatusInternalServerError, err
+	}
+	for _, imsi := range rangeImsis {
+		if err = subscriberData.update(imsi, devGroup.ImsiMsisdns[imsi]); err != nil {
+			logger.DbLog.Errorf("updatePolicyAndProvisionedData failed for IMSI %s: %+v", imsi, err)
+			errorOccured = true
+		}
+	}
+	// delete IMSI's that are removed
+	dimsis := getDeletedImsisList(devGroup, prevDevGroup)
+	if prevDevGroup != nil {
+		prevRangeImsis, err := provisionedRangeImsis(prevDevGroup)
+		if err != nil {
+			logger.DbLog.Errorf("failed to fetch the subscribers of the IMSI ranges of %s: %+v", prevDevGroup.DeviceGroupName, err)
+			return http.StatusInternalServerError, err
+		}
+		for _, imsi := range prevRangeImsis {
+			if !devGroup.ContainsImsi(imsi) {
+				dimsis = append(dimsis, imsi)
+			}
+		}
+	}
+	for _, imsi := range dimsis {
+		if err = subscriberData.remove(imsi); err != nil {
+			logger.ConfigLog.Errorln(err)
+			errorOccured = true
+		}
+	}
+
+	if errorOccured {
+		return http.StatusInternalServerError, fmt.Errorf("syncDeviceGroupSubscriber failed, please check logs")
+	} else {
+		return http.StatusOK, nil
+	}
+}
+
+// subscriberSlice is a network slice, given by its S-NSSAI and PLMN, the subscribers of a
+// device group are provisioned for, with its default QoS if any
+type subscriberSlice struct {
+	snssai *models.Snssai
+	mcc    string
+	mnc    string
+	qos    *configmodels.SliceQos
+}
+
+// newSubscriberSlice returns the S-NSSAI of slice in each of the PLMNs it is served in
+func newSubscriberSlice(slice *configmodels.Slice) ([]subscriberSlice, error) {
+	if slice.SliceId.Sst == "" {
+		return nil, fmt.Errorf("missing SST in slice %s", slice.SliceName)
+	}
 	sVal, err := strconv.ParseUint(slice.SliceId.Sst, 10, 32)
 	if err != nil {
-		logger.DbLog.Errorf("could not parse SST %s", slice.SliceId.Sst)
-		return http.StatusBadRequest, err
+		return nil, fmt.Errorf("could not parse SST %s: %w", slice.SliceId.Sst, err)
 	}
-	snssai := &models.Snssai{
-		Sd:  openapi.PtrString(slice.SliceId.Sd),
-		Sst: int32(sVal),
+	plmns := slice.SiteInfo.PlmnList()
+	subscriberSlices := make([]subscriberSlice, 0, len(plmns))
+	for _, plmn := range plmns {
+		subscriberSlices = append(subscriberSlices, subscriberSlice{
+			snssai: &models.Snssai{
+				Sd:  openapi.PtrString(slice.SliceId.Sd),
+				Sst: int32(sVal),
+			},
+			mcc: plmn.Mcc,
+			mnc: plmn.Mnc,
+			qos: slice.Qos,
+		})
 	}
-	var errorOccured bool
-	dnnMap := make(map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos)
-	for _, ipDomain := range devGroup.IpDomainsExpanded {
-		if ipDomain.UeDnnQos != nil {
-			dnnMap[ipDomain.Dnn] = append(dnnMap[ipDomain.Dnn], *ipDomain.UeDnnQos)
+	return subscriberSlices, nil
+}
+
+// newSubscriberSlices returns the distinct S-NSSAI and PLMN pairs of networkSlices
+func newSubscriberSlices(networkSlices []*configmodels.Slice) ([]subscriberSlice, error) {
+	subscriberSlices := make([]subscriberSlice, 0, len(networkSlices))
+	for _, slice := range networkSlices {
+		sliceSubscriberSlices, err := newSubscriberSlice(slice)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range sliceSubscriberSlices {
+			if !slices.ContainsFunc(subscriberSlices, s.equal) {
+				subscriberSlices = append(subscriberSlices, s)
+			}
 		}
 	}
+	return subscriberSlices, nil
+}
+
+func (s subscriberSlice) equal(other subscriberSlice) bool {
+	return s.mcc == other.mcc && s.mnc == other.mnc && s.snssai.Sst == other.snssai.Sst && s.snssai.GetSd() == other.snssai.GetSd()
+}
 
+func (s subscriberSlice) samePlmn(other subscriberSlice) bool {
+	return s.mcc == other.mcc && s.mnc == other.mnc
+}
+
+// deviceGroupSubscriberData is the policy and provisioned data written for every
+// subscriber of a device group belonging to network slices
+type deviceGroupSubscriberData struct {
+	slices        []subscriberSlice
+	dnnMap        map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos
+	sessionTypes  map[string][]models.PduSessionType
+	aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos
+}
+
+func newDeviceGroupSubscriberData(devGroup *configmodels.DeviceGroups, networkSlices []*configmodels.Slice) (*deviceGroupSubscriberData, error) {
+	subscriberSlices, err := newSubscriberSlices(networkSlices)
+	if err != nil {
+		return nil, err
+	}
+	dnnMap := deviceGroupDnnQos(devGroup, subscriberSlices)
 	// Calculate the aggregatedQoS
 	var allQosProfiles []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos
 	for _, qosList := range dnnMap {
 		allQosProfiles = append(allQosProfiles, qosList...)
 	}
+	return &deviceGroupSubscriberData{
+		slices:        subscriberSlices,
+		dnnMap:        dnnMap,
+		sessionTypes:  dnnPduSessionTypes(devGroup),
+		aggregatedQoS: aggregateQoS(allQosProfiles),
+	}, nil
+}
 
-	aggregatedQoS := aggregateQoS(allQosProfiles)
-	for i, imsi := range devGroup.Imsis {
-		/* update all current IMSIs */
-		if subscriberAuthenticationDataGet("imsi-"+imsi) != nil {
-			var gpsi string
-			if devGroup.Msisdns != nil && i < len(devGroup.Msisdns) {
-				gpsi = devGroup.Msisdns[i]
+// deviceGroupDnnQos returns the QoS profiles of every DNN of devGroup. A DNN without QoS is
+// only included when one of subscriberSlices has a default QoS to give it.
+func deviceGroupDnnQos(devGroup *configmodels.DeviceGroups, subscriberSlices []subscriberSlice) map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
+	hasDefaultQos := slices.ContainsFunc(subscriberSlices, func(s subscriberSlice) bool {
+		return s.qos != nil && s.qos.TrafficClass != nil
+	})
+	dnnMap := make(map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos)
+	for _, ipDomain := range devGroup.IpDomainsExpanded {
+		if ipDomain.UeDnnQos != nil {
+			dnnMap[ipDomain.Dnn] = append(dnnMap[ipDomain.Dnn], *ipDomain.UeDnnQos)
+		} else if _, exists := dnnMap[ipDomain.Dnn]; !exists && hasDefaultQos {
+			dnnMap[ipDomain.Dnn] = nil
+		}
+	}
+	return dnnMap
+}
+
+func (d *deviceGroupSubscriberData) update(imsi string, gpsi string) error {
+	return updatePolicyAndProvisionedData(imsi, gpsi, d.slices, d.dnnMap, d.sessionTypes, d.aggregatedQoS)
+}
+
+// remove deletes the policy and provisioned data of imsi for every PLMN of the network slices
+func (d *deviceGroupSubscriberData) remove(imsi string) error {
+	var removed []subscriberSlice
+	for _, s := range d.slices {
+		if slices.ContainsFunc(removed, s.samePlmn) {
+			continue
+		}
+		if err := removeSubscriberEntriesRelatedToDeviceGroups(s.mcc, s.mnc, imsi); err != nil {
+			return err
+		}
+		removed = append(removed, s)
+	}
+	return nil
+}
+
+// removeSlice deletes the provisioned data of imsi specific to removedSlice, a network slice
+// its device group no longer belongs to, leaving the data of the other network slices
+func (d *deviceGroupSubscriberData) removeSlice(imsi string, removedSlice subscriberSlice) error {
+	if slices.ContainsFunc(d.slices, removedSlice.equal) {
+		return nil
+	}
+	if len(d.slices) == 0 {
+		return removeSubscriberEntriesRelatedToDeviceGroups(removedSlice.mcc, removedSlice.mnc, imsi)
+	}
+	if !slices.ContainsFunc(d.slices, removedSlice.samePlmn) {
+		return removeSubscriberPlmnEntries(removedSlice.mcc, removedSlice.mnc, imsi)
+	}
+	return removeSubscriberSliceEntries(removedSlice.snssai, removedSlice.mcc, removedSlice.mnc, imsi)
+}
+
+// dnnPduSessionTypes returns the PDU session types allowed on each DNN of devGroup
+func dnnPduSessionTypes(devGroup *configmodels.DeviceGroups) map[string][]models.PduSessionType {
+	sessionTypes := make(map[string][]models.PduSessionType)
+	for i := range devGroup.IpDomainsExpanded {
+		ipDomain := &devGroup.IpDomainsExpanded[i]
+		for _, sessionType := range ipDomain.AllowedPduSessionTypes() {
+			if !slices.Contains(sessionTypes[ipDomain.Dnn], sessionType) {
+				sessionTypes[ipDomain.Dnn] = append(sessionTypes[ipDomain.Dnn], sessionType)
 			}
-			err = updatePolicyAndProvisionedData(
-				imsi,
-				gpsi,
-				snssai,
-				dnnMap,
-				slice.SiteInfo.Plmn.Mcc,
-				slice.SiteInfo.Plmn.Mnc,
-				aggregatedQoS,
-			)
-			if err != nil {
-				logger.DbLog.Errorf("updatePolicyAndProvisionedData failed for IMSI %s: %+v", imsi, err)
-				errorOccured = true
+		}
+	}
+	return sessionTypes
+}
+
+// provisionedRangeImsis returns the IMSIs of the IMSI ranges of devGroup that have an
+// authentication subscription. Each range is resolved with a single query rather than
+// expanded, as most IMSIs of a range are usually not provisioned.
+func provisionedRangeImsis(devGroup *configmodels.DeviceGroups) ([]string, error) {
+	imsis := []string{}
+	for _, imsiRange := range devGroup.ImsiRanges {
+		parsedRange, err := imsiRange.Parse()
+		if err != nil {
+			logger.ConfigLog.Warnf("skipping invalid IMSI range of device group %s: %+v", devGroup.DeviceGroupName, err)
+			continue
+		}
+		filter := bson.M{"ueId": bson.M{"$gte": "imsi-" + parsedRange.Start(), "$lte": "imsi-" + parsedRange.End()}}
+		ueIds, err := dbadapter.AuthDBClient.RestfulAPIDistinct(authSubsDataColl, "ueId", filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, ueId := range ueIds {
+			ueIdStr, _ := ueId.(string)
+			// the string comparison also matches shorter IMSIs sharing a prefix with the bounds
+			if imsi, ok := strings.CutPrefix(ueIdStr, "imsi-"); ok && parsedRange.Contains(imsi) {
+				imsis = append(imsis, imsi)
 			}
 		}
 	}
-	// delete IMSI's that are removed
-	dimsis := getDeletedImsisList(devGroup, prevDevGroup)
-	for _, imsi := range dimsis {
-		err = removeSubscriberEntriesRelatedToDeviceGroups(slice.SiteInfo.Plmn.Mcc, slice.SiteInfo.Plmn.Mnc, imsi)
+	return imsis, nil
+}
+
+// deviceGroupImsiRange associates a valid IMSI range with the device group it belongs to
+type deviceGroupImsiRange struct {
+	imsiRange   identity.ImsiRange
+	deviceGroup string
+}
+
+// appendDeviceGroupImsiRanges appends the valid IMSI ranges of devGroup to ranges
+func appendDeviceGroupImsiRanges(ranges []deviceGroupImsiRange, devGroup *configmodels.DeviceGroups) []deviceGroupImsiRange {
+	for _, imsiRange := range devGroup.ImsiRanges {
+		parsedRange, err := imsiRange.Parse()
 		if err != nil {
-			logger.ConfigLog.Errorln(err)
-			errorOccured = true
+			logger.ConfigLog.Warnf("skipping invalid IMSI range of device group %s: %+v", devGroup.DeviceGroupName, err)
+			continue
 		}
+		ranges = append(ranges, deviceGroupImsiRange{imsiRange: parsedRange, deviceGroup: devGroup.DeviceGroupName})
 	}
+	return ranges
+}
 
-	if errorOccured {
-		return http.StatusInternalServerError, fmt.Errorf("syncDeviceGroupSubscriber failed, please check logs")
-	} else {
-		return http.StatusOK, nil
+// deviceGroupOfImsiRanges returns the device group of the first range containing imsi, or
+// an empty string if no range contains it
+func deviceGroupOfImsiRanges(ranges []deviceGroupImsiRange, imsi string) string {
+	for _, r := range ranges {
+		if r.imsiRange.Contains(imsi) {
+			return r.deviceGroup
+		}
 	}
+	return ""
 }
 
 func handleDeviceGroupDelete(groupName string) error {
@@ -270,17 +552,21 @@ func getDeviceGroupByName(name string) *configmodels.DeviceGroups {
 		logger.DbLog.Errorf("could not unmarshall device group %s", devGroupDataInterface)
 		return nil
 	}
+	devGroupData.ConvertLegacyMsisdns()
 	return &devGroupData
 }
 
-func findSliceByDeviceGroup(DevGroupName string) *configmodels.Slice {
+// findSlicesByDeviceGroup returns the network slices the device group belongs to, sorted by name
+func findSlicesByDeviceGroup(devGroupName string) []*configmodels.Slice {
+	var networkSlices []*configmodels.Slice
 	for _, slice := range getSlices() {
-		for _, dgName := range slice.SiteDeviceGroup {
-			if dgName == DevGroupName {
-				logger.WebUILog.Infof("device Group [%s] is part of slice: %s", dgName, slice.SliceName)
-				return slice
-			}
+		if slices.Contains(slice.SiteDeviceGroup, devGroupName) {
+			logger.WebUILog.Infof("device Group [%s] is part of slice: %s", devGroupName, slice.SliceName)
+			networkSlices = append(networkSlices, slice)
 		}
 	}
-	return nil
+	slices.SortFunc(networkSlices, func(a, b *configmodels.Slice) int {
+		return strings.Compare(a.SliceName, b.SliceName)
+	})
+	return networkSlices
 }
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+)
+
+// ueIpv6PrefixLength is the length of the IPv6 prefix delegated to each UE
+const ueIpv6PrefixLength = 64
+
+var errUeIpPoolOverlap = errors.New("UE IP pools overlap")
+
+// deviceGroupUeIpPool associates a valid UE IP pool with the device group and DNN it belongs to
+type deviceGroupUeIpPool struct {
+	pool        netip.Prefix
+	dnn         string
+	deviceGroup string
+}
+
+// parseUeIpPool parses an IPv4 UE IP pool in CIDR notation. The address bits beyond the
+// prefix length are ignored.
+func parseUeIpPool(value string) (netip.Prefix, error) {
+	pool, err := netip.ParsePrefix(value)
+	if err != nil || !pool.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid UE IP pool %q: expected an IPv4 CIDR", value)
+	}
+	return pool.Masked(), nil
+}
+
+// parseUeIpv6Pool parses an IPv6 UE prefix pool in CIDR notation. The prefix length must
+// leave room for a /64 prefix per UE.
+func parseUeIpv6Pool(value string) (netip.Prefix, error) {
+	pool, err := netip.ParsePrefix(value)
+	if err != nil || !pool.Addr().Is6() || pool.Addr().Is4In6() {
+		return netip.Prefix{}, fmt.Errorf("invalid UE IPv6 pool %q: expected an IPv6 CIDR", value)
+	}
+	if pool.Bits() > ueIpv6PrefixLength {
+		return netip.Prefix{}, fmt.Errorf("invalid UE IPv6 pool %q: the prefix length must not exceed %d", value, ueIpv6PrefixLength)
+	}
+	return pool.Masked(), nil
+}
+
+// ueIpPoolSize returns the number of UEs pool can serve: its IPv4 addresses, without the
+// network and broadcast addresses, or its IPv6 /64 prefixes
+func ueIpPoolSize(pool netip.Prefix) uint64 {
+	if pool.Addr().Is6() {
+		if ueIpv6PrefixLength-pool.Bits() >= 64 {
+			return math.MaxUint64
+		}
+		return uint64(1) << (ueIpv6PrefixLength - pool.Bits())
+	}
+	size := uint64(1) << (pool.Addr().BitLen() - pool.Bits())
+	if size > 2 {
+		size -= 2
+	}
+	return size
+}
+
+// validatePduSessionTypes checks the PDU session types allowed on the DNN of ipDomain and
+// returns whether IPv4 and IPv6 sessions are allowed
+func validatePduSessionTypes(ipDomain *configmodels.DeviceGroupsIpDomainExpanded) (ipv4 bool, ipv6 bool, err error) {
+	for i, sessionType := range ipDomain.PduSessionTypes {
+		if slices.Contains(ipDomain.PduSessionTypes[:i], sessionType) {
+			return false, false, fmt.Errorf("duplicate PDU session type %s", sessionType)
+		}
+	}
+	for _, sessionType := range ipDomain.AllowedPduSessionTypes() {
+		switch sessionType {
+		case models.PDUSESSIONTYPE_IPV4:
+			ipv4 = true
+		case models.PDUSESSIONTYPE_IPV6:
+			ipv6 = true
+		case models.PDUSESSIONTYPE_IPV4_V6:
+			ipv4, ipv6 = true, true
+		default:
+			return false, false, fmt.Errorf("unsupported PDU session type %q: expected %s, %s or %s", sessionType,
+				models.PDUSESSIONTYPE_IPV4, models.PDUSESSIONTYPE_IPV6, models.PDUSESSIONTYPE_IPV4_V6)
+		}
+	}
+	return ipv4, ipv6, nil
+}
+
+// validateIpDomainAddresses checks the IPv4 and IPv6 DNS and P-CSCF addresses of ipDomain
+func validateIpDomainAddresses(ipDomain *configmodels.DeviceGroupsIpDomainExpanded) error {
+	addresses := []struct {
+		name  string
+		value string
+		ipv6  bool
+	}{
+		{"dns-primary", ipDomain.DnsPrimary, false},
+		{"dns-secondary", ipDomain.DnsSecondary, false},
+		{"pcscf-primary", ipDomain.PcscfPrimary, false},
+		{"dns-primary-ipv6", ipDomain.DnsPrimaryIpv6, true},
+		{"dns-secondary-ipv6", ipDomain.DnsSecondaryIpv6, true},
+		{"pcscf-primary-ipv6", ipDomain.PcscfPrimaryIpv6, true},
+	}
+	for _, address := range addresses {
+		if address.value == "" {
+			continue
+		}
+		addr, err := netip.ParseAddr(address.value)
+		if address.ipv6 && (err != nil || !addr.Is6() || addr.Is4In6()) {
+			return fmt.Errorf("invalid %s %q: expected an IPv6 address", address.name, address.value)
+		}
+		if !address.ipv6 && (err != nil || !addr.Is4()) {
+			return fmt.Errorf("invalid %s %q: expected an IPv4 address", address.name, address.value)
+		}
+	}
+	return nil
+}
+
+// validateIpDomains checks the PDU session types, UE IP pools, DNS and P-CSCF addresses of
+// every IP domain of devGroup, and that the UE IP pools of the device group do not overlap.
+// A UE IP pool is required for each IP version allowed on the DNN.
+func validateIpDomains(devGroup *configmodels.DeviceGroups) error {
+	var pools []deviceGroupUeIpPool
+	for i := range devGroup.IpDomainsExpanded {
+		ipDomain := &devGroup.IpDomainsExpanded[i]
+		ipv4, ipv6, err := validatePduSessionTypes(ipDomain)
+		if err != nil {
+			return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+		}
+		var domainPools []netip.Prefix
+		if ipv4 || ipDomain.UeIpPool != "" {
+			pool, err := parseUeIpPool(ipDomain.UeIpPool)
+			if err != nil {
+				return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+			}
+			domainPools = append(domainPools, pool)
+		}
+		if ipv6 || ipDomain.UeIpv6Pool != "" {
+			pool, err := parseUeIpv6Pool(ipDomain.UeIpv6Pool)
+			if err != nil {
+				return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+			}
+			domainPools = append(domainPools, pool)
+		}
+		if err = validateIpDomainAddresses(ipDomain); err != nil {
+			return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+		}
+		for _, pool := range domainPools {
+			for _, other := range pools {
+				if other.pool.Overlaps(pool) {
+					return fmt.Errorf("%w: %s of DNN %s and %s of DNN %s", errUeIpPoolOverlap, other.pool, other.dnn, pool, ipDomain.Dnn)
+				}
+			}
+			pools = append(pools, deviceGroupUeIpPool{pool: pool, dnn: ipDomain.Dnn, deviceGroup: devGroup.DeviceGroupName})
+		}
+	}
+	return nil
+}
+
+// warnUeIpPoolCapacity logs a warning for every UE IP pool of devGroup too small to
+// allocate an address, or an IPv6 prefix, to each of its IMSIs
+func warnUeIpPoolCapacity(devGroup *configmodels.DeviceGroups) {
+	imsiCount := uint64(len(devGroup.Imsis))
+	for _, imsiRange := range devGroup.ImsiRanges {
+		if parsedRange, err := imsiRange.Parse(); err == nil {
+			imsiCount += parsedRange.Len()
+		}
+	}
+	for _, pool := range appendUeIpPools(nil, devGroup) {
+		if size := ueIpPoolSize(pool.pool); size < imsiCount {
+			logger.ConfigLog.Warnf("UE IP pool %s of DNN %s can serve %d UEs for the %d IMSIs of device group %s",
+				pool.pool, pool.dnn, size, imsiCount, devGroup.DeviceGroupName)
+		}
+	}
+}
+
+// appendUeIpPools appends the valid IPv4 and IPv6 UE IP pools of devGroup to pools
+func appendUeIpPools(pools []deviceGroupUeIpPool, devGroup *configmodels.DeviceGroups) []deviceGroupUeIpPool {
+	for _, ipDomain := range devGroup.IpDomainsExpanded {
+		parsers := []struct {
+			value string
+			parse func(string) (netip.Prefix, error)
+		}{
+			{ipDomain.UeIpPool, parseUeIpPool},
+			{ipDomain.UeIpv6Pool, parseUeIpv6Pool},
+		}
+		for _, parser := range parsers {
+			if parser.value == "" {
+				continue
+			}
+			pool, err := parser.parse(parser.value)
+			if err != nil {
+				logger.ConfigLog.Warnf("skipping invalid UE IP pool of device group %s: %+v", devGroup.DeviceGroupName, err)
+				continue
+			}
+			pools = append(pools, deviceGroupUeIpPool{pool: pool, dnn: ipDomain.Dnn, deviceGroup: devGroup.DeviceGroupName})
+		}
+	}
+	return pools
+}
+
+// sliceUpfNames returns the names of the UPFs serving slice
+func sliceUpfNames(slice *configmodels.Slice) []string {
+	var upfNames []string
+	for _, upf := range slice.SiteInfo.UpfList() {
+		upfNames = append(upfNames, upf.UpfName)
+	}
+	return upfNames
+}
+
+// checkUeIpPoolOverlaps rejects the UE IP pools of changedGroups overlapping the pool of
+// another device group served by the same UPF. networkSlices and devGroups are the network
+// slices and device groups as they are to be stored.
+func checkUeIpPoolOverlaps(changedGroups []string, networkSlices []*configmodels.Slice, devGroups []configmodels.DeviceGroups) error {
+	groupsByUpf := make(map[string][]string)
+	for _, slice := range networkSlices {
+		for _, upfName := range sliceUpfNames(slice) {
+			groupsByUpf[upfName] = append(groupsByUpf[upfName], slice.SiteDeviceGroup...)
+		}
+	}
+	upfNames := make([]string, 0, len(groupsByUpf))
+	for upfName := range groupsByUpf {
+		upfNames = append(upfNames, upfName)
+	}
+	slices.Sort(upfNames)
+	for _, upfName := range upfNames {
+		groupNames := groupsByUpf[upfName]
+		var pools []deviceGroupUeIpPool
+		for i := range devGroups {
+			if slices.Contains(groupNames, devGroups[i].DeviceGroupName) {
+				pools = appendUeIpPools(pools, &devGroups[i])
+			}
+		}
+		for i, a := range pools {
+			for _, b := range pools[i+1:] {
+				if a.deviceGroup == b.deviceGroup || !a.pool.Overlaps(b.pool) {
+					continue
+				}
+				if !slices.Contains(changedGroups, a.deviceGroup) && !slices.Contains(changedGroups, b.deviceGroup) {
+					continue
+				}
+				return fmt.Errorf("%w: %s of device group %s and %s of device group %s are both served by UPF %s",
+					errUeIpPoolOverlap, a.pool, a.deviceGroup, b.pool, b.deviceGroup, upfName)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSliceUeIpPoolOverlaps rejects the device groups of slice whose UE IP pools overlap
+// the pool of another device group served by the same UPF
+func checkSliceUeIpPoolOverlaps(slice configmodels.Slice) error {
+	networkSlices := slices.DeleteFunc(getSlices(), func(s *configmodels.Slice) bool {
+		return s.SliceName == slice.SliceName
+	})
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		return err
+	}
+	return checkUeIpPoolOverlaps(slice.SiteDeviceGroup, append(networkSlices, &slice), devGroups)
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/configmodels"
+)
+
+func deviceGroupWithUeIpPool(name string, ueIpPool string) configmodels.DeviceGroups {
+	devGroup := deviceGroupWithImsis(name, []string{"208930100007487"})
+	devGroup.IpDomainsExpanded[0].UeIpPool = ueIpPool
+	return devGroup
+}
+
+func TestValidateIpDomains(t *testing.T) {
+	tests := []struct {
+		name          string
+		ipDomain      configmodels.DeviceGroupsIpDomainExpanded
+		extraPool     string
+		expectedError string
+	}{
+		{name: "Valid IP domain", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", DnsPrimary: "1.1.1.1", PcscfPrimary: "10.0.0.1"}},
+		{name: "Second DNN", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.0.0/24"}, extraPool: "172.250.1.0/24"},
+		{name: "Missing UE IP pool", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet"}, expectedError: "invalid UE IP pool"},
+		{name: "UE IP pool without prefix length", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0"}, expectedError: "invalid UE IP pool"},
+		{name: "IPv6 UE IP pool", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "2001:db8::/64"}, expectedError: "invalid UE IP pool"},
+		{name: "Invalid DNS", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", DnsSecondary: "dns.example.com"}, expectedError: "invalid dns-secondary"},
+		{name: "Invalid P-CSCF", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", PcscfPrimary: "10.0.0.256"}, expectedError: "invalid pcscf-primary"},
+		{name: "Dual-stack IP domain", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", UeIpv6Pool: "2001:db8:1::/48", DnsPrimaryIpv6: "2001:4860:4860::8888", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_IPV4_V6}}},
+		{name: "IPv6 only IP domain", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpv6Pool: "2001:db8:1::/48", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_IPV6}}},
+		{name: "Missing UE IPv6 pool", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_IPV4_V6}}, expectedError: "invalid UE IPv6 pool"},
+		{name: "UE IPv6 pool longer than /64", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpv6Pool: "2001:db8:1::/96", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_IPV6}}, expectedError: "must not exceed 64"},
+		{name: "IPv4 DNS as IPv6 DNS", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", DnsPrimaryIpv6: "1.1.1.1"}, expectedError: "invalid dns-primary-ipv6"},
+		{name: "Unsupported PDU session type", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_ETHERNET}}, expectedError: "unsupported PDU session type"},
+		{name: "Duplicate PDU session type", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.1.0/16", PduSessionTypes: []models.PduSessionType{models.PDUSESSIONTYPE_IPV4, models.PDUSESSIONTYPE_IPV4}}, expectedError: "duplicate PDU session type"},
+		{name: "Overlapping DNNs", ipDomain: configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", UeIpPool: "172.250.0.0/16"}, extraPool: "172.250.1.0/24", expectedError: errUeIpPoolOverlap.Error()},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			devGroup := configmodels.DeviceGroups{DeviceGroupName: "group1", IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{tc.ipDomain}}
+			if tc.extraPool != "" {
+				devGroup.IpDomainsExpanded = append(devGroup.IpDomainsExpanded, configmodels.DeviceGroupsIpDomainExpanded{Dnn: "ims", UeIpPool: tc.extraPool})
+			}
+			err := validateIpDomains(&devGroup)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestUeIpPoolSize(t *testing.T) {
+	tests := map[string]uint64{"10.0.0.0/16": 65534, "10.0.0.0/30": 2, "10.0.0.0/31": 2, "10.0.0.1/32": 1, "2001:db8::/48": 65536, "2001:db8::/64": 1}
+	for value, expected := range tests {
+		parse := parseUeIpPool
+		if strings.Contains(value, ":") {
+			parse = parseUeIpv6Pool
+		}
+		pool, err := parse(value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if size := ueIpPoolSize(pool); size != expected {
+			t.Errorf("expected %d addresses in %s, got %d", expected, value, size)
+		}
+	}
+	if pool, _ := parseUeIpPool("172.250.1.0/16"); pool != netip.MustParsePrefix("172.250.0.0/16") {
+		t.Errorf("expected the pool to be masked, got %s", pool)
+	}
+}
+
+func TestCheckUeIpPoolOverlaps(t *testing.T) {
+	devGroups := []configmodels.DeviceGroups{
+		deviceGroupWithUeIpPool("group1", "172.250.0.0/16"),
+		deviceGroupWithUeIpPool("group2", "172.250.1.0/24"),
+		deviceGroupWithUeIpPool("group3", "172.251.0.0/16"),
+	}
+	upfSlice := func(name string, upfName string, groups ...string) *configmodels.Slice {
+		return &configmodels.Slice{
+			SliceName:       name,
+			SiteDeviceGroup: groups,
+			SiteInfo:        configmodels.SliceSiteInfo{Upf: &configmodels.SliceSiteInfoUpfReference{UpfName: upfName}},
+		}
+	}
+	tests := []struct {
+		name          string
+		changedGroups []string
+		networkSlices []*configmodels.Slice
+		expectOverlap bool
+	}{
+		{name: "Same UPF", changedGroups: []string{"group2"}, networkSlices: []*configmodels.Slice{upfSlice("slice1", "upf1", "group1"), upfSlice("slice2", "upf1", "group2")}, expectOverlap: true},
+		{name: "Different UPFs", changedGroups: []string{"group2"}, networkSlices: []*configmodels.Slice{upfSlice("slice1", "upf1", "group1"), upfSlice("slice2", "upf2", "group2")}},
+		{name: "Disjoint pools", changedGroups: []string{"group3"}, networkSlices: []*configmodels.Slice{upfSlice("slice1", "upf1", "group1", "group3")}},
+		{name: "Overlap of unchanged groups", changedGroups: []string{"group3"}, networkSlices: []*configmodels.Slice{upfSlice("slice1", "upf1", "group1", "group2", "group3")}},
+		{name: "Group without slice", changedGroups: []string{"group2"}, networkSlices: []*configmodels.Slice{upfSlice("slice1", "upf1", "group1")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkUeIpPoolOverlaps(tc.changedGroups, tc.networkSlices, devGroups)
+			if tc.expectOverlap != errors.Is(err, errUeIpPoolOverlap) {
+				t.Errorf("expected overlap: %t, got %v", tc.expectOverlap, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	errDeviceGroupNotFound       = errors.New("device group not found")
+	errDeviceGroupMemberExists   = errors.New("IMSI already belongs to the device group")
+	errDeviceGroupMemberNotFound = errors.New("IMSI is not a member of the device group")
+	errDeviceGroupMemberInRange  = errors.New("IMSI belongs to an IMSI range of the device group")
+)
+
+// fetchDeviceGroup returns the device group named groupName, or nil if it does not exist
+func fetchDeviceGroup(groupName string) (*configmodels.DeviceGroups, error) {
+	rawDeviceGroup, err := dbadapter.CommonDBClient.RestfulAPIGetOne(devGroupDataColl, bson.M{"group-name": groupName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch device group %s: %w", groupName, err)
+	}
+	if len(rawDeviceGroup) == 0 {
+		return nil, nil
+	}
+	return unmarshalDeviceGroup(rawDeviceGroup)
+}
+
+// unmarshalDeviceGroup decodes a stored device group, converting the MSISDNs it may still
+// match to its IMSIs by position
+func unmarshalDeviceGroup(rawDeviceGroup map[string]interface{}) (*configmodels.DeviceGroups, error) {
+	var devGroup configmodels.DeviceGroups
+	if err := json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &devGroup); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device group: %w", err)
+	}
+	devGroup.ConvertLegacyMsisdns()
+	return &devGroup, nil
+}
+
+func storeDeviceGroup(devGroup *configmodels.DeviceGroups) error {
+	filter := bson.M{"group-name": devGroup.DeviceGroupName}
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPost(devGroupDataColl, filter, configmodels.ToBsonM(devGroup)); err != nil {
+		return fmt.Errorf("failed to store device group %s: %w", devGroup.DeviceGroupName, err)
+	}
+	return nil
+}
+
+// deviceGroupMemberAdd adds member to the device group and only writes the policy and
+// provisioned data of its IMSI, instead of updating every member of the group. An IMSI
+// of another device group is rejected unless allowDuplicates is set, in which case the
+// conflicts are returned.
+func deviceGroupMemberAdd(groupName string, member configmodels.DeviceGroupMember, allowDuplicates bool) ([]configmodels.ImsiConflict, error) {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	devGroup, conflicts, err := deviceGroupWithMember(groupName, member, allowDuplicates)
+	if err != nil {
+		return conflicts, err
+	}
+	if err = storeDeviceGroup(devGroup); err != nil {
+		return nil, err
+	}
+	warnUeIpPoolCapacity(devGroup)
+	logger.ConfigLog.Infof("added IMSI %s to device group %s", member.Imsi, groupName)
+
+	networkSlices := findSlicesByDeviceGroup(groupName)
+	if len(networkSlices) == 0 || subscriberAuthenticationDataGet("imsi-"+member.Imsi) == nil {
+		return conflicts, nil
+	}
+	subscriberData, err := newDeviceGroupSubscriberData(devGroup, networkSlices)
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, subscriberData.update(member.Imsi, member.Msisdn)
+}
+
+// deviceGroupWithMember returns the device group groupName with member added, and the
+// IMSI conflicts allowDuplicates accepts
+func deviceGroupWithMember(groupName string, member configmodels.DeviceGroupMember, allowDuplicates bool) (*configmodels.DeviceGroups, []configmodels.ImsiConflict, error) {
+	devGroup, err := fetchDeviceGroup(groupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if devGroup == nil {
+		return nil, nil, errDeviceGroupNotFound
+	}
+	if devGroup.ContainsImsi(member.Imsi) {
+		return nil, nil, errDeviceGroupMemberExists
+	}
+	devGroup.Imsis = append(devGroup.Imsis, member.Imsi)
+	if member.Msisdn != "" {
+		for imsi, msisdn := range devGroup.ImsiMsisdns {
+			if msisdn == member.Msisdn {
+				return nil, nil, fmt.Errorf("%w: %s is assigned to IMSI %s", errMsisdnConflict, msisdn, imsi)
+			}
+		}
+		if devGroup.ImsiMsisdns == nil {
+			devGroup.ImsiMsisdns = make(map[string]string)
+		}
+		devGroup.ImsiMsisdns[member.Imsi] = member.Msisdn
+	}
+	otherGroups, err := getDeviceGroups(groupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = checkMsisdnConflicts(devGroup, otherGroups); err != nil {
+		return nil, nil, err
+	}
+	conflicts, err := checkImsiConflicts(devGroup, otherGroups, allowDuplicates)
+	if err != nil {
+		return nil, conflicts, err
+	}
+	return devGroup, conflicts, nil
+}
+
+// deviceGroupMemberRemove removes imsi, and its MSISDN, from the device group and only
+// deletes the policy and provisioned data of this IMSI.
+func deviceGroupMemberRemove(groupName string, imsi string) error {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	devGroup, err := deviceGroupWithoutMember(groupName, imsi)
+	if err != nil {
+		return err
+	}
+	if err = storeDeviceGroup(devGroup); err != nil {
+		return err
+	}
+	logger.ConfigLog.Infof("removed IMSI %s from device group %s", imsi, groupName)
+
+	if devGroup.ContainsImsi(imsi) {
+		return nil
+	}
+	subscriberData, err := newDeviceGroupSubscriberData(devGroup, findSlicesByDeviceGroup(groupName))
+	if err != nil {
+		return err
+	}
+	return subscriberData.remove(imsi)
+}
+
+// deviceGroupWithoutMember returns the device group groupName with imsi, and its MSISDN,
+// removed
+func deviceGroupWithoutMember(groupName string, imsi string) (*configmodels.DeviceGroups, error) {
+	devGroup, err := fetchDeviceGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+	if devGroup == nil {
+		return nil, errDeviceGroupNotFound
+	}
+	if !slices.Contains(devGroup.Imsis, imsi) {
+		if devGroup.ContainsImsi(imsi) {
+			return nil, errDeviceGroupMemberInRange
+		}
+		return nil, errDeviceGroupMemberNotFound
+	}
+	removeImsiFromDeviceGroup(devGroup, imsi)
+	return devGroup, nil
+}
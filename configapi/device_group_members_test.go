@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type DeviceGroupMemberMockDBClient struct {
+	dbadapter.DBInterface
+	deviceGroup  *configmodels.DeviceGroups
+	otherGroups  []configmodels.DeviceGroups
+	slices       []configmodels.Slice
+	storedGroups []configmodels.DeviceGroups
+	updatedColls map[string][]any
+	deletedColls map[string][]any
+}
+
+func newDeviceGroupMemberMockDBClient(deviceGroup *configmodels.DeviceGroups, slices ...configmodels.Slice) *DeviceGroupMemberMockDBClient {
+	return &DeviceGroupMemberMockDBClient{
+		deviceGroup:  deviceGroup,
+		slices:       slices,
+		updatedColls: map[string][]any{},
+		deletedColls: map[string][]any{},
+	}
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if coll != devGroupDataColl || db.deviceGroup == nil {
+		return nil, nil
+	}
+	return configmodels.ToBsonM(db.deviceGroup), nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	if coll == devGroupDataColl {
+		for _, deviceGroup := range db.otherGroups {
+			results = append(results, configmodels.ToBsonM(deviceGroup))
+		}
+		return results, nil
+	}
+	for _, slice := range db.slices {
+		results = append(results, configmodels.ToBsonM(slice))
+	}
+	return results, nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIPost(collName string, filter bson.M, postData map[string]any) (bool, error) {
+	if collName == devGroupDataColl {
+		var deviceGroup configmodels.DeviceGroups
+		if err := json.Unmarshal(configmodels.MapToByte(postData), &deviceGroup); err != nil {
+			return false, err
+		}
+		db.storedGroups = append(db.storedGroups, deviceGroup)
+		return true, nil
+	}
+	db.updatedColls[collName] = append(db.updatedColls[collName], filter["ueId"])
+	return true, nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	db.updatedColls[collName] = append(db.updatedColls[collName], filter["ueId"])
+	return true, nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIDeleteOneWithContext(ctx context.Context, collName string, filter bson.M) error {
+	db.deletedColls[collName] = append(db.deletedColls[collName], filter["ueId"])
+	return nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIDeleteManyWithContext(ctx context.Context, collName string, filter bson.M) error {
+	db.deletedColls[collName] = append(db.deletedColls[collName], filter["ueId"])
+	return nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	db.deletedColls[collName] = append(db.deletedColls[collName], filter["ueId"])
+	return nil
+}
+
+func (db *DeviceGroupMemberMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return &MockSession{}, nil
+}
+
+func setupDeviceGroupMemberMocks(t *testing.T, commonDb *DeviceGroupMemberMockDBClient, authSubscribers []string) {
+	t.Helper()
+	origCommonDB := dbadapter.CommonDBClient
+	origAuthDB := dbadapter.AuthDBClient
+	t.Cleanup(func() {
+		dbadapter.CommonDBClient = origCommonDB
+		dbadapter.AuthDBClient = origAuthDB
+	})
+	dbadapter.CommonDBClient = commonDb
+	dbadapter.AuthDBClient = &AuthDBMockDBClient{subscribers: authSubscribers}
+}
+
+func TestDeviceGroupMemberAdd_MapsMsisdnToImsi(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})
+	deviceGroup.Msisdns = []string{"msisdn-0900000001"}
+	commonDb := newDeviceGroupMemberMockDBClient(&deviceGroup)
+	setupDeviceGroupMemberMocks(t, commonDb, nil)
+
+	_, err := deviceGroupMemberAdd("group1", configmodels.DeviceGroupMember{Imsi: "208930100007489", Msisdn: "msisdn-0900000003"}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commonDb.storedGroups) != 1 {
+		t.Fatalf("expected the device group to be stored once, got %d", len(commonDb.storedGroups))
+	}
+	stored := commonDb.storedGroups[0]
+	expectedImsis := []string{"208930100007487", "208930100007488", "208930100007489"}
+	expectedMsisdns := map[string]string{"208930100007487": "msisdn-0900000001", "208930100007489": "msisdn-0900000003"}
+	if !reflect.DeepEqual(stored.Imsis, expectedImsis) || !reflect.DeepEqual(stored.ImsiMsisdns, expectedMsisdns) || stored.Msisdns != nil {
+		t.Errorf("expected IMSIs %v and MSISDNs %v, got %v and %v", expectedImsis, expectedMsisdns, stored.Imsis, stored.ImsiMsisdns)
+	}
+	if len(commonDb.updatedColls) != 0 {
+		t.Errorf("expected no subscriber data to be written outside of a network slice, got %v", commonDb.updatedColls)
+	}
+}
+
+func TestDeviceGroupMemberAdd_OnlyUpdatesAddedImsi(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})
+	commonDb := newDeviceGroupMemberMockDBClient(&deviceGroup, networkSlice("slice1"))
+	setupDeviceGroupMemberMocks(t, commonDb, []string{"imsi-208930100007489"})
+
+	if _, err := deviceGroupMemberAdd("group1", configmodels.DeviceGroupMember{Imsi: "208930100007489"}, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commonDb.storedGroups) != 1 || commonDb.storedGroups[0].ImsiMsisdns != nil {
+		t.Errorf("expected the device group to be stored without MSISDNs, got %+v", commonDb.storedGroups)
+	}
+	for _, collName := range []string{amDataColl, smDataColl, amPolicyDataColl, smPolicyDataColl} {
+		if !reflect.DeepEqual(commonDb.updatedColls[collName], []any{"imsi-208930100007489"}) {
+			t.Errorf("expected only the added IMSI to be written to %s, got %v", collName, commonDb.updatedColls[collName])
+		}
+	}
+}
+
+func TestDeviceGroupMemberRemove_OnlyDeletesRemovedImsi(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})
+	deviceGroup.Msisdns = []string{"msisdn-0900000001", "msisdn-0900000002"}
+	commonDb := newDeviceGroupMemberMockDBClient(&deviceGroup, networkSlice("slice1"))
+	setupDeviceGroupMemberMocks(t, commonDb, nil)
+
+	if err := deviceGroupMemberRemove("group1", "208930100007487"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored := commonDb.storedGroups[0]
+	if !reflect.DeepEqual(stored.Imsis, []string{"208930100007488"}) || !reflect.DeepEqual(stored.ImsiMsisdns, map[string]string{"208930100007488": "msisdn-0900000002"}) {
+		t.Errorf("expected the IMSI and its MSISDN to be removed, got %v and %v", stored.Imsis, stored.ImsiMsisdns)
+	}
+	for _, collName := range []string{amDataColl, smDataColl, amPolicyDataColl, smPolicyDataColl} {
+		if !reflect.DeepEqual(commonDb.deletedColls[collName], []any{"imsi-208930100007487"}) {
+			t.Errorf("expected only the removed IMSI to be deleted from %s, got %v", collName, commonDb.deletedColls[collName])
+		}
+	}
+}
+
+func TestDeviceGroupMemberHandlers(t *testing.T) {
+	rangeGroup := deviceGroupWithImsis("group1", []string{"208930100007487"})
+	rangeGroup.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100000001", Count: 100}}
+
+	tests := []struct {
+		name         string
+		deviceGroup  *configmodels.DeviceGroups
+		method       string
+		url          string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Add IMSI", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "imsi-208930100007488"}`, expectedCode: http.StatusOK},
+		{name: "Add existing IMSI", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "208930100007487"}`, expectedCode: http.StatusConflict},
+		{name: "Add IMSI of a range", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "208930100000050"}`, expectedCode: http.StatusConflict},
+		{name: "Add IMSI of another group", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "208930100007490"}`, expectedCode: http.StatusConflict},
+		{
+			name: "Add IMSI of another group allowing duplicates", deviceGroup: &rangeGroup, method: http.MethodPost,
+			url: "/config/v1/device-group/group1/imsis?allow-duplicate-imsis=true", body: `{"imsi": "208930100007490"}`, expectedCode: http.StatusOK,
+			expectedBody: `{"imsi-conflicts":[{"start":"208930100007490","end":"208930100007490","device-groups":["group1","group2"]}]}`,
+		},
+		{name: "Invalid allow duplicates flag", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis?allow-duplicate-imsis=maybe", body: `{"imsi": "208930100007490"}`, expectedCode: http.StatusBadRequest},
+		{name: "Add invalid IMSI", deviceGroup: &rangeGroup, method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "1234"}`, expectedCode: http.StatusBadRequest},
+		{name: "Add to missing device group", method: http.MethodPost, url: "/config/v1/device-group/group1/imsis", body: `{"imsi": "208930100007488"}`, expectedCode: http.StatusNotFound},
+		{name: "Remove IMSI", deviceGroup: &rangeGroup, method: http.MethodDelete, url: "/config/v1/device-group/group1/imsis/imsi-208930100007487", expectedCode: http.StatusOK},
+		{name: "Remove IMSI not in the group", deviceGroup: &rangeGroup, method: http.MethodDelete, url: "/config/v1/device-group/group1/imsis/208930100007488", expectedCode: http.StatusNotFound},
+		{name: "Remove IMSI of a range", deviceGroup: &rangeGroup, method: http.MethodDelete, url: "/config/v1/device-group/group1/imsis/208930100000050", expectedCode: http.StatusConflict},
+		{name: "Remove invalid IMSI", deviceGroup: &rangeGroup, method: http.MethodDelete, url: "/config/v1/device-group/group1/imsis/nai-user@example.com", expectedCode: http.StatusBadRequest},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var deviceGroup *configmodels.DeviceGroups
+			if tc.deviceGroup != nil {
+				deviceGroupCopy := *tc.deviceGroup
+				deviceGroupCopy.Imsis = slices.Clone(tc.deviceGroup.Imsis)
+				deviceGroup = &deviceGroupCopy
+			}
+			commonDb := newDeviceGroupMemberMockDBClient(deviceGroup)
+			commonDb.otherGroups = []configmodels.DeviceGroups{deviceGroupWithImsis("group2", []string{"208930100007490"})}
+			setupDeviceGroupMemberMocks(t, commonDb, nil)
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedBody != "" && w.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %s, got %s", tc.expectedBody, w.Body.String())
+			}
+			if (w.Code == http.StatusOK) != (len(commonDb.storedGroups) == 1) {
+				t.Errorf("expected the device group to be stored only on success, got %d writes", len(commonDb.storedGroups))
+			}
+		})
+	}
+}
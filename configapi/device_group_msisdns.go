@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/identity"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var errMsisdnConflict = errors.New("MSISDN already assigned to another IMSI")
+
+// normalizeDeviceGroupMsisdns converts the MSISDNs matched to the IMSIs by position and
+// validates the MSISDN of every IMSI. Each MSISDN must belong to an IMSI of the group and
+// may only be assigned once.
+func normalizeDeviceGroupMsisdns(devGroup *configmodels.DeviceGroups) error {
+	devGroup.ConvertLegacyMsisdns()
+	if len(devGroup.ImsiMsisdns) == 0 {
+		devGroup.ImsiMsisdns = nil
+		return nil
+	}
+	imsiMsisdns := make(map[string]string, len(devGroup.ImsiMsisdns))
+	imsiByMsisdn := make(map[string]string, len(devGroup.ImsiMsisdns))
+	for imsi, msisdn := range devGroup.ImsiMsisdns {
+		supi, err := identity.ParseImsi(imsi)
+		if err != nil {
+			return err
+		}
+		if !devGroup.ContainsImsi(supi.Value) {
+			return fmt.Errorf("MSISDN %s is assigned to IMSI %s which does not belong to device group %s", msisdn, supi.Value, devGroup.DeviceGroupName)
+		}
+		gpsi, err := identity.ParseMsisdn(msisdn)
+		if err != nil {
+			return err
+		}
+		if otherImsi, exists := imsiByMsisdn[gpsi]; exists {
+			return fmt.Errorf("%w: %s is assigned to IMSIs %s and %s", errMsisdnConflict, gpsi, otherImsi, supi.Value)
+		}
+		imsiByMsisdn[gpsi] = supi.Value
+		imsiMsisdns[supi.Value] = gpsi
+	}
+	devGroup.ImsiMsisdns = imsiMsisdns
+	return nil
+}
+
+// checkMsisdnConflicts rejects the MSISDNs of devGroup assigned to another IMSI in otherGroups
+func checkMsisdnConflicts(devGroup *configmodels.DeviceGroups, otherGroups []configmodels.DeviceGroups) error {
+	if len(devGroup.ImsiMsisdns) == 0 {
+		return nil
+	}
+	imsiByMsisdn := make(map[string]string, len(devGroup.ImsiMsisdns))
+	for imsi, msisdn := range devGroup.ImsiMsisdns {
+		imsiByMsisdn[msisdn] = imsi
+	}
+	for _, otherGroup := range otherGroups {
+		for otherImsi, msisdn := range otherGroup.ImsiMsisdns {
+			if imsi, exists := imsiByMsisdn[msisdn]; exists && imsi != otherImsi {
+				return fmt.Errorf("%w: %s is assigned to IMSI %s in device group %s", errMsisdnConflict, msisdn, otherImsi, otherGroup.DeviceGroupName)
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateDeviceGroupMsisdns converts the MSISDNs of the stored device groups matched to
+// their IMSIs by position to an explicit mapping. It returns the number of device groups
+// converted.
+func MigrateDeviceGroupMsisdns() (int, error) {
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{"msisdns": bson.M{"$exists": true}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch device groups: %w", err)
+	}
+	migrated := 0
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		devGroup, err := unmarshalDeviceGroup(rawDeviceGroup)
+		if err != nil {
+			return migrated, err
+		}
+		devGroup.ConvertLegacyMsisdns()
+		for imsi, msisdn := range devGroup.ImsiMsisdns {
+			gpsi, err := identity.ParseMsisdn(msisdn)
+			if err != nil {
+				// kept as is so that no phone number is lost, it must be fixed by the operator
+				logger.ConfigLog.Warnf("device group %s: IMSI %s has an invalid MSISDN: %+v", devGroup.DeviceGroupName, imsi, err)
+				continue
+			}
+			devGroup.ImsiMsisdns[imsi] = gpsi
+		}
+		if err = storeDeviceGroup(devGroup); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	if migrated > 0 {
+		logger.ConfigLog.Infof("converted the MSISDNs of %d device groups to an IMSI mapping", migrated)
+	}
+	return migrated, nil
+}
+
+// GetSubscriberByMsisdn godoc
+//
+// @Description  Return the IMSI and device group an MSISDN is assigned to
+// @Tags         Subscribers
+// @Param        msisdn    path    string    true    "MSISDN, with or without its msisdn- prefix"
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.MsisdnAssignment  "MSISDN assignment"
+// @Failure      400  {object}  nil                            "Invalid MSISDN"
+// @Failure      401  {object}  nil                            "Authorization failed"
+// @Failure      403  {object}  nil                            "Forbidden"
+// @Failure      404  {object}  nil                            "MSISDN not assigned"
+// @Failure      500  {object}  nil                            "Error retrieving device groups"
+// @Router       /api/msisdn/{msisdn}  [get]
+func GetSubscriberByMsisdn(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get Subscriber by MSISDN")
+	gpsi, err := identity.ParseMsisdn(c.Param("msisdn"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to retrieve device groups: %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "failed to retrieve device groups",
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	for _, devGroup := range devGroups {
+		for imsi, msisdn := range devGroup.ImsiMsisdns {
+			if msisdn == gpsi {
+				c.JSON(http.StatusOK, configmodels.MsisdnAssignment{
+					Msisdn:      gpsi,
+					UeId:        "imsi-" + imsi,
+					DeviceGroup: devGroup.DeviceGroupName,
+				})
+				return
+			}
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("MSISDN %s is not assigned", gpsi), "request_id": requestID})
+}
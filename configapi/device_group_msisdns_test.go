@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+)
+
+func TestNormalizeDeviceGroupMsisdns(t *testing.T) {
+	tests := []struct {
+		name          string
+		msisdns       []string
+		imsiMsisdns   map[string]string
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name:     "Legacy MSISDNs matched by position",
+			msisdns:  []string{"0900000001", ""},
+			expected: map[string]string{"208930100007487": "msisdn-0900000001"},
+		},
+		{
+			name:        "Mapped MSISDNs",
+			imsiMsisdns: map[string]string{"imsi-208930100007488": "+0900000002"},
+			expected:    map[string]string{"208930100007488": "msisdn-0900000002"},
+		},
+		{
+			name:        "Mapping kept over legacy MSISDNs",
+			msisdns:     []string{"0900000001"},
+			imsiMsisdns: map[string]string{"208930100007487": "0900000003"},
+			expected:    map[string]string{"208930100007487": "msisdn-0900000003"},
+		},
+		{
+			name:          "Invalid MSISDN",
+			imsiMsisdns:   map[string]string{"208930100007487": "09000a"},
+			expectedError: "invalid MSISDN",
+		},
+		{
+			name:          "IMSI of another group",
+			imsiMsisdns:   map[string]string{"208930100007489": "0900000001"},
+			expectedError: "does not belong",
+		},
+		{
+			name:          "MSISDN assigned twice",
+			imsiMsisdns:   map[string]string{"208930100007487": "0900000001", "208930100007488": "msisdn-0900000001"},
+			expectedError: errMsisdnConflict.Error(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			devGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})
+			devGroup.Msisdns = tc.msisdns
+			devGroup.ImsiMsisdns = tc.imsiMsisdns
+			err := normalizeDeviceGroupMsisdns(&devGroup)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(devGroup.ImsiMsisdns, tc.expected) || devGroup.Msisdns != nil {
+				t.Errorf("expected MSISDNs %v, got %v and %v", tc.expected, devGroup.ImsiMsisdns, devGroup.Msisdns)
+			}
+		})
+	}
+}
+
+func TestDeviceGroupPostHandler_MsisdnConflict(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	otherGroup := deviceGroup("group2")
+	otherGroup.ImsiMsisdns = map[string]string{"208930100007487": "msisdn-0900000001"}
+	mock := &DeviceGroupMockDBClient{configuredDeviceGroups: []configmodels.DeviceGroups{otherGroup}}
+	dbadapter.CommonDBClient = mock
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	newDeviceGroup := deviceGroup("group1")
+	newDeviceGroup.Imsis = []string{"208930100007489"}
+	newDeviceGroup.ImsiMsisdns = map[string]string{"208930100007489": "0900000001"}
+	jsonBody, err := json.Marshal(newDeviceGroup)
+	if err != nil {
+		t.Fatalf("failed to marshal device group %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1", bytes.NewReader(jsonBody))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if len(mock.postData) != 0 {
+		t.Errorf("expected the device group not to be stored, got %d posts", len(mock.postData))
+	}
+}
+
+func TestDeviceGroupMemberAdd_MsisdnAlreadyAssigned(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487"})
+	deviceGroup.ImsiMsisdns = map[string]string{"208930100007487": "msisdn-0900000001"}
+	commonDb := newDeviceGroupMemberMockDBClient(&deviceGroup)
+	setupDeviceGroupMemberMocks(t, commonDb, nil)
+
+	_, err := deviceGroupMemberAdd("group1", configmodels.DeviceGroupMember{Imsi: "208930100007488", Msisdn: "msisdn-0900000001"}, false)
+	if !errors.Is(err, errMsisdnConflict) {
+		t.Fatalf("expected an MSISDN conflict, got %v", err)
+	}
+	if len(commonDb.storedGroups) != 0 {
+		t.Errorf("expected the device group not to be stored, got %+v", commonDb.storedGroups)
+	}
+}
+
+func TestMigrateDeviceGroupMsisdns(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488", "208930100007489"})
+	deviceGroup.Msisdns = []string{"0900000001", "", "invalid"}
+	commonDb := newDeviceGroupMemberMockDBClient(nil)
+	commonDb.otherGroups = []configmodels.DeviceGroups{deviceGroup}
+	setupDeviceGroupMemberMocks(t, commonDb, nil)
+
+	migrated, err := MigrateDeviceGroupMsisdns()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrated != 1 || len(commonDb.storedGroups) != 1 {
+		t.Fatalf("expected 1 device group to be migrated, got %d and %d stored", migrated, len(commonDb.storedGroups))
+	}
+	expected := map[string]string{"208930100007487": "msisdn-0900000001", "208930100007489": "invalid"}
+	stored := commonDb.storedGroups[0]
+	if !reflect.DeepEqual(stored.ImsiMsisdns, expected) || stored.Msisdns != nil {
+		t.Errorf("expected MSISDNs %v, got %v and %v", expected, stored.ImsiMsisdns, stored.Msisdns)
+	}
+}
+
+func TestGetSubscriberByMsisdn(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	group := deviceGroup("group1")
+	group.ImsiMsisdns = map[string]string{"208930100007488": "msisdn-0900000002"}
+	dbadapter.CommonDBClient = &DeviceGroupMockDBClient{configuredDeviceGroups: []configmodels.DeviceGroups{group}}
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+
+	tests := []struct {
+		name         string
+		msisdn       string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Assigned MSISDN",
+			msisdn:       "+0900000002",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msisdn":"msisdn-0900000002","ueId":"imsi-208930100007488","device-group":"group1"}`,
+		},
+		{name: "Unassigned MSISDN", msisdn: "msisdn-0900000003", expectedCode: http.StatusNotFound},
+		{name: "Invalid MSISDN", msisdn: "0900a", expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/api/msisdn/"+tc.msisdn, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedBody != "" && w.Body.String() != tc.expectedBody {
+				t.Errorf("expected %s, got %s", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
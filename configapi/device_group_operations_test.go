@@ -32,7 +32,7 @@ func (db *DeviceGroupMockDBClient) RestfulAPIGetOne(coll string, filter bson.M)
 	if db.err != nil {
 		return nil, db.err
 	}
-	if len(db.configuredDeviceGroups) == 0 {
+	if len(db.configuredDeviceGroups) == 0 || coll == trafficClassDataColl {
 		return nil, nil
 	}
 	dg := configmodels.ToBsonM(db.configuredDeviceGroups[0])
@@ -100,7 +100,7 @@ func deviceGroup(name string) configmodels.DeviceGroups {
 	}
 	deviceGroup := configmodels.DeviceGroups{
 		DeviceGroupName: name,
-		Imsis:           []string{"1234", "5678"},
+		Imsis:           []string{"208930100007487", "208930100007488"},
 		SiteInfo:        "demo",
 		IpDomainName:    "pool1",
 		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
@@ -463,3 +463,202 @@ func TestDeviceGroupPostHandler_DeviceGroupNameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceGroupPostHandler_ImsiValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		imsis         []string
+		expectedCode  int
+		expectedImsis []any
+	}{
+		{
+			name:          "IMSIs are normalized",
+			imsis:         []string{"imsi-208930100007487", "208930100007488"},
+			expectedCode:  http.StatusOK,
+			expectedImsis: []any{"208930100007487", "208930100007488"},
+		},
+		{
+			name:         "IMSI too short",
+			imsis:        []string{"1234"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "NAI is not an IMSI",
+			imsis:        []string{"nai-user@example.com"},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &DeviceGroupMockDBClient{}
+			dbadapter.CommonDBClient = mock
+
+			newDeviceGroup := deviceGroup("group1")
+			newDeviceGroup.Imsis = tc.imsis
+			jsonBody, err := json.Marshal(newDeviceGroup)
+			if err != nil {
+				t.Fatalf("failed to marshal device group %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1", bytes.NewReader(jsonBody))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if tc.expectedCode != w.Code {
+				t.Fatalf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if tc.expectedImsis == nil {
+				if len(mock.postData) != 0 {
+					t.Errorf("expected no device group to be stored")
+				}
+				return
+			}
+			if len(mock.postData) == 0 {
+				t.Fatal("expected a post operation but none was recorded")
+			}
+			result := mock.postData[0]["data"].(map[string]any)
+			if !reflect.DeepEqual(result["imsis"], tc.expectedImsis) {
+				t.Errorf("expected IMSIs %v, got %v", tc.expectedImsis, result["imsis"])
+			}
+		})
+	}
+}
+
+func TestDeviceGroupPostHandler_ImsiRangeValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name           string
+		imsiRanges     []configmodels.ImsiRange
+		expectedCode   int
+		expectedRanges []any
+	}{
+		{
+			name:           "Range with end is normalized",
+			imsiRanges:     []configmodels.ImsiRange{{Start: "imsi-208930100000001", End: "imsi-208930100000100"}},
+			expectedCode:   http.StatusOK,
+			expectedRanges: []any{map[string]any{"start": "208930100000001", "end": "208930100000100"}},
+		},
+		{
+			name:           "Range with count",
+			imsiRanges:     []configmodels.ImsiRange{{Start: "208930100000001", Count: 1000}},
+			expectedCode:   http.StatusOK,
+			expectedRanges: []any{map[string]any{"start": "208930100000001", "count": float64(1000)}},
+		},
+		{
+			name:         "Range with both end and count",
+			imsiRanges:   []configmodels.ImsiRange{{Start: "208930100000001", End: "208930100000100", Count: 100}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Range end lower than start",
+			imsiRanges:   []configmodels.ImsiRange{{Start: "208930100000100", End: "208930100000001"}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Range with invalid start",
+			imsiRanges:   []configmodels.ImsiRange{{Start: "1234", Count: 10}},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &DeviceGroupMockDBClient{}
+			dbadapter.CommonDBClient = mock
+
+			newDeviceGroup := deviceGroup("group1")
+			newDeviceGroup.ImsiRanges = tc.imsiRanges
+			jsonBody, err := json.Marshal(newDeviceGroup)
+			if err != nil {
+				t.Fatalf("failed to marshal device group %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1", bytes.NewReader(jsonBody))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if tc.expectedCode != w.Code {
+				t.Fatalf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if tc.expectedRanges == nil {
+				if len(mock.postData) != 0 {
+					t.Errorf("expected no device group to be stored")
+				}
+				return
+			}
+			if len(mock.postData) == 0 {
+				t.Fatal("expected a post operation but none was recorded")
+			}
+			result := mock.postData[0]["data"].(map[string]any)
+			if !reflect.DeepEqual(result["imsi-ranges"], tc.expectedRanges) {
+				t.Errorf("expected IMSI ranges %v, got %v", tc.expectedRanges, result["imsi-ranges"])
+			}
+		})
+	}
+}
+
+type ImsiRangeMockDBClient struct {
+	dbadapter.DBInterface
+	ueIds          []any
+	receivedFilter []bson.M
+}
+
+func (db *ImsiRangeMockDBClient) RestfulAPIDistinct(collName string, fieldName string, filter bson.M) ([]any, error) {
+	db.receivedFilter = append(db.receivedFilter, filter)
+	return db.ueIds, nil
+}
+
+func TestProvisionedRangeImsis(t *testing.T) {
+	originalAuthDBClient := dbadapter.AuthDBClient
+	defer func() { dbadapter.AuthDBClient = originalAuthDBClient }()
+	mock := &ImsiRangeMockDBClient{
+		// the string bounds also match the 14 digit IMSI
+		ueIds: []any{"imsi-208930100000001", "imsi-20893010000005", "imsi-208930100000100"},
+	}
+	dbadapter.AuthDBClient = mock
+
+	devGroup := deviceGroup("group1")
+	devGroup.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100000001", Count: 100}}
+	imsis, err := provisionedRangeImsis(&devGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedImsis := []string{"208930100000001", "208930100000100"}
+	if !reflect.DeepEqual(imsis, expectedImsis) {
+		t.Errorf("expected IMSIs %v, got %v", expectedImsis, imsis)
+	}
+	expectedFilter := []bson.M{{"ueId": bson.M{"$gte": "imsi-208930100000001", "$lte": "imsi-208930100000100"}}}
+	if !reflect.DeepEqual(mock.receivedFilter, expectedFilter) {
+		t.Errorf("expected filter %v, got %v", expectedFilter, mock.receivedFilter)
+	}
+}
+
+func TestGetDeletedImsisList_ImsiRanges(t *testing.T) {
+	prevGroup := deviceGroup("group1")
+	group := deviceGroup("group1")
+	group.Imsis = []string{}
+	group.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100007487", End: "208930100007487"}}
+
+	dimsis := getDeletedImsisList(&group, &prevGroup)
+	if !reflect.DeepEqual(dimsis, []string{"208930100007488"}) {
+		t.Errorf("expected only the IMSI outside of the range to be deleted, got %v", dimsis)
+	}
+}
@@ -0,0 +1,642 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const deviceGroupTemplateDataColl = "webconsoleData.snapshots.devGroupTemplateData"
+
+var (
+	errDeviceGroupTemplateNotFound = errors.New("device group template not found")
+	errDeviceGroupTemplateExists   = errors.New("device group template already exists")
+	errDeviceGroupTemplateInUse    = errors.New("device group template is referenced by device groups")
+	errDeviceGroupExists           = errors.New("device group already exists")
+)
+
+// fetchDeviceGroupTemplate returns the template named templateName, or nil if it does not exist
+func fetchDeviceGroupTemplate(templateName string) (*configmodels.DeviceGroupTemplate, error) {
+	rawTemplate, err := dbadapter.CommonDBClient.RestfulAPIGetOne(deviceGroupTemplateDataColl, bson.M{"template-name": templateName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch device group template %s: %w", templateName, err)
+	}
+	if len(rawTemplate) == 0 {
+		return nil, nil
+	}
+	var template configmodels.DeviceGroupTemplate
+	if err = json.Unmarshal(configmodels.MapToByte(rawTemplate), &template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device group template %s: %w", templateName, err)
+	}
+	return &template, nil
+}
+
+// validateDeviceGroupTemplate checks the IP domains of template. UE IP pools are rejected,
+// as device groups sharing a UPF cannot share their pools.
+func validateDeviceGroupTemplate(template *configmodels.DeviceGroupTemplate) error {
+	var dnns []string
+	for i := range template.IpDomainsExpanded {
+		ipDomain := &template.IpDomainsExpanded[i]
+		if ipDomain.Dnn == "" {
+			return fmt.Errorf("IP domain %d: missing DNN", i)
+		}
+		if slices.Contains(dnns, ipDomain.Dnn) {
+			return fmt.Errorf("duplicate DNN %s", ipDomain.Dnn)
+		}
+		dnns = append(dnns, ipDomain.Dnn)
+		if ipDomain.UeIpPool != "" || ipDomain.UeIpv6Pool != "" {
+			return fmt.Errorf("DNN %s: UE IP pools must be set on the device groups derived from the template", ipDomain.Dnn)
+		}
+		if _, _, err := validatePduSessionTypes(ipDomain); err != nil {
+			return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+		}
+		if err := validateIpDomainAddresses(ipDomain); err != nil {
+			return fmt.Errorf("DNN %s: %w", ipDomain.Dnn, err)
+		}
+	}
+	return nil
+}
+
+// applyDeviceGroupTemplate returns the IP domains of template with overrides applied. Each
+// override must match a DNN of the template.
+func applyDeviceGroupTemplate(template *configmodels.DeviceGroupTemplate, overrides []configmodels.DeviceGroupsIpDomainExpanded) ([]configmodels.DeviceGroupsIpDomainExpanded, error) {
+	var ipDomains []configmodels.DeviceGroupsIpDomainExpanded
+	for _, ipDomain := range template.IpDomainsExpanded {
+		ipDomain.PduSessionTypes = slices.Clone(ipDomain.PduSessionTypes)
+		ipDomain.UeDnnQos = copyUeDnnQos(ipDomain.UeDnnQos)
+		ipDomains = append(ipDomains, ipDomain)
+	}
+	for i, override := range overrides {
+		if slices.ContainsFunc(overrides[:i], func(o configmodels.DeviceGroupsIpDomainExpanded) bool { return o.Dnn == override.Dnn }) {
+			return nil, fmt.Errorf("duplicate override of DNN %s", override.Dnn)
+		}
+		j := slices.IndexFunc(ipDomains, func(ipDomain configmodels.DeviceGroupsIpDomainExpanded) bool {
+			return ipDomain.Dnn == override.Dnn
+		})
+		if j < 0 {
+			return nil, fmt.Errorf("override of DNN %q which is not part of template %s", override.Dnn, template.TemplateName)
+		}
+		overrideIpDomain(&ipDomains[j], override)
+	}
+	return ipDomains, nil
+}
+
+// overrideIpDomain replaces the fields of ipDomain by the non-empty fields of override
+func overrideIpDomain(ipDomain *configmodels.DeviceGroupsIpDomainExpanded, override configmodels.DeviceGroupsIpDomainExpanded) {
+	fields := []struct {
+		value    *string
+		override string
+	}{
+		{&ipDomain.UeIpPool, override.UeIpPool},
+		{&ipDomain.UeIpv6Pool, override.UeIpv6Pool},
+		{&ipDomain.DnsPrimary, override.DnsPrimary},
+		{&ipDomain.DnsPrimaryIpv6, override.DnsPrimaryIpv6},
+		{&ipDomain.DnsSecondary, override.DnsSecondary},
+		{&ipDomain.DnsSecondaryIpv6, override.DnsSecondaryIpv6},
+		{&ipDomain.PcscfPrimary, override.PcscfPrimary},
+		{&ipDomain.PcscfPrimaryIpv6, override.PcscfPrimaryIpv6},
+	}
+	for _, field := range fields {
+		if field.override != "" {
+			*field.value = field.override
+		}
+	}
+	if len(override.PduSessionTypes) > 0 {
+		ipDomain.PduSessionTypes = slices.Clone(override.PduSessionTypes)
+	}
+	if override.Mtu != 0 {
+		ipDomain.Mtu = override.Mtu
+	}
+	if override.UeDnnQos != nil {
+		ipDomain.UeDnnQos = copyUeDnnQos(override.UeDnnQos)
+	}
+}
+
+// copyUeDnnQos returns a deep copy of qos, as the bitrates of a device group are converted
+// in place when it is stored
+func copyUeDnnQos(qos *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
+	if qos == nil {
+		return nil
+	}
+	qosCopy := *qos
+	if qos.TrafficClass != nil {
+		trafficClass := *qos.TrafficClass
+		qosCopy.TrafficClass = &trafficClass
+	}
+	return &qosCopy
+}
+
+// derivedDeviceGroups returns the device groups derived from the template named templateName
+func derivedDeviceGroups(templateName string) ([]configmodels.DeviceGroups, error) {
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(devGroups, func(devGroup configmodels.DeviceGroups) bool {
+		return devGroup.Template == nil || devGroup.Template.Name != templateName
+	}), nil
+}
+
+// previewDeviceGroupTemplate compares the IP domains of every device group of devGroups with
+// the IP domains template would give it, as they would be stored. The IP domains to post
+// are returned for each changed device group.
+func previewDeviceGroupTemplate(template *configmodels.DeviceGroupTemplate, devGroups []configmodels.DeviceGroups) ([]configmodels.DeviceGroupTemplatePreview, map[string][]configmodels.DeviceGroupsIpDomainExpanded) {
+	previews := make([]configmodels.DeviceGroupTemplatePreview, 0, len(devGroups))
+	changes := make(map[string][]configmodels.DeviceGroupsIpDomainExpanded)
+	for _, devGroup := range devGroups {
+		preview := configmodels.DeviceGroupTemplatePreview{
+			DeviceGroup: devGroup.DeviceGroupName,
+			Current:     devGroup.IpDomainsExpanded,
+		}
+		ipDomains, err := applyDeviceGroupTemplate(template, devGroup.Template.IpDomainOverrides)
+		if err != nil {
+			preview.Error = err.Error()
+			previews = append(previews, preview)
+			continue
+		}
+		var updated []configmodels.DeviceGroupsIpDomainExpanded
+		for _, ipDomain := range ipDomains {
+			ipDomain.UeDnnQos = copyUeDnnQos(ipDomain.UeDnnQos)
+			if ipDomain.UeDnnQos != nil {
+				convertUeDnnQosToBps(ipDomain.UeDnnQos)
+			}
+			updated = append(updated, ipDomain)
+		}
+		if !reflect.DeepEqual(devGroup.IpDomainsExpanded, updated) {
+			preview.Changed = true
+			preview.Updated = updated
+			changes[devGroup.DeviceGroupName] = ipDomains
+		}
+		previews = append(previews, preview)
+	}
+	return previews, changes
+}
+
+// propagateDeviceGroupTemplate stores the changed IP domains of the device groups derived
+// from the template named templateName. A device group failing to be updated does not
+// prevent the others from being updated, and its error is reported in its preview.
+func propagateDeviceGroupTemplate(templateName string) ([]configmodels.DeviceGroupTemplatePreview, error) {
+	template, err := fetchDeviceGroupTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, errDeviceGroupTemplateNotFound
+	}
+	devGroups, err := derivedDeviceGroups(templateName)
+	if err != nil {
+		return nil, err
+	}
+	previews, changes := previewDeviceGroupTemplate(template, devGroups)
+	var failedGroups []string
+	for i := range previews {
+		preview := &previews[i]
+		if preview.Error != "" {
+			failedGroups = append(failedGroups, preview.DeviceGroup)
+			continue
+		}
+		if !preview.Changed {
+			continue
+		}
+		devGroup := devGroups[i]
+		devGroup.IpDomainsExpanded = changes[devGroup.DeviceGroupName]
+		// the IMSIs were accepted when the device group was stored
+		if _, _, err = deviceGroupPostHelper(devGroup, devGroup.DeviceGroupName, true); err != nil {
+			logger.ConfigLog.Errorf("failed to propagate template %s to device group %s: %+v", templateName, devGroup.DeviceGroupName, err)
+			preview.Error = err.Error()
+			failedGroups = append(failedGroups, preview.DeviceGroup)
+		}
+	}
+	if len(failedGroups) > 0 {
+		return previews, fmt.Errorf("failed to update device groups %s", strings.Join(failedGroups, ", "))
+	}
+	return previews, nil
+}
+
+// deviceGroupTemplatePostHelper validates and stores template. An existing template is
+// rejected unless overwrite is set. The derived device groups are not updated.
+func deviceGroupTemplatePostHelper(template configmodels.DeviceGroupTemplate, templateName string, overwrite bool) (int, error) {
+	if !isValidName(templateName) {
+		return http.StatusBadRequest, fmt.Errorf("invalid device group template name %s. Name needs to match regular expression: %s", templateName, NAME_PATTERN)
+	}
+	template.TemplateName = templateName
+	if err := validateDeviceGroupTemplate(&template); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := resolveIpDomainTrafficClasses(template.IpDomainsExpanded); err != nil {
+		if errors.Is(err, errInvalidTrafficClassReference) {
+			return http.StatusBadRequest, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	if !overwrite {
+		existing, err := fetchDeviceGroupTemplate(templateName)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if existing != nil {
+			return http.StatusConflict, errDeviceGroupTemplateExists
+		}
+	}
+	filter := bson.M{"template-name": templateName}
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPost(deviceGroupTemplateDataColl, filter, configmodels.ToBsonM(template)); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to store device group template %s: %w", templateName, err)
+	}
+	return http.StatusOK, nil
+}
+
+// deviceGroupTemplateDeleteHelper deletes the template named templateName, unless device
+// groups are derived from it
+func deviceGroupTemplateDeleteHelper(templateName string) error {
+	devGroups, err := derivedDeviceGroups(templateName)
+	if err != nil {
+		return err
+	}
+	if len(devGroups) > 0 {
+		groupNames := make([]string, 0, len(devGroups))
+		for _, devGroup := range devGroups {
+			groupNames = append(groupNames, devGroup.DeviceGroupName)
+		}
+		return fmt.Errorf("%w: %s", errDeviceGroupTemplateInUse, strings.Join(groupNames, ", "))
+	}
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	if err = dbadapter.CommonDBClient.RestfulAPIDeleteOne(deviceGroupTemplateDataColl, bson.M{"template-name": templateName}); err != nil {
+		return fmt.Errorf("failed to delete device group template %s: %w", templateName, err)
+	}
+	return nil
+}
+
+// instantiateDeviceGroupTemplate creates the device group named groupName from the
+// template named templateName. With dryRun, the device group is validated and the effect
+// of creating it is returned instead.
+func instantiateDeviceGroupTemplate(templateName string, groupName string, instantiation configmodels.DeviceGroupInstantiation, allowDuplicates bool, dryRun bool) (*configmodels.DryRunReport, []configmodels.ImsiConflict, int, error) {
+	devGroup, statusCode, err := templateDeviceGroup(templateName, groupName, instantiation)
+	if err != nil {
+		return nil, nil, statusCode, err
+	}
+	if dryRun {
+		report, statusCode, err := deviceGroupDryRun(*devGroup, groupName, allowDuplicates)
+		return report, nil, statusCode, err
+	}
+	conflicts, statusCode, err := deviceGroupPostHelper(*devGroup, groupName, allowDuplicates)
+	return nil, conflicts, statusCode, err
+}
+
+// templateDeviceGroup returns the device group named groupName instantiated from the
+// template named templateName
+func templateDeviceGroup(templateName string, groupName string, instantiation configmodels.DeviceGroupInstantiation) (*configmodels.DeviceGroups, int, error) {
+	template, err := fetchDeviceGroupTemplate(templateName)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if template == nil {
+		return nil, http.StatusNotFound, errDeviceGroupTemplateNotFound
+	}
+	existing, err := fetchDeviceGroup(groupName)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if existing != nil {
+		return nil, http.StatusConflict, errDeviceGroupExists
+	}
+	ipDomains, err := applyDeviceGroupTemplate(template, instantiation.IpDomainOverrides)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	devGroup := configmodels.DeviceGroups{
+		DeviceGroupName:   groupName,
+		Imsis:             instantiation.Imsis,
+		ImsiRanges:        instantiation.ImsiRanges,
+		ImsiMsisdns:       instantiation.ImsiMsisdns,
+		SiteInfo:          instantiation.SiteInfo,
+		IpDomainsExpanded: ipDomains,
+		Template: &configmodels.DeviceGroupTemplateRef{
+			Name:              templateName,
+			IpDomainOverrides: instantiation.IpDomainOverrides,
+		},
+	}
+	if devGroup.Imsis == nil {
+		devGroup.Imsis = []string{}
+	}
+	return &devGroup, http.StatusOK, nil
+}
+
+// GetDeviceGroupTemplates godoc
+//
+// @Description  Return the list of device group templates
+// @Tags         Device Group Templates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   string  "List of device group template names"
+// @Failure      401  {object}  nil     "Authorization failed"
+// @Failure      403  {object}  nil     "Forbidden"
+// @Failure      500  {object}  nil     "Error retrieving device group templates"
+// @Router       /config/v1/device-group-template  [get]
+func GetDeviceGroupTemplates(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get all Device Group Templates")
+	rawTemplates, err := dbadapter.CommonDBClient.RestfulAPIGetMany(deviceGroupTemplateDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s failed to retrieve device group templates: %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve device group templates", "request_id": requestID})
+		return
+	}
+	templateNames := make([]string, 0, len(rawTemplates))
+	for _, rawTemplate := range rawTemplates {
+		if templateName, ok := rawTemplate["template-name"].(string); ok {
+			templateNames = append(templateNames, templateName)
+		}
+	}
+	c.JSON(http.StatusOK, templateNames)
+}
+
+// GetDeviceGroupTemplateByName godoc
+//
+// @Description  Return the device group template
+// @Tags         Device Group Templates
+// @Param        templateName    path    string    true    " "
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.DeviceGroupTemplate  "Device group template"
+// @Failure      401  {object}  nil                               "Authorization failed"
+// @Failure      403  {object}  nil                               "Forbidden"
+// @Failure      404  {object}  nil                               "Device group template not found"
+// @Failure      500  {object}  nil                               "Error retrieving device group template"
+// @Router       /config/v1/device-group-template/{templateName}  [get]
+func GetDeviceGroupTemplateByName(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get Device Group Template by name")
+	template, err := fetchDeviceGroupTemplate(c.Param("template-name"))
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve device group template", "request_id": requestID})
+		return
+	}
+	if template == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errDeviceGroupTemplateNotFound.Error(), "request_id": requestID})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeviceGroupTemplatePost godoc
+//
+// @Description  Create a new device group template
+// @Tags         Device Group Templates
+// @Param        templateName    path    string                                true    " "
+// @Param        content         body    configmodels.DeviceGroupTemplate     true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Device group template created"
+// @Failure      400  {object}  nil  "Invalid device group template content"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "Device group template already exists"
+// @Failure      500  {object}  nil  "Error creating device group template"
+// @Router       /config/v1/device-group-template/{templateName}  [post]
+func DeviceGroupTemplatePost(c *gin.Context) {
+	deviceGroupTemplateWrite(c, false)
+}
+
+// DeviceGroupTemplatePut godoc
+//
+// @Description  Create or update a device group template. The device groups derived from it are updated by propagating the template.
+// @Tags         Device Group Templates
+// @Param        templateName    path    string                                true    " "
+// @Param        content         body    configmodels.DeviceGroupTemplate     true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Device group template stored"
+// @Failure      400  {object}  nil  "Invalid device group template content"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      500  {object}  nil  "Error storing device group template"
+// @Router       /config/v1/device-group-template/{templateName}  [put]
+func DeviceGroupTemplatePut(c *gin.Context) {
+	deviceGroupTemplateWrite(c, true)
+}
+
+func deviceGroupTemplateWrite(c *gin.Context, overwrite bool) {
+	requestID := uuid.New().String()
+	templateName := c.Param("template-name")
+	logger.WebUILog.Debugf("Request ID: %s store device group template %s", requestID, templateName)
+	var template configmodels.DeviceGroupTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		err = fmt.Errorf("JSON bind error: %w", err)
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	if statusCode, err := deviceGroupTemplatePostHelper(template, templateName, overwrite); err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to store device group template %s: %+v", requestID, templateName, err)
+		c.JSON(statusCode, gin.H{
+			"error":      fmt.Sprintf("Failed to store device group template %s with error: %+v.", templateName, err),
+			"request_id": requestID,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeviceGroupTemplateDelete godoc
+//
+// @Description  Delete a device group template no device group is derived from
+// @Tags         Device Group Templates
+// @Param        templateName    path    string    true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Device group template deleted"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "Device groups are derived from the template"
+// @Failure      500  {object}  nil  "Error deleting device group template"
+// @Router       /config/v1/device-group-template/{templateName}  [delete]
+func DeviceGroupTemplateDelete(c *gin.Context) {
+	requestID := uuid.New().String()
+	templateName := c.Param("template-name")
+	logger.WebUILog.Debugf("Request ID: %s delete device group template %s", requestID, templateName)
+	if err := deviceGroupTemplateDeleteHelper(templateName); err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to delete device group template %s: %+v", requestID, templateName, err)
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, errDeviceGroupTemplateInUse) {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error":      fmt.Sprintf("Failed to delete device group template %s with error: %+v.", templateName, err),
+			"request_id": requestID,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeviceGroupTemplateInstantiate godoc
+//
+// @Description  Create a device group from a device group template. The IP domain overrides, such as the UE IP pools, are matched to the IP domains of the template by DNN.
+// @Tags         Device Group Templates
+// @Param        templateName       path    string                                   true    " "
+// @Param        deviceGroupName    path    string                                   true    " "
+// @Param        content            body    configmodels.DeviceGroupInstantiation    true    " "
+// @Param        allow-duplicate-imsis    query    bool    false    "Accept IMSIs belonging to another device group and report them"
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Device group created"
+// @Failure      400  {object}  nil  "Invalid device group content"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Device group template not found"
+// @Failure      409  {object}  nil  "Device group already exists, IMSIs already belong to another device group, MSISDNs already assigned or UE IP pool overlap"
+// @Failure      500  {object}  nil  "Error creating device group"
+// @Router       /config/v1/device-group-template/{templateName}/device-group/{deviceGroupName}  [post]
+func DeviceGroupTemplateInstantiate(c *gin.Context) {
+	requestID := uuid.New().String()
+	templateName := c.Param("template-name")
+	groupName := c.Param("group-name")
+	logger.WebUILog.Debugf("Request ID: %s create device group %s from template %s", requestID, groupName, templateName)
+	if !isValidName(groupName) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      fmt.Sprintf("Invalid Device Group name %s. Name needs to match regular expression: %s", groupName, NAME_PATTERN),
+			"request_id": requestID,
+		})
+		return
+	}
+	var instantiation configmodels.DeviceGroupInstantiation
+	if err := c.ShouldBindJSON(&instantiation); err != nil {
+		err = fmt.Errorf("JSON bind error: %w", err)
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	allowDuplicates, err := allowDuplicateImsis(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	report, conflicts, statusCode, err := instantiateDeviceGroupTemplate(templateName, groupName, instantiation, allowDuplicates, dryRun)
+	if err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to create device group %s from template %s: %+v", requestID, groupName, templateName, err)
+		c.JSON(statusCode, gin.H{
+			"error":      fmt.Sprintf("Failed to create device group %s with error: %+v.", groupName, err),
+			"request_id": requestID,
+			"message":    "Please refer to the log with the provided Request ID for details",
+		})
+		return
+	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	respondImsiConflicts(c, conflicts)
+}
+
+// GetDeviceGroupTemplatePreview godoc
+//
+// @Description  Return the changes propagating the device group template would make to the IP domains of the device groups derived from it
+// @Tags         Device Group Templates
+// @Param        templateName    path    string    true    " "
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.DeviceGroupTemplatePreview  "IP domains of the derived device groups"
+// @Failure      401  {object}  nil                                      "Authorization failed"
+// @Failure      403  {object}  nil                                      "Forbidden"
+// @Failure      404  {object}  nil                                      "Device group template not found"
+// @Failure      500  {object}  nil                                      "Error retrieving device groups"
+// @Router       /config/v1/device-group-template/{templateName}/preview  [get]
+func GetDeviceGroupTemplatePreview(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	templateName := c.Param("template-name")
+	logger.WebUILog.Infof("Preview device group template %s", templateName)
+	template, err := fetchDeviceGroupTemplate(templateName)
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve device group template", "request_id": requestID})
+		return
+	}
+	if template == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errDeviceGroupTemplateNotFound.Error(), "request_id": requestID})
+		return
+	}
+	devGroups, err := derivedDeviceGroups(templateName)
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve device groups", "request_id": requestID})
+		return
+	}
+	previews, _ := previewDeviceGroupTemplate(template, devGroups)
+	c.JSON(http.StatusOK, previews)
+}
+
+// DeviceGroupTemplatePropagate godoc
+//
+// @Description  Update the IP domains of the device groups derived from the device group template, and the data of their subscribers
+// @Tags         Device Group Templates
+// @Param        templateName    path    string    true    " "
+// @Param        dryRun    query    bool    false    "Report the effect of the change without applying it"
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.DeviceGroupTemplatePreview  "Changes made to the derived device groups"
+// @Failure      401  {object}  nil                                      "Authorization failed"
+// @Failure      403  {object}  nil                                      "Forbidden"
+// @Failure      404  {object}  nil                                      "Device group template not found"
+// @Failure      500  {object}  nil                                      "Error updating the derived device groups"
+// @Router       /config/v1/device-group-template/{templateName}/propagate  [post]
+func DeviceGroupTemplatePropagate(c *gin.Context) {
+	requestID := uuid.New().String()
+	templateName := c.Param("template-name")
+	logger.WebUILog.Infof("Request ID: %s propagate device group template %s", requestID, templateName)
+	dryRun, err := dryRunRequested(c)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	var report *configmodels.DryRunReport
+	var previews []configmodels.DeviceGroupTemplatePreview
+	if dryRun {
+		report, previews, err = deviceGroupTemplatePropagateDryRun(templateName)
+	} else {
+		previews, err = propagateDeviceGroupTemplate(templateName)
+	}
+	if errors.Is(err, errDeviceGroupTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	if err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to propagate device group template %s: %+v", requestID, templateName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":         fmt.Sprintf("Failed to propagate device group template %s with error: %+v.", templateName, err),
+			"request_id":    requestID,
+			"device-groups": previews,
+		})
+		return
+	}
+	if report != nil {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	c.JSON(http.StatusOK, previews)
+}
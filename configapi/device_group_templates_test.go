@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type DeviceGroupTemplateMockDBClient struct {
+	dbadapter.DBInterface
+	templates    []configmodels.DeviceGroupTemplate
+	deviceGroups []configmodels.DeviceGroups
+	storedGroups []configmodels.DeviceGroups
+	deletedColls []string
+}
+
+func (db *DeviceGroupTemplateMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	switch coll {
+	case deviceGroupTemplateDataColl:
+		for _, template := range db.templates {
+			if template.TemplateName == filter["template-name"] {
+				return configmodels.ToBsonM(template), nil
+			}
+		}
+	case devGroupDataColl:
+		for _, devGroup := range db.deviceGroups {
+			if devGroup.DeviceGroupName == filter["group-name"] {
+				return configmodels.ToBsonM(devGroup), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *DeviceGroupTemplateMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	if coll == devGroupDataColl {
+		for _, devGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(devGroup))
+		}
+	}
+	return results, nil
+}
+
+func (db *DeviceGroupTemplateMockDBClient) RestfulAPIPost(coll string, filter bson.M, postData map[string]any) (bool, error) {
+	if coll == devGroupDataColl {
+		var devGroup configmodels.DeviceGroups
+		if err := json.Unmarshal(configmodels.MapToByte(postData), &devGroup); err != nil {
+			return false, err
+		}
+		db.storedGroups = append(db.storedGroups, devGroup)
+	}
+	return true, nil
+}
+
+func (db *DeviceGroupTemplateMockDBClient) RestfulAPIDeleteOne(coll string, filter bson.M) error {
+	db.deletedColls = append(db.deletedColls, coll)
+	return nil
+}
+
+func deviceGroupTemplate(name string) configmodels.DeviceGroupTemplate {
+	ipDomain := deviceGroup("").IpDomainsExpanded[0]
+	ipDomain.UeIpPool = ""
+	return configmodels.DeviceGroupTemplate{
+		TemplateName:      name,
+		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{ipDomain},
+	}
+}
+
+// derivedDeviceGroup returns the device group derived from template, as stored
+func derivedDeviceGroup(t *testing.T, name string, template configmodels.DeviceGroupTemplate, ueIpPool string) configmodels.DeviceGroups {
+	t.Helper()
+	overrides := []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet", UeIpPool: ueIpPool}}
+	ipDomains, err := applyDeviceGroupTemplate(&template, overrides)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ipDomain := range ipDomains {
+		if ipDomain.UeDnnQos != nil {
+			convertUeDnnQosToBps(ipDomain.UeDnnQos)
+		}
+	}
+	devGroup := deviceGroupWithImsis(name, []string{"208930100007487"})
+	devGroup.IpDomainsExpanded = ipDomains
+	devGroup.Template = &configmodels.DeviceGroupTemplateRef{Name: template.TemplateName, IpDomainOverrides: overrides}
+	return devGroup
+}
+
+func TestValidateDeviceGroupTemplate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(*configmodels.DeviceGroupsIpDomainExpanded)
+		expectedError string
+	}{
+		{name: "Valid template", modify: func(*configmodels.DeviceGroupsIpDomainExpanded) {}},
+		{name: "Missing DNN", modify: func(d *configmodels.DeviceGroupsIpDomainExpanded) { d.Dnn = "" }, expectedError: "missing DNN"},
+		{name: "UE IP pool", modify: func(d *configmodels.DeviceGroupsIpDomainExpanded) { d.UeIpPool = "10.0.0.0/16" }, expectedError: "UE IP pools"},
+		{name: "Invalid DNS", modify: func(d *configmodels.DeviceGroupsIpDomainExpanded) { d.DnsPrimary = "dns" }, expectedError: "invalid dns-primary"},
+		{name: "Invalid PDU session type", modify: func(d *configmodels.DeviceGroupsIpDomainExpanded) {
+			d.PduSessionTypes = []models.PduSessionType{"ETHERNET"}
+		}, expectedError: "unsupported PDU session type"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := deviceGroupTemplate("template1")
+			tc.modify(&template.IpDomainsExpanded[0])
+			err := validateDeviceGroupTemplate(&template)
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+	template := deviceGroupTemplate("template1")
+	template.IpDomainsExpanded = append(template.IpDomainsExpanded, template.IpDomainsExpanded[0])
+	if err := validateDeviceGroupTemplate(&template); err == nil || !strings.Contains(err.Error(), "duplicate DNN") {
+		t.Errorf("expected a duplicate DNN error, got %v", err)
+	}
+}
+
+func TestApplyDeviceGroupTemplate(t *testing.T) {
+	template := deviceGroupTemplate("template1")
+	overrides := []configmodels.DeviceGroupsIpDomainExpanded{{
+		Dnn:        "internet",
+		UeIpPool:   "10.1.0.0/16",
+		DnsPrimary: "9.9.9.9",
+		UeDnnQos:   &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{DnnMbrUplink: 5, DnnMbrDownlink: 5, BitrateUnit: "mbps"},
+	}}
+	ipDomains, err := applyDeviceGroupTemplate(&template, overrides)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := template.IpDomainsExpanded[0]
+	expected.UeIpPool = "10.1.0.0/16"
+	expected.DnsPrimary = "9.9.9.9"
+	expected.UeDnnQos = overrides[0].UeDnnQos
+	if !reflect.DeepEqual(ipDomains, []configmodels.DeviceGroupsIpDomainExpanded{expected}) {
+		t.Errorf("expected IP domains %+v, got %+v", expected, ipDomains)
+	}
+	if ipDomains[0].UeDnnQos == overrides[0].UeDnnQos {
+		t.Error("expected the QoS of the override to be copied")
+	}
+
+	ipDomains, err = applyDeviceGroupTemplate(&template, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	convertUeDnnQosToBps(ipDomains[0].UeDnnQos)
+	if template.IpDomainsExpanded[0].UeDnnQos.DnnMbrUplink != 10000000 {
+		t.Errorf("expected the QoS of the template to be left unchanged, got %+v", template.IpDomainsExpanded[0].UeDnnQos)
+	}
+
+	_, err = applyDeviceGroupTemplate(&template, []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "ims"}})
+	if err == nil || !strings.Contains(err.Error(), "not part of template") {
+		t.Errorf("expected an unknown DNN error, got %v", err)
+	}
+}
+
+func TestDeviceGroupTemplateInstantiate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		templateName string
+		groupName    string
+		query        string
+		overrides    []configmodels.DeviceGroupsIpDomainExpanded
+		expectedCode int
+	}{
+		{name: "Device group created", templateName: "template1", groupName: "group2", overrides: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet", UeIpPool: "10.2.0.0/16"}}, expectedCode: http.StatusOK},
+		{name: "Dry run", templateName: "template1", groupName: "group2", query: "?dryRun=true", overrides: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet", UeIpPool: "10.2.0.0/16"}}, expectedCode: http.StatusOK},
+		{name: "Invalid dry run", templateName: "template1", groupName: "group2", query: "?dryRun=maybe", overrides: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet", UeIpPool: "10.2.0.0/16"}}, expectedCode: http.StatusBadRequest},
+		{name: "Missing UE IP pool", templateName: "template1", groupName: "group2", expectedCode: http.StatusBadRequest},
+		{name: "Unknown DNN", templateName: "template1", groupName: "group2", overrides: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "ims"}}, expectedCode: http.StatusBadRequest},
+		{name: "Unknown template", templateName: "template2", groupName: "group2", expectedCode: http.StatusNotFound},
+		{name: "Existing device group", templateName: "template1", groupName: "group1", expectedCode: http.StatusConflict},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			template := deviceGroupTemplate("template1")
+			mock := &DeviceGroupTemplateMockDBClient{
+				templates:    []configmodels.DeviceGroupTemplate{template},
+				deviceGroups: []configmodels.DeviceGroups{derivedDeviceGroup(t, "group1", template, "10.1.0.0/16")},
+			}
+			dbadapter.CommonDBClient = mock
+
+			body, err := json.Marshal(configmodels.DeviceGroupInstantiation{Imsis: []string{"208930100007490"}, IpDomainOverrides: tc.overrides})
+			if err != nil {
+				t.Fatalf("failed to marshal instantiation: %v", err)
+			}
+			url := "/config/v1/device-group-template/" + tc.templateName + "/device-group/" + tc.groupName + tc.query
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedCode != http.StatusOK || tc.query != "" {
+				if len(mock.storedGroups) != 0 {
+					t.Errorf("expected no device group to be stored, got %+v", mock.storedGroups)
+				}
+				return
+			}
+			if len(mock.storedGroups) != 1 {
+				t.Fatalf("expected the device group to be stored once, got %d", len(mock.storedGroups))
+			}
+			stored := mock.storedGroups[0]
+			if stored.Template == nil || stored.Template.Name != "template1" || !reflect.DeepEqual(stored.Template.IpDomainOverrides, tc.overrides) {
+				t.Errorf("expected a reference to template1 with the overrides, got %+v", stored.Template)
+			}
+			if stored.IpDomainsExpanded[0].UeIpPool != "10.2.0.0/16" || stored.IpDomainsExpanded[0].DnsPrimary != "1.1.1.1" {
+				t.Errorf("expected the IP domain of the template with the UE IP pool, got %+v", stored.IpDomainsExpanded[0])
+			}
+		})
+	}
+}
+
+func TestDeviceGroupTemplatePreviewAndPropagate(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	template := deviceGroupTemplate("template1")
+	unrelated := deviceGroup("group3")
+	mock := &DeviceGroupTemplateMockDBClient{
+		deviceGroups: []configmodels.DeviceGroups{
+			derivedDeviceGroup(t, "group1", template, "10.1.0.0/16"),
+			derivedDeviceGroup(t, "group2", template, "10.2.0.0/16"),
+			unrelated,
+		},
+	}
+	template.IpDomainsExpanded[0].DnsPrimary = "9.9.9.9"
+	mock.templates = []configmodels.DeviceGroupTemplate{template}
+	dbadapter.CommonDBClient = mock
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	req, err := http.NewRequest(http.MethodGet, "/config/v1/device-group-template/template1/preview", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var previews []configmodels.DeviceGroupTemplatePreview
+	if err = json.Unmarshal(w.Body.Bytes(), &previews); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(previews) != 2 {
+		t.Fatalf("expected the previews of the 2 derived device groups, got %+v", previews)
+	}
+	for _, preview := range previews {
+		if !preview.Changed || preview.Updated[0].DnsPrimary != "9.9.9.9" || preview.Current[0].DnsPrimary != "1.1.1.1" {
+			t.Errorf("expected the DNS of %s to be changed, got %+v", preview.DeviceGroup, preview)
+		}
+		if preview.Updated[0].UeDnnQos.DnnMbrUplink != preview.Current[0].UeDnnQos.DnnMbrUplink {
+			t.Errorf("expected the bitrates of %s to be unchanged, got %+v", preview.DeviceGroup, preview.Updated[0].UeDnnQos)
+		}
+	}
+	if len(mock.storedGroups) != 0 {
+		t.Fatalf("expected the preview not to store device groups, got %+v", mock.storedGroups)
+	}
+
+	req, err = http.NewRequest(http.MethodPost, "/config/v1/device-group-template/template1/propagate?dryRun=true", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var report configmodels.DryRunReport
+	if err = json.Unmarshal(w.Body.Bytes(), &report); err != nil || report.SubscriberChanges == nil {
+		t.Fatalf("expected a dry run report, got %s", w.Body.String())
+	}
+	if len(mock.storedGroups) != 0 {
+		t.Fatalf("expected the dry run not to store device groups, got %+v", mock.storedGroups)
+	}
+
+	req, err = http.NewRequest(http.MethodPost, "/config/v1/device-group-template/template1/propagate", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(mock.storedGroups) != 2 {
+		t.Fatalf("expected the 2 derived device groups to be stored, got %+v", mock.storedGroups)
+	}
+	for i, stored := range mock.storedGroups {
+		expected := mock.deviceGroups[i]
+		expected.IpDomainsExpanded[0].DnsPrimary = "9.9.9.9"
+		if !reflect.DeepEqual(stored.IpDomainsExpanded, expected.IpDomainsExpanded) || stored.Template.Name != "template1" {
+			t.Errorf("expected device group %+v, got %+v", expected, stored)
+		}
+	}
+}
+
+func TestDeviceGroupTemplateDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		deviceGroups []configmodels.DeviceGroups
+		expectedCode int
+	}{
+		{name: "Unused template", deviceGroups: []configmodels.DeviceGroups{deviceGroup("group1")}, expectedCode: http.StatusOK},
+		{name: "Template in use", deviceGroups: []configmodels.DeviceGroups{derivedDeviceGroup(t, "group1", deviceGroupTemplate("template1"), "10.1.0.0/16")}, expectedCode: http.StatusConflict},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &DeviceGroupTemplateMockDBClient{
+				templates:    []configmodels.DeviceGroupTemplate{deviceGroupTemplate("template1")},
+				deviceGroups: tc.deviceGroups,
+			}
+			dbadapter.CommonDBClient = mock
+
+			req, err := http.NewRequest(http.MethodDelete, "/config/v1/device-group-template/template1", nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if deleted := len(mock.deletedColls) > 0; deleted != (tc.expectedCode == http.StatusOK) {
+				t.Errorf("expected the template to be deleted: %t, got %v", tc.expectedCode == http.StatusOK, mock.deletedColls)
+			}
+		})
+	}
+}
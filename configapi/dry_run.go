@@ -0,0 +1,525 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const dryRunParam = "dryRun"
+
+// NfConfigRenderer returns the configuration every nfconfig endpoint would serve for
+// networkSlices and deviceGroups, keyed by endpoint
+type NfConfigRenderer func(networkSlices []configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) map[string]any
+
+var nfConfigRenderer NfConfigRenderer
+
+// SetNfConfigRenderer sets the renderer computing the nfconfig changes of a dry run. No
+// nfconfig change is reported until it is set.
+func SetNfConfigRenderer(renderer NfConfigRenderer) {
+	nfConfigRenderer = renderer
+}
+
+// dryRunRequested returns the value of the dryRun query parameter, false when it is not set
+func dryRunRequested(c *gin.Context) (bool, error) {
+	value := c.Query(dryRunParam)
+	if value == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: expected true or false", dryRunParam, value)
+	}
+	return dryRun, nil
+}
+
+// dryRunState is the set of network slices and device groups a dry run compares before
+// and after the change
+type dryRunState struct {
+	networkSlices []configmodels.Slice
+	deviceGroups  map[string]configmodels.DeviceGroups
+}
+
+func loadDryRunState() (dryRunState, error) {
+	state := dryRunState{deviceGroups: make(map[string]configmodels.DeviceGroups)}
+	for _, slice := range getSlices() {
+		state.networkSlices = append(state.networkSlices, *slice)
+	}
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		return dryRunState{}, err
+	}
+	for _, devGroup := range devGroups {
+		state.deviceGroups[devGroup.DeviceGroupName] = devGroup
+	}
+	return state, nil
+}
+
+func (state dryRunState) getSlice(name string) *configmodels.Slice {
+	i := slices.IndexFunc(state.networkSlices, func(s configmodels.Slice) bool {
+		return s.SliceName == name
+	})
+	if i < 0 {
+		return nil
+	}
+	return &state.networkSlices[i]
+}
+
+// withSlice returns a copy of state where slice is created or replaced
+func (state dryRunState) withSlice(slice configmodels.Slice) dryRunState {
+	networkSlices := slices.Clone(state.networkSlices)
+	i := slices.IndexFunc(networkSlices, func(s configmodels.Slice) bool {
+		return s.SliceName == slice.SliceName
+	})
+	if i < 0 {
+		networkSlices = append(networkSlices, slice)
+	} else {
+		networkSlices[i] = slice
+	}
+	return dryRunState{networkSlices: networkSlices, deviceGroups: state.deviceGroups}
+}
+
+// withoutSlice returns a copy of state where the network slice sliceName is deleted
+func (state dryRunState) withoutSlice(sliceName string) dryRunState {
+	networkSlices := slices.DeleteFunc(slices.Clone(state.networkSlices), func(s configmodels.Slice) bool {
+		return s.SliceName == sliceName
+	})
+	return dryRunState{networkSlices: networkSlices, deviceGroups: state.deviceGroups}
+}
+
+// withDeviceGroup returns a copy of state where devGroup is created or replaced
+func (state dryRunState) withDeviceGroup(devGroup configmodels.DeviceGroups) dryRunState {
+	deviceGroups := maps.Clone(state.deviceGroups)
+	deviceGroups[devGroup.DeviceGroupName] = devGroup
+	return dryRunState{networkSlices: state.networkSlices, deviceGroups: deviceGroups}
+}
+
+// withoutDeviceGroup returns a copy of state where the device group groupName is deleted
+// and removed from its network slices
+func (state dryRunState) withoutDeviceGroup(groupName string) dryRunState {
+	deviceGroups := maps.Clone(state.deviceGroups)
+	delete(deviceGroups, groupName)
+	networkSlices := slices.Clone(state.networkSlices)
+	for i := range networkSlices {
+		networkSlices[i].SiteDeviceGroup = slices.DeleteFunc(slices.Clone(networkSlices[i].SiteDeviceGroup), func(name string) bool {
+			return name == groupName
+		})
+	}
+	return dryRunState{networkSlices: networkSlices, deviceGroups: deviceGroups}
+}
+
+// withUpdatedSlices returns a copy of state where update is applied to the network slices
+// matching filter, and the names of these network slices
+func (state dryRunState) withUpdatedSlices(filter func(*configmodels.Slice) bool, update func(*configmodels.Slice)) (dryRunState, []string) {
+	networkSlices := slices.Clone(state.networkSlices)
+	var updated []string
+	for i := range networkSlices {
+		slice := &networkSlices[i]
+		if !filter(slice) {
+			continue
+		}
+		slice.SiteInfo.GNodeBs = slices.Clone(slice.SiteInfo.GNodeBs)
+		if slice.SiteInfo.Upf != nil {
+			upf := *slice.SiteInfo.Upf
+			slice.SiteInfo.Upf = &upf
+		}
+		slice.SiteInfo.Upfs = slices.Clone(slice.SiteInfo.Upfs)
+		update(slice)
+		updated = append(updated, slice.SliceName)
+	}
+	return dryRunState{networkSlices: networkSlices, deviceGroups: state.deviceGroups}, updated
+}
+
+// slicesOf returns the network slices the device group groupName belongs to, sorted by name
+func (state dryRunState) slicesOf(groupName string) []*configmodels.Slice {
+	var networkSlices []*configmodels.Slice
+	for i := range state.networkSlices {
+		if slices.Contains(state.networkSlices[i].SiteDeviceGroup, groupName) {
+			networkSlices = append(networkSlices, &state.networkSlices[i])
+		}
+	}
+	slices.SortFunc(networkSlices, func(a, b *configmodels.Slice) int {
+		return strings.Compare(a.SliceName, b.SliceName)
+	})
+	return networkSlices
+}
+
+// subscriberDocuments builds the policy and provisioned data of imsi for the first device
+// group, by name, it belongs to that has network slices. There is none if imsi has no
+// authentication subscription.
+func (state dryRunState) subscriberDocuments(imsi string) ([]subscriberDocument, error) {
+	groupNames := slices.Sorted(maps.Keys(state.deviceGroups))
+	for _, groupName := range groupNames {
+		devGroup := state.deviceGroups[groupName]
+		networkSlices := state.slicesOf(groupName)
+		if !devGroup.ContainsImsi(imsi) || len(networkSlices) == 0 {
+			continue
+		}
+		if subscriberAuthenticationDataGet("imsi-"+imsi) == nil {
+			return nil, nil
+		}
+		subscriberData, err := newDeviceGroupSubscriberData(&devGroup, networkSlices)
+		if err != nil {
+			return nil, err
+		}
+		return subscriberDataDocuments(imsi, devGroup.ImsiMsisdns[imsi], subscriberData.slices, subscriberData.dnnMap,
+			subscriberData.sessionTypes, subscriberData.aggregatedQoS)
+	}
+	return nil, nil
+}
+
+// computeDryRunReport compares the subscriber data of the device groups groupNames, and
+// the configuration of every nfconfig endpoint, between current and updated
+func computeDryRunReport(current, updated dryRunState, groupNames []string) (*configmodels.DryRunReport, error) {
+	imsis := make(map[string]bool)
+	for _, state := range []dryRunState{current, updated} {
+		for _, groupName := range groupNames {
+			devGroup, ok := state.deviceGroups[groupName]
+			if !ok {
+				continue
+			}
+			rangeImsis, err := provisionedRangeImsis(&devGroup)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch the subscribers of the IMSI ranges of %s: %w", groupName, err)
+			}
+			for _, imsi := range slices.Concat(devGroup.Imsis, rangeImsis) {
+				imsis[imsi] = true
+			}
+		}
+	}
+	return computeImsisDryRunReport(current, updated, slices.Sorted(maps.Keys(imsis)))
+}
+
+// computeImsisDryRunReport compares the subscriber data of imsis, and the configuration of
+// every nfconfig endpoint, between current and updated
+func computeImsisDryRunReport(current, updated dryRunState, imsis []string) (*configmodels.DryRunReport, error) {
+	report := &configmodels.DryRunReport{
+		SubscriberChanges: []configmodels.DryRunSubscriberChange{},
+		NfConfigChanges:   []configmodels.DryRunNfConfigChange{},
+	}
+	for _, imsi := range imsis {
+		desired, err := updated.subscriberDocuments(imsi)
+		if err != nil {
+			return nil, err
+		}
+		stored, err := storedSubscriberDocuments(imsi)
+		if err != nil {
+			return nil, err
+		}
+		report.SubscriberChanges = append(report.SubscriberChanges, diffSubscriberDocuments(stored, desired)...)
+	}
+	if nfConfigRenderer != nil {
+		currentConfig := nfConfigRenderer(current.networkSlices, current.deviceGroups)
+		updatedConfig := nfConfigRenderer(updated.networkSlices, updated.deviceGroups)
+		for _, endpoint := range slices.Sorted(maps.Keys(updatedConfig)) {
+			if reflect.DeepEqual(normalizeJSON(currentConfig[endpoint]), normalizeJSON(updatedConfig[endpoint])) {
+				continue
+			}
+			report.NfConfigChanges = append(report.NfConfigChanges, configmodels.DryRunNfConfigChange{
+				Endpoint: endpoint,
+				Current:  currentConfig[endpoint],
+				Updated:  updatedConfig[endpoint],
+			})
+		}
+	}
+	logger.ConfigLog.Infof("dry run: %d subscriber documents and %d nfconfig endpoints would change",
+		len(report.SubscriberChanges), len(report.NfConfigChanges))
+	return report, nil
+}
+
+// storedSubscriberDocuments returns the stored policy and provisioned data of imsi
+func storedSubscriberDocuments(imsi string) ([]subscriberDocument, error) {
+	var docs []subscriberDocument
+	for _, collName := range []string{amPolicyDataColl, smPolicyDataColl, amDataColl, smDataColl, smfSelDataColl} {
+		filter := bson.M{"ueId": "imsi-" + imsi}
+		rawDocs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(collName, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s of IMSI %s: %w", collName, imsi, err)
+		}
+		for _, rawDoc := range rawDocs {
+			docs = append(docs, subscriberDocument{collName: collName, filter: filter, data: rawDoc})
+		}
+	}
+	return docs, nil
+}
+
+// diffSubscriberDocuments returns the changes writing desired makes to stored. The stored
+// documents are matched by serving PLMN and S-NSSAI, the AM data created with the
+// subscriber being replaced by its AM data for a PLMN.
+func diffSubscriberDocuments(stored, desired []subscriberDocument) []configmodels.DryRunSubscriberChange {
+	storedData := make([]map[string]interface{}, len(stored))
+	for i, doc := range stored {
+		storedData[i] = normalizeDocument(doc.data)
+	}
+	matched := make([]bool, len(stored))
+	var changes []configmodels.DryRunSubscriberChange
+	for _, doc := range desired {
+		data := normalizeDocument(doc.data)
+		i := -1
+		for j := range stored {
+			if !matched[j] && stored[j].collName == doc.collName && subscriberDocumentKey(storedData[j]) == subscriberDocumentKey(data) {
+				i = j
+				break
+			}
+		}
+		if i < 0 && doc.collName == amDataColl {
+			for j := range stored {
+				if !matched[j] && stored[j].collName == amDataColl && storedData[j]["servingPlmnId"] == nil {
+					i = j
+					break
+				}
+			}
+		}
+		change := configmodels.DryRunSubscriberChange{
+			Collection: doc.collName,
+			UeId:       fmt.Sprint(data["ueId"]),
+			Updated:    data,
+		}
+		if i < 0 {
+			change.Operation = configmodels.DryRunCreate
+			changes = append(changes, change)
+			continue
+		}
+		matched[i] = true
+		if reflect.DeepEqual(storedData[i], data) {
+			continue
+		}
+		change.Operation = configmodels.DryRunUpdate
+		change.Current = storedData[i]
+		changes = append(changes, change)
+	}
+	for i, doc := range stored {
+		if matched[i] || (doc.collName == amDataColl && storedData[i]["servingPlmnId"] == nil) {
+			continue
+		}
+		changes = append(changes, configmodels.DryRunSubscriberChange{
+			Collection: doc.collName,
+			UeId:       fmt.Sprint(storedData[i]["ueId"]),
+			Operation:  configmodels.DryRunDelete,
+			Current:    storedData[i],
+		})
+	}
+	return changes
+}
+
+// subscriberDocumentKey identifies a subscriber document of a collection by its serving
+// PLMN and S-NSSAI, when it has them
+func subscriberDocumentKey(data map[string]interface{}) string {
+	return fmt.Sprint(data["servingPlmnId"], "/", data["singlenssai"])
+}
+
+// normalizeDocument returns the JSON representation of a stored or built document, so that
+// documents can be compared regardless of the types of their values
+func normalizeDocument(data map[string]interface{}) map[string]interface{} {
+	normalized, _ := normalizeJSON(data).(map[string]interface{})
+	delete(normalized, "_id")
+	return normalized
+}
+
+func normalizeJSON(value any) any {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		logger.ConfigLog.Warnf("could not marshal %+v: %+v", value, err)
+		return value
+	}
+	var normalized any
+	if err = json.Unmarshal(encoded, &normalized); err != nil {
+		logger.ConfigLog.Warnf("could not unmarshal %s: %+v", encoded, err)
+		return value
+	}
+	return normalized
+}
+
+// networkSliceDryRun computes the effect of storing slice without storing it
+func networkSliceDryRun(slice configmodels.Slice) (*configmodels.DryRunReport, int, error) {
+	if _, err := newSubscriberSlice(&slice); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	groupNames := slices.Clone(slice.SiteDeviceGroup)
+	if prevSlice := current.getSlice(slice.SliceName); prevSlice != nil {
+		groupNames = append(groupNames, prevSlice.SiteDeviceGroup...)
+	}
+	report, err := computeDryRunReport(current, current.withSlice(slice), groupNames)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return report, http.StatusOK, nil
+}
+
+// networkSliceDeleteDryRun computes the effect of deleting the network slice sliceName
+// without deleting it
+func networkSliceDeleteDryRun(sliceName string) (*configmodels.DryRunReport, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, err
+	}
+	var groupNames []string
+	if prevSlice := current.getSlice(sliceName); prevSlice != nil {
+		groupNames = prevSlice.SiteDeviceGroup
+	}
+	return computeDryRunReport(current, current.withoutSlice(sliceName), groupNames)
+}
+
+// deviceGroupDryRun validates requestDeviceGroup and computes the effect of storing it
+// without storing it
+func deviceGroupDryRun(requestDeviceGroup configmodels.DeviceGroups, groupName string, allowDuplicates bool) (*configmodels.DryRunReport, int, error) {
+	conflicts, statusCode, err := validateDeviceGroup(&requestDeviceGroup, groupName, allowDuplicates)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	report, err := computeDryRunReport(current, current.withDeviceGroup(requestDeviceGroup), []string{groupName})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	report.ImsiConflicts = conflicts
+	return report, http.StatusOK, nil
+}
+
+// deviceGroupDeleteDryRun computes the effect of deleting the device group groupName
+// without deleting it
+func deviceGroupDeleteDryRun(groupName string) (*configmodels.DryRunReport, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, err
+	}
+	return computeDryRunReport(current, current.withoutDeviceGroup(groupName), []string{groupName})
+}
+
+// deviceGroupMemberAddDryRun computes the effect of adding member to the device group
+// groupName without adding it. As when it is added, only the data of its IMSI is compared.
+func deviceGroupMemberAddDryRun(groupName string, member configmodels.DeviceGroupMember, allowDuplicates bool) (*configmodels.DryRunReport, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	devGroup, conflicts, err := deviceGroupWithMember(groupName, member, allowDuplicates)
+	if err != nil {
+		return nil, err
+	}
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, err
+	}
+	report, err := computeImsisDryRunReport(current, current.withDeviceGroup(*devGroup), []string{member.Imsi})
+	if err != nil {
+		return nil, err
+	}
+	report.ImsiConflicts = conflicts
+	return report, nil
+}
+
+// deviceGroupMemberRemoveDryRun computes the effect of removing imsi from the device group
+// groupName without removing it. As when it is removed, only the data of imsi is compared.
+func deviceGroupMemberRemoveDryRun(groupName string, imsi string) (*configmodels.DryRunReport, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	devGroup, err := deviceGroupWithoutMember(groupName, imsi)
+	if err != nil {
+		return nil, err
+	}
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, err
+	}
+	return computeImsisDryRunReport(current, current.withDeviceGroup(*devGroup), []string{imsi})
+}
+
+// deviceGroupTemplatePropagateDryRun validates the device groups derived from the template
+// named templateName, as propagating it would change them, and computes the effect of
+// storing them without storing them. The previews report the device groups which could
+// not be updated.
+func deviceGroupTemplatePropagateDryRun(templateName string) (*configmodels.DryRunReport, []configmodels.DeviceGroupTemplatePreview, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	template, err := fetchDeviceGroupTemplate(templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if template == nil {
+		return nil, nil, errDeviceGroupTemplateNotFound
+	}
+	devGroups, err := derivedDeviceGroups(templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, nil, err
+	}
+	previews, changes := previewDeviceGroupTemplate(template, devGroups)
+	updated := current
+	var groupNames, failedGroups []string
+	for i := range previews {
+		preview := &previews[i]
+		if preview.Error != "" {
+			failedGroups = append(failedGroups, preview.DeviceGroup)
+			continue
+		}
+		if !preview.Changed {
+			continue
+		}
+		devGroup := devGroups[i]
+		devGroup.IpDomainsExpanded = changes[devGroup.DeviceGroupName]
+		// the IMSIs were accepted when the device group was stored
+		if _, _, err = validateDeviceGroup(&devGroup, devGroup.DeviceGroupName, true); err != nil {
+			preview.Error = err.Error()
+			failedGroups = append(failedGroups, preview.DeviceGroup)
+			continue
+		}
+		updated = updated.withDeviceGroup(devGroup)
+		groupNames = append(groupNames, devGroup.DeviceGroupName)
+	}
+	if len(failedGroups) > 0 {
+		return nil, previews, fmt.Errorf("device groups %s cannot be updated", strings.Join(failedGroups, ", "))
+	}
+	report, err := computeDryRunReport(current, updated, groupNames)
+	if err != nil {
+		return nil, previews, err
+	}
+	return report, previews, nil
+}
+
+// inventoryDryRun computes the effect of applying update to the network slices matching
+// filter, as an inventory change does, without applying it
+func inventoryDryRun(filter func(*configmodels.Slice) bool, update func(*configmodels.Slice)) (*configmodels.DryRunReport, error) {
+	rwLock.RLock()
+	defer rwLock.RUnlock()
+	current, err := loadDryRunState()
+	if err != nil {
+		return nil, err
+	}
+	updated, sliceNames := current.withUpdatedSlices(filter, update)
+	var groupNames []string
+	for _, sliceName := range sliceNames {
+		groupNames = append(groupNames, updated.getSlice(sliceName).SiteDeviceGroup...)
+	}
+	return computeDryRunReport(current, updated, groupNames)
+}
@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type DryRunMockDBClient struct {
+	dbadapter.DBInterface
+	slices         []configmodels.Slice
+	deviceGroups   []configmodels.DeviceGroups
+	subscriberDocs map[string][]map[string]any
+	writtenColls   []string
+}
+
+func (db *DryRunMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	switch coll {
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			if slice.SliceName == filter["slice-name"] {
+				return configmodels.ToBsonM(slice), nil
+			}
+		}
+	case devGroupDataColl:
+		for _, devGroup := range db.deviceGroups {
+			if devGroup.DeviceGroupName == filter["group-name"] {
+				return configmodels.ToBsonM(devGroup), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *DryRunMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	switch coll {
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	case devGroupDataColl:
+		for _, devGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(devGroup))
+		}
+	default:
+		for _, doc := range db.subscriberDocs[coll] {
+			if doc["ueId"] == filter["ueId"] {
+				results = append(results, doc)
+			}
+		}
+	}
+	return results, nil
+}
+
+func (db *DryRunMockDBClient) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	if coll == devGroupDataColl {
+		for _, devGroup := range db.deviceGroups {
+			if devGroup.DeviceGroupName == filter["group-name"] {
+				return 1, nil
+			}
+		}
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (db *DryRunMockDBClient) RestfulAPIPost(coll string, filter bson.M, postData map[string]any) (bool, error) {
+	db.writtenColls = append(db.writtenColls, coll)
+	return true, nil
+}
+
+func (db *DryRunMockDBClient) RestfulAPIPutOne(coll string, filter bson.M, putData map[string]any) (bool, error) {
+	db.writtenColls = append(db.writtenColls, coll)
+	return true, nil
+}
+
+func (db *DryRunMockDBClient) RestfulAPIDeleteOne(coll string, filter bson.M) error {
+	db.writtenColls = append(db.writtenColls, coll)
+	return nil
+}
+
+func TestDryRunRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		query       string
+		expected    bool
+		expectedErr bool
+	}{
+		{query: "", expected: false},
+		{query: "?dryRun=true", expected: true},
+		{query: "?dryRun=false", expected: false},
+		{query: "?dryRun=maybe", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/config/v1/network-slice/slice1"+tc.query, nil)
+			dryRun, err := dryRunRequested(c)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if dryRun != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, dryRun)
+			}
+		})
+	}
+}
+
+func TestDiffSubscriberDocuments(t *testing.T) {
+	ueId := "imsi-208930100007487"
+	baseAmData := subscriberDocument{collName: amDataColl, data: map[string]interface{}{"ueId": ueId, "gpsis": []string{"msisdn-1"}}}
+	amData := subscriberDocument{collName: amDataColl, data: map[string]interface{}{"ueId": ueId, "servingPlmnId": "20893", "gpsis": []string{"msisdn-1"}}}
+	smData := subscriberDocument{collName: smDataColl, data: map[string]interface{}{"ueId": ueId, "servingPlmnId": "20893", "singlenssai": map[string]any{"sst": 1, "sd": "010203"}}}
+	staleSmData := subscriberDocument{collName: smDataColl, data: map[string]interface{}{"ueId": ueId, "servingPlmnId": "20893", "singlenssai": map[string]any{"sst": 2, "sd": "010203"}}}
+	amPolicyData := subscriberDocument{collName: amPolicyDataColl, data: map[string]interface{}{"ueId": ueId, "subscCats": []string{"aether"}}}
+
+	changes := diffSubscriberDocuments(
+		[]subscriberDocument{baseAmData, staleSmData, amPolicyData},
+		[]subscriberDocument{amPolicyData, amData, smData},
+	)
+
+	expected := []struct {
+		collection string
+		operation  string
+	}{
+		{collection: amDataColl, operation: configmodels.DryRunUpdate},
+		{collection: smDataColl, operation: configmodels.DryRunCreate},
+		{collection: smDataColl, operation: configmodels.DryRunDelete},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %+v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if change.Collection != expected[i].collection || change.Operation != expected[i].operation || change.UeId != ueId {
+			t.Errorf("expected %s of %s, got %+v", expected[i].operation, expected[i].collection, change)
+		}
+	}
+}
+
+func TestNetworkSlicePutDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	originalDBClient := dbadapter.CommonDBClient
+	originalAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = originalDBClient
+		dbadapter.AuthDBClient = originalAuthDBClient
+		SetNfConfigRenderer(nil)
+	}()
+	devGroup := deviceGroupWithImsis("group1", []string{"208930100007487"})
+	mock := &DryRunMockDBClient{
+		deviceGroups: []configmodels.DeviceGroups{devGroup},
+		subscriberDocs: map[string][]map[string]any{
+			amDataColl: {{"ueId": "imsi-208930100007487"}},
+		},
+	}
+	dbadapter.CommonDBClient = mock
+	dbadapter.AuthDBClient = &AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}
+	SetNfConfigRenderer(func(networkSlices []configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) map[string]any {
+		return map[string]any{"/nfconfig/plmn-snssai": len(networkSlices)}
+	})
+
+	slice := networkSlice("slice1")
+	slice.SiteDeviceGroup = []string{"group1"}
+	body, err := json.Marshal(slice)
+	if err != nil {
+		t.Fatalf("failed to marshal network slice: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, "/config/v1/network-slice/slice1?dryRun=true", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(mock.writtenColls) != 0 {
+		t.Errorf("expected nothing to be written, got writes to %v", mock.writtenColls)
+	}
+
+	var report configmodels.DryRunReport
+	if err = json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	operations := make(map[string]string)
+	for _, change := range report.SubscriberChanges {
+		operations[change.Collection] = change.Operation
+	}
+	expectedOperations := map[string]string{
+		amPolicyDataColl: configmodels.DryRunCreate,
+		smPolicyDataColl: configmodels.DryRunCreate,
+		amDataColl:       configmodels.DryRunUpdate,
+		smDataColl:       configmodels.DryRunCreate,
+		smfSelDataColl:   configmodels.DryRunCreate,
+	}
+	if len(operations) != len(expectedOperations) {
+		t.Fatalf("expected changes to %d collections, got %+v", len(expectedOperations), report.SubscriberChanges)
+	}
+	for coll, operation := range expectedOperations {
+		if operations[coll] != operation {
+			t.Errorf("expected %s of %s, got %q", operation, coll, operations[coll])
+		}
+	}
+	if len(report.NfConfigChanges) != 1 || report.NfConfigChanges[0].Endpoint != "/nfconfig/plmn-snssai" {
+		t.Errorf("expected the plmn-snssai configuration to change, got %+v", report.NfConfigChanges)
+	}
+}
+
+func TestDeviceGroupMemberDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name              string
+		method            string
+		url               string
+		body              string
+		expectedUeId      string
+		expectedOperation string
+	}{
+		{
+			name:              "Member added",
+			method:            http.MethodPost,
+			url:               "/config/v1/device-group/group1/imsis?dryRun=true",
+			body:              `{"imsi": "208930100007489"}`,
+			expectedUeId:      "imsi-208930100007489",
+			expectedOperation: configmodels.DryRunCreate,
+		},
+		{
+			name:              "Member removed",
+			method:            http.MethodDelete,
+			url:               "/config/v1/device-group/group1/imsis/208930100007487?dryRun=true",
+			expectedUeId:      "imsi-208930100007487",
+			expectedOperation: configmodels.DryRunDelete,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			originalAuthDBClient := dbadapter.AuthDBClient
+			defer func() {
+				dbadapter.CommonDBClient = originalDBClient
+				dbadapter.AuthDBClient = originalAuthDBClient
+			}()
+			slice := networkSlice("slice1")
+			slice.SiteDeviceGroup = []string{"group1"}
+			mock := &DryRunMockDBClient{
+				slices:       []configmodels.Slice{slice},
+				deviceGroups: []configmodels.DeviceGroups{deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})},
+				subscriberDocs: map[string][]map[string]any{
+					smDataColl: {{"ueId": "imsi-208930100007487", "servingPlmnId": "20893", "singlenssai": map[string]any{"sst": 1, "sd": "010203"}}},
+				},
+			}
+			dbadapter.CommonDBClient = mock
+			dbadapter.AuthDBClient = &AuthDBMockDBClient{subscribers: []string{"imsi-208930100007489"}}
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewReader([]byte(tc.body)))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if len(mock.writtenColls) != 0 {
+				t.Errorf("expected nothing to be written, got writes to %v", mock.writtenColls)
+			}
+			var report configmodels.DryRunReport
+			if err = json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to unmarshal report: %v", err)
+			}
+			if len(report.SubscriberChanges) == 0 {
+				t.Fatalf("expected subscriber changes, got none")
+			}
+			for _, change := range report.SubscriberChanges {
+				if change.UeId != tc.expectedUeId || change.Operation != tc.expectedOperation {
+					t.Errorf("expected %s of the documents of %s, got %+v", tc.expectedOperation, tc.expectedUeId, change)
+				}
+			}
+		})
+	}
+}
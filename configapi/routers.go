@@ -55,6 +55,8 @@ func addRoutes(group *gin.RouterGroup, routes Routes) {
 			group.POST(route.Pattern, route.HandlerFunc)
 		case http.MethodPut:
 			group.PUT(route.Pattern, route.HandlerFunc)
+		case http.MethodPatch:
+			group.PATCH(route.Pattern, route.HandlerFunc)
 		case http.MethodDelete:
 			group.DELETE(route.Pattern, route.HandlerFunc)
 		}
@@ -109,6 +111,118 @@ var routes = Routes{
 		DeviceGroupGroupNamePost,
 	},
 
+	{
+		"DeviceGroupMemberPost",
+		http.MethodPost,
+		"/device-group/:group-name/imsis",
+		DeviceGroupMemberPost,
+	},
+
+	{
+		"DeviceGroupMemberDelete",
+		http.MethodDelete,
+		"/device-group/:group-name/imsis/:imsi",
+		DeviceGroupMemberDelete,
+	},
+
+	{
+		"GetDeviceGroupTemplates",
+		http.MethodGet,
+		"/device-group-template",
+		GetDeviceGroupTemplates,
+	},
+
+	{
+		"GetDeviceGroupTemplateByName",
+		http.MethodGet,
+		"/device-group-template/:template-name",
+		GetDeviceGroupTemplateByName,
+	},
+
+	{
+		"DeviceGroupTemplatePost",
+		http.MethodPost,
+		"/device-group-template/:template-name",
+		DeviceGroupTemplatePost,
+	},
+
+	{
+		"DeviceGroupTemplatePut",
+		http.MethodPut,
+		"/device-group-template/:template-name",
+		DeviceGroupTemplatePut,
+	},
+
+	{
+		"DeviceGroupTemplateDelete",
+		http.MethodDelete,
+		"/device-group-template/:template-name",
+		DeviceGroupTemplateDelete,
+	},
+
+	{
+		"DeviceGroupTemplateInstantiate",
+		http.MethodPost,
+		"/device-group-template/:template-name/device-group/:group-name",
+		DeviceGroupTemplateInstantiate,
+	},
+
+	{
+		"GetDeviceGroupTemplatePreview",
+		http.MethodGet,
+		"/device-group-template/:template-name/preview",
+		GetDeviceGroupTemplatePreview,
+	},
+
+	{
+		"DeviceGroupTemplatePropagate",
+		http.MethodPost,
+		"/device-group-template/:template-name/propagate",
+		DeviceGroupTemplatePropagate,
+	},
+
+	{
+		"GetTrafficClasses",
+		http.MethodGet,
+		"/traffic-class",
+		GetTrafficClasses,
+	},
+
+	{
+		"GetTrafficClassByName",
+		http.MethodGet,
+		"/traffic-class/:traffic-class-name",
+		GetTrafficClassByName,
+	},
+
+	{
+		"TrafficClassPost",
+		http.MethodPost,
+		"/traffic-class/:traffic-class-name",
+		TrafficClassPost,
+	},
+
+	{
+		"TrafficClassPut",
+		http.MethodPut,
+		"/traffic-class/:traffic-class-name",
+		TrafficClassPut,
+	},
+
+	{
+		"TrafficClassDelete",
+		http.MethodDelete,
+		"/traffic-class/:traffic-class-name",
+		TrafficClassDelete,
+	},
+
+	{
+		"GetImsiConflicts",
+		http.MethodGet,
+		"/imsi-conflicts",
+		GetImsiConflicts,
+	},
+
 	{
 		"GetNetworkSlices",
 		http.MethodGet,
@@ -72,6 +72,48 @@ var apiRoutes = Routes{
 		PatchSubscriberByID,
 	},
 
+	{
+		"ResyncSubscriberSequenceNumber",
+		http.MethodPost,
+		"/subscriber/:ueId/sqn-resync",
+		ResyncSubscriberSequenceNumber,
+	},
+
+	{
+		"ImportSubscribers",
+		http.MethodPost,
+		"/subscriber-import",
+		ImportSubscribers,
+	},
+
+	{
+		"ExportSubscribers",
+		http.MethodGet,
+		"/subscriber-export",
+		ExportSubscribers,
+	},
+
+	{
+		"ReconcileSubscribers",
+		http.MethodPost,
+		"/subscriber-reconciliation",
+		ReconcileSubscribers,
+	},
+
+	{
+		"GetSubscriberReconciliationReport",
+		http.MethodGet,
+		"/subscriber-reconciliation",
+		GetSubscriberReconciliationReport,
+	},
+
+	{
+		"GetSubscriberByMsisdn",
+		http.MethodGet,
+		"/msisdn/:msisdn",
+		GetSubscriberByMsisdn,
+	},
+
 	{
 		"Registered UE Context",
 		http.MethodGet,
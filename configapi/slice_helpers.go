@@ -6,6 +6,7 @@ package configapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/omec-project/openapi/v2"
 	"github.com/omec-project/openapi/v2/models"
 	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
@@ -35,34 +37,54 @@ func networkSliceDeleteHelper(sliceName string) error {
 	return nil
 }
 
-func networkSlicePostHelper(c *gin.Context, sliceName string) (int, error) {
+// networkSlicePostHelper validates and stores the network slice of the request. With dryRun
+// the network slice is only validated, and the report of the effect storing it would have is
+// returned.
+func networkSlicePostHelper(c *gin.Context, sliceName string, dryRun bool) (*configmodels.DryRunReport, int, error) {
 	logger.ConfigLog.Infof("received slice: %s", sliceName)
-	requestSlice, err := parseAndValidateSliceRequest(c, sliceName)
+	requestSlice, err := parseSliceRequest(c)
 	if err != nil {
-		return http.StatusBadRequest, err
+		return nil, http.StatusBadRequest, err
+	}
+	requestSlice.SliceName = sliceName
+	if err = validateSlice(&requestSlice); err != nil {
+		var validationErr *sliceValidationError
+		if errors.As(err, &validationErr) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, err
 	}
 
 	logSliceMetadata(requestSlice)
 	normalizeApplicationFilteringRules(&requestSlice)
-	requestSlice.SliceName = sliceName
+	normalizeSliceQos(&requestSlice)
+	if err = checkSliceUeIpPoolOverlaps(requestSlice); err != nil {
+		if errors.Is(err, errUeIpPoolOverlap) {
+			return nil, http.StatusConflict, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	if dryRun {
+		return networkSliceDryRun(requestSlice)
+	}
 	prevSlice := getSliceByName(sliceName)
 
 	if prevSlice == nil {
 		logger.ConfigLog.Infof("Adding new slice [%s]", sliceName)
 		if statusCode, err := createNS(requestSlice); err != nil {
 			logger.ConfigLog.Errorf("Error creating slice %s: %+v", sliceName, err)
-			return statusCode, err
+			return nil, statusCode, err
 		}
 	} else {
 		if statusCode, err := updateNS(requestSlice, *prevSlice); err != nil {
 			logger.ConfigLog.Errorf("Error updating slice %s: %+v", sliceName, err)
-			return statusCode, err
+			return nil, statusCode, err
 		}
 	}
-	return http.StatusOK, nil
+	return nil, http.StatusOK, nil
 }
 
-func parseAndValidateSliceRequest(c *gin.Context, sliceName string) (configmodels.Slice, error) {
+func parseSliceRequest(c *gin.Context) (configmodels.Slice, error) {
 	var request configmodels.Slice
 
 	ct := strings.Split(c.GetHeader("Content-Type"), ";")[0]
@@ -74,22 +96,6 @@ func parseAndValidateSliceRequest(c *gin.Context, sliceName string) (configmodel
 		return request, fmt.Errorf("JSON bind error: %w", err)
 	}
 
-	for _, gnb := range request.SiteInfo.GNodeBs {
-		if !isValidName(gnb.Name) {
-			return request, fmt.Errorf("invalid gNB name `%s` in Network Slice %s", gnb.Name, sliceName)
-		}
-		if !isValidGnbTac(gnb.Tac) {
-			return request, fmt.Errorf("invalid TAC %d for gNB %s in Network Slice %s", gnb.Tac, gnb.Name, sliceName)
-		}
-	}
-
-	for _, ruleConfig := range request.ApplicationFilteringRules {
-		if ruleConfig.TrafficClass == nil {
-			logger.ConfigLog.Errorln("TrafficClass (QCI, ARP) required but not provided, network slice NOT configured in the network")
-			return request, fmt.Errorf("TrafficClass (QCI, ARP) required but not provided, network slice NOT configured in the network")
-		}
-	}
-
 	slices.Sort(request.SiteDeviceGroup)
 	request.SiteDeviceGroup = slices.Compact(request.SiteDeviceGroup)
 
@@ -109,7 +115,9 @@ func logSliceMetadata(slice configmodels.Slice) {
 	for i, gnb := range site.GNodeBs {
 		logger.ConfigLog.Infof("gNB (%d): name=%s, tac=%d", i+1, gnb.Name, gnb.Tac)
 	}
-	logger.ConfigLog.Infof("site UPF: %s", site.Upf)
+	for i, upf := range site.UpfList() {
+		logger.ConfigLog.Infof("UPF (%d): name=%s, port=%s", i+1, upf.UpfName, upf.UpfPort)
+	}
 }
 
 func normalizeApplicationFilteringRules(slice *configmodels.Slice) {
@@ -130,6 +138,23 @@ func normalizeApplicationFilteringRules(slice *configmodels.Slice) {
 	}
 }
 
+// normalizeSliceQos converts the session AMBR of the default QoS of slice to bps, capping it
+// to math.MaxInt64
+func normalizeSliceQos(slice *configmodels.Slice) {
+	if slice.Qos == nil {
+		return
+	}
+	slice.Qos.Uplink = convertToBps(slice.Qos.Uplink, slice.Qos.BitrateUnit)
+	if slice.Qos.Uplink < 0 {
+		slice.Qos.Uplink = math.MaxInt64
+	}
+	slice.Qos.Downlink = convertToBps(slice.Qos.Downlink, slice.Qos.BitrateUnit)
+	if slice.Qos.Downlink < 0 {
+		slice.Qos.Downlink = math.MaxInt64
+	}
+	logger.ConfigLog.Infof("default session AMBR uplink: %v, downlink: %v, traffic class: %v", slice.Qos.Uplink, slice.Qos.Downlink, slice.Qos.TrafficClass)
+}
+
 func convertBitrateToInt32(bitrate int64) int32 {
 	if bitrate < 0 || bitrate > math.MaxInt32 {
 		return math.MaxInt32
@@ -204,15 +229,10 @@ var syncSubscribersOnSliceCreateOrUpdate = func(slice configmodels.Slice, prevSl
 		logger.DbLog.Error(err)
 		return http.StatusBadRequest, err
 	}
-	sVal, err := strconv.ParseUint(slice.SliceId.Sst, 10, 32)
-	if err != nil {
+	if _, err := strconv.ParseUint(slice.SliceId.Sst, 10, 32); err != nil {
 		logger.DbLog.Errorf("could not parse SST %s", slice.SliceId.Sst)
 		return http.StatusBadRequest, err
 	}
-	snssai := models.NewSnssai(int32(sVal))
-	snssai.SetSd(slice.SliceId.Sd)
-	mcc := slice.SiteInfo.Plmn.Mcc
-	mnc := slice.SiteInfo.Plmn.Mnc
 	for _, dgName := range slice.SiteDeviceGroup {
 		logger.ConfigLog.Debugf("dgName: %s", dgName)
 		devGroupConfig := getDeviceGroupByName(dgName)
@@ -225,8 +245,15 @@ var syncSubscribersOnSliceCreateOrUpdate = func(slice configmodels.Slice, prevSl
 			logger.ConfigLog.Warnln("IPDomainExpanded is nil or empty for dgName:", dgName)
 			continue
 		}
-		_, err := processDeviceGroup(devGroupConfig, snssai, mcc, mnc)
+		// the device group is provisioned for all its network slices, not only this one
+		networkSlices := slices.DeleteFunc(findSlicesByDeviceGroup(dgName), func(s *configmodels.Slice) bool {
+			return s.SliceName == slice.SliceName
+		})
+		subscriberSlices, err := newSubscriberSlices(append(networkSlices, &slice))
 		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if _, err = processDeviceGroup(devGroupConfig, subscriberSlices); err != nil {
 			return http.StatusInternalServerError, err
 		}
 	}
@@ -236,22 +263,9 @@ var syncSubscribersOnSliceCreateOrUpdate = func(slice configmodels.Slice, prevSl
 	return http.StatusOK, nil
 }
 
-func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *models.Snssai, mcc, mnc string) (int, error) {
-	dnnMap := make(map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) // Stores multiple DNNs & their QoS per IMSI
-	for _, ipDomain := range devGroupConfig.IpDomainsExpanded {
-		dnn := ipDomain.Dnn
-
-		// Ensure UeDnnQos is not nil before appending
-		if ipDomain.UeDnnQos != nil {
-			// Append the QoS profile when present.
-			dnnMap[dnn] = append(dnnMap[dnn], *ipDomain.UeDnnQos)
-		} else {
-			// Initialize an entry for this DNN if it doesn't exist so it is not omitted downstream.
-			if _, exists := dnnMap[dnn]; !exists {
-				dnnMap[dnn] = nil
-			}
-		}
-	}
+func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, subscriberSlices []subscriberSlice) (int, error) {
+	// Stores multiple DNNs & their QoS per IMSI
+	dnnMap := deviceGroupDnnQos(devGroupConfig, subscriberSlices)
 	var allQosProfiles []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos // Create a slice to hold all QoS profiles from all DNNs in the device group.
 	// Iterate through the dnnMap to collect all QoS profiles into a single slice.
 	for _, qosList := range dnnMap {
@@ -259,22 +273,19 @@ func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *model
 	}
 	// Calculate aggregate QoS once for the entire group
 	aggregatedQoS := aggregateQoS(allQosProfiles)
-	for i, imsi := range devGroupConfig.Imsis {
+	sessionTypes := dnnPduSessionTypes(devGroupConfig)
+	for _, imsi := range devGroupConfig.Imsis {
 		if subscriberAuthenticationDataGet("imsi-"+imsi) != nil {
 			// Process each IP domain for this IMSI
-			var gpsi string
-			if devGroupConfig.Msisdns != nil && i < len(devGroupConfig.Msisdns) {
-				gpsi = devGroupConfig.Msisdns[i]
-			}
+			gpsi := devGroupConfig.ImsiMsisdns[imsi]
 			// Call update functions once after processing all DNNs
 			logger.ConfigLog.Infoln("Processing IMSI:", imsi, "with GPSI:", gpsi)
 			err := updatePolicyAndProvisionedData(
 				imsi,
 				gpsi,
-				snssai,
+				subscriberSlices,
 				dnnMap,
-				mcc,
-				mnc,
+				sessionTypes,
 				aggregatedQoS,
 			)
 			if err != nil {
@@ -283,22 +294,71 @@ func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *model
 			}
 		}
 	}
+	rangeImsis, err := provisionedRangeImsis(devGroupConfig)
+	if err != nil {
+		logger.DbLog.Errorf("failed to fetch the subscribers of the IMSI ranges of %s: %+v", devGroupConfig.DeviceGroupName, err)
+		return http.StatusInternalServerError, err
+	}
+	for _, imsi := range rangeImsis {
+		logger.ConfigLog.Infoln("Processing IMSI:", imsi)
+		if err = updatePolicyAndProvisionedData(imsi, devGroupConfig.ImsiMsisdns[imsi], subscriberSlices, dnnMap, sessionTypes, aggregatedQoS); err != nil {
+			logger.DbLog.Errorf("updatePolicyAndProvisionedData failed for IMSI %s: %+v", imsi, err)
+			return http.StatusInternalServerError, err
+		}
+	}
 	return http.StatusOK, nil
 }
 
+// cleanupDeviceGroups removes the data of the subscribers of the device groups removed from
+// prevSlice. The data of the other network slices of a device group is kept.
 func cleanupDeviceGroups(slice, prevSlice configmodels.Slice) error {
 	dgnames := getDeletedDeviceGroupsList(slice, prevSlice)
+	if len(dgnames) == 0 {
+		return nil
+	}
+	removedSlices, err := newSubscriberSlice(&prevSlice)
+	if err != nil {
+		return err
+	}
 	for _, dgName := range dgnames {
 		devGroupConfig := getDeviceGroupByName(dgName)
 		if devGroupConfig == nil {
 			logger.ConfigLog.Warnf("Device group not found during cleanup: %s", dgName)
 			continue
 		}
+		subscriberData, err := newDeviceGroupSubscriberData(devGroupConfig, findSlicesByDeviceGroup(dgName))
+		if err != nil {
+			return err
+		}
+		rangeImsis, err := provisionedRangeImsis(devGroupConfig)
+		if err != nil {
+			logger.ConfigLog.Errorf("Failed to fetch the subscribers of the IMSI ranges of %s: %+v", dgName, err)
+			return err
+		}
+		for _, imsi := range slices.Concat(devGroupConfig.Imsis, rangeImsis) {
+			for _, removedSlice := range removedSlices {
+				if err := subscriberData.removeSlice(imsi, removedSlice); err != nil {
+					logger.ConfigLog.Errorf("Failed to remove subscriber for IMSI %s: %+v", imsi, err)
+					return err
+				}
+			}
+		}
+		if len(subscriberData.slices) == 0 {
+			continue
+		}
+		// rewrite the data shared by the remaining network slices
 		for _, imsi := range devGroupConfig.Imsis {
-			mcc := prevSlice.SiteInfo.Plmn.Mcc
-			mnc := prevSlice.SiteInfo.Plmn.Mnc
-			if err := removeSubscriberEntriesRelatedToDeviceGroups(mcc, mnc, imsi); err != nil {
-				logger.ConfigLog.Errorf("Failed to remove subscriber for IMSI %s: %+v", imsi, err)
+			if subscriberAuthenticationDataGet("imsi-"+imsi) == nil {
+				continue
+			}
+			if err := subscriberData.update(imsi, devGroupConfig.ImsiMsisdns[imsi]); err != nil {
+				logger.ConfigLog.Errorf("Failed to update subscriber for IMSI %s: %+v", imsi, err)
+				return err
+			}
+		}
+		for _, imsi := range rangeImsis {
+			if err := subscriberData.update(imsi, devGroupConfig.ImsiMsisdns[imsi]); err != nil {
+				logger.ConfigLog.Errorf("Failed to update subscriber for IMSI %s: %+v", imsi, err)
 				return err
 			}
 		}
@@ -306,48 +366,93 @@ func cleanupDeviceGroups(slice, prevSlice configmodels.Slice) error {
 	return nil
 }
 
-func updatePolicyAndProvisionedData(imsi string, gpsi string, snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, mcc string, mnc string, aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) error {
-	err := updateAmPolicyData(imsi)
+// updatePolicyAndProvisionedData writes the policy and provisioned data of imsi for every
+// network slice of subscriberSlices
+func updatePolicyAndProvisionedData(imsi string, gpsi string, subscriberSlices []subscriberSlice, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sessionTypes map[string][]models.PduSessionType, aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) error {
+	docs, err := subscriberDataDocuments(imsi, gpsi, subscriberSlices, dnnMap, sessionTypes, aggregatedQoS)
 	if err != nil {
-		return fmt.Errorf("updateAmPolicyData failed: %w", err)
-	}
-	err = updateSmPolicyData(snssai, dnnMap, imsi)
-	if err != nil {
-		return fmt.Errorf("updateSmPolicyData failed: %w", err)
+		return err
 	}
-	err = updateAmProvisionedData(gpsi, snssai, aggregatedQoS, mcc, mnc, imsi)
-	if err != nil {
-		return fmt.Errorf("updateAmProvisionedData failed: %w", err)
+	for _, doc := range docs {
+		if err = doc.write(); err != nil {
+			return fmt.Errorf("failed to update %s: %w", doc.collName, err)
+		}
 	}
-	err = updateSmProvisionedData(snssai, dnnMap, mcc, mnc, imsi)
-	if err != nil {
-		return fmt.Errorf("updateSmProvisionedData failed: %w", err)
+	return nil
+}
+
+// subscriberDocument is a policy or provisioned data document of a device group IMSI, with
+// the filter matching its stored version
+type subscriberDocument struct {
+	collName string
+	filter   bson.M
+	data     map[string]interface{}
+}
+
+// write stores doc. The SM data is replaced, the other documents are upserted.
+func (doc subscriberDocument) write() error {
+	var err error
+	if doc.collName == smDataColl {
+		_, err = dbadapter.CommonDBClient.RestfulAPIPutOne(doc.collName, doc.filter, doc.data)
+	} else {
+		_, err = dbadapter.CommonDBClient.RestfulAPIPost(doc.collName, doc.filter, doc.data)
 	}
-	err = updateSmfSelectionProvisionedData(snssai, mcc, mnc, dnnMap, imsi)
 	if err != nil {
-		return fmt.Errorf("updateSmfSelectionProvisionedData failed: %w", err)
+		logger.DbLog.Errorf("failed to update %s for %s: %+v", doc.collName, doc.data["ueId"], err)
+		return err
 	}
+	logger.DbLog.Debugf("succeeded to update %s for %s", doc.collName, doc.data["ueId"])
 	return nil
 }
 
-func updateAmPolicyData(imsi string) error {
+// subscriberDataDocuments builds the policy and provisioned data of imsi for every network
+// slice of subscriberSlices. The AM and SMF selection data of a PLMN list the S-NSSAIs of
+// all its network slices, and there is an SM data document per S-NSSAI.
+func subscriberDataDocuments(imsi string, gpsi string, subscriberSlices []subscriberSlice, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sessionTypes map[string][]models.PduSessionType, aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) ([]subscriberDocument, error) {
+	snssais := make([]models.Snssai, 0, len(subscriberSlices))
+	for _, s := range subscriberSlices {
+		snssais = append(snssais, *s.snssai)
+	}
+	docs := []subscriberDocument{
+		amPolicyDataDocument(imsi),
+		smPolicyDataDocument(snssais, dnnMap, imsi),
+	}
+	for i, s := range subscriberSlices {
+		if slices.ContainsFunc(subscriberSlices[:i], s.samePlmn) {
+			continue
+		}
+		var plmnSlices []subscriberSlice
+		var plmnSnssais []models.Snssai
+		for _, other := range subscriberSlices[i:] {
+			if other.samePlmn(s) {
+				plmnSlices = append(plmnSlices, other)
+				plmnSnssais = append(plmnSnssais, *other.snssai)
+			}
+		}
+		docs = append(docs, amProvisionedDataDocument(gpsi, plmnSnssais, subscribedUeAmbrQoS(aggregatedQoS, plmnSlices), s.mcc, s.mnc, imsi))
+		for _, plmnSlice := range plmnSlices {
+			doc, err := smProvisionedDataDocument(plmnSlice.snssai, dnnMap, sessionTypes, plmnSlice.qos, s.mcc, s.mnc, imsi)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+		docs = append(docs, smfSelectionDataDocument(plmnSnssais, s.mcc, s.mnc, dnnMap, imsi))
+	}
+	return docs, nil
+}
+
+func amPolicyDataDocument(imsi string) subscriberDocument {
 	var amPolicy models.AmPolicyData
 	amPolicy.SubscCats = append(amPolicy.SubscCats, "aether")
 	amPolicyDatBsonA := configmodels.ToBsonM(amPolicy)
 	amPolicyDatBsonA["ueId"] = "imsi-" + imsi
 	filter := bson.M{"ueId": "imsi-" + imsi}
-	_, err := dbadapter.CommonDBClient.RestfulAPIPost(amPolicyDataColl, filter, amPolicyDatBsonA)
-	if err != nil {
-		logger.DbLog.Errorf("failed to update AM Policy Data for IMSI %s: %+v", imsi, err)
-		return err
-	}
-	logger.DbLog.Debugf("succeeded to update AM Policy Data for IMSI %s", imsi)
-	return nil
+	return subscriberDocument{collName: amPolicyDataColl, filter: filter, data: amPolicyDatBsonA}
 }
 
-func updateSmPolicyData(snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, imsi string) error {
+func smPolicyDataDocument(snssais []models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, imsi string) subscriberDocument {
 	var smPolicyData models.SmPolicyData
-	var smPolicySnssaiData models.SmPolicySnssaiData
 	// Iterate over all DNNs in the map
 	dnnData := &map[string]models.SmPolicyDnnData{}
 
@@ -356,24 +461,40 @@ func updateSmPolicyData(snssai *models.Snssai, dnnMap map[string][]configmodels.
 			Dnn: dnn,
 		}
 	}
-	// smpolicydata
-	smPolicySnssaiData.Snssai = *snssai
-	smPolicySnssaiData.SmPolicyDnnData = dnnData
+	// smpolicydata, for the S-NSSAIs of every network slice
 	smPolicyData.SmPolicySnssaiData = make(map[string]models.SmPolicySnssaiData)
-	smPolicyData.SmPolicySnssaiData[SnssaiModelsToHex(*snssai)] = smPolicySnssaiData
+	for _, snssai := range snssais {
+		smPolicyData.SmPolicySnssaiData[SnssaiModelsToHex(snssai)] = models.SmPolicySnssaiData{
+			Snssai:          snssai,
+			SmPolicyDnnData: dnnData,
+		}
+	}
 	smPolicyDatBsonA := configmodels.ToBsonM(smPolicyData)
 	smPolicyDatBsonA["ueId"] = "imsi-" + imsi
 	filter := bson.M{"ueId": "imsi-" + imsi}
-	_, err := dbadapter.CommonDBClient.RestfulAPIPost(smPolicyDataColl, filter, smPolicyDatBsonA)
-	if err != nil {
-		logger.DbLog.Errorf("failed to update SM Policy Data for IMSI %s: %+v", imsi, err)
-		return err
+	return subscriberDocument{collName: smPolicyDataColl, filter: filter, data: smPolicyDatBsonA}
+}
+
+// subscribedUeAmbrQoS returns the QoS the UE AMBR of a subscriber is taken from: aggregatedQoS,
+// the QoS of its device group, or, when the device group has none, the default QoS of the
+// network slices plmnSlices of the PLMN
+func subscribedUeAmbrQoS(aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, plmnSlices []subscriberSlice) configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
+	if aggregatedQoS.DnnMbrUplink != 0 || aggregatedQoS.DnnMbrDownlink != 0 {
+		return aggregatedQoS
+	}
+	var defaultQosList []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos
+	for _, s := range plmnSlices {
+		if s.qos != nil {
+			defaultQosList = append(defaultQosList, s.qos.UeDnnQos())
+		}
 	}
-	logger.DbLog.Debugf("succeeded to update SM Policy Data for IMSI %s", imsi)
-	return nil
+	if len(defaultQosList) == 0 {
+		return aggregatedQoS
+	}
+	return aggregateQoS(defaultQosList)
 }
 
-func updateAmProvisionedData(gpsi string, snssai *models.Snssai, aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, mcc, mnc, imsi string) error {
+func amProvisionedDataDocument(gpsi string, snssais []models.Snssai, aggregatedQoS configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, mcc, mnc, imsi string) subscriberDocument {
 	var gpsiSlice []string // Initialize a slice to hold the GPSI.
 	if gpsi != "" {        // Only add if gpsi is not empty
 		gpsiSlice = []string{gpsi}
@@ -381,8 +502,8 @@ func updateAmProvisionedData(gpsi string, snssai *models.Snssai, aggregatedQoS c
 	amData := models.AccessAndMobilitySubscriptionData{
 		Gpsis: gpsiSlice,
 		Nssai: *models.NewNullableNssai(&models.Nssai{
-			DefaultSingleNssais: []models.Snssai{*snssai},
-			SingleNssais:        []models.Snssai{*snssai},
+			DefaultSingleNssais: snssais,
+			SingleNssais:        snssais,
 		}),
 		SubscribedUeAmbr: models.NewAmbr(ConvertToString(uint64(aggregatedQoS.DnnMbrUplink)), ConvertToString(uint64(aggregatedQoS.DnnMbrDownlink))),
 	}
@@ -396,50 +517,66 @@ func updateAmProvisionedData(gpsi string, snssai *models.Snssai, aggregatedQoS c
 			{"servingPlmnId": bson.M{"$exists": false}},
 		},
 	}
-	_, err := dbadapter.CommonDBClient.RestfulAPIPost(amDataColl, filter, amDataBsonA)
+	return subscriberDocument{collName: amDataColl, filter: filter, data: amDataBsonA}
+}
+
+func smProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sessionTypes map[string][]models.PduSessionType, defaultQos *configmodels.SliceQos, mcc, mnc, imsi string) (subscriberDocument, error) {
+	smDataBsonA, err := buildSmProvisionedDataDocument(snssai, dnnMap, sessionTypes, defaultQos, mcc, mnc, imsi)
 	if err != nil {
-		logger.DbLog.Errorf("failed to update AM provisioned Data for IMSI %s: %+v", imsi, err)
-		return err
+		return subscriberDocument{}, err
 	}
-	logger.DbLog.Debugf("succeeded to update AM provisioned Data for IMSI %s", imsi)
-	return nil
+	logger.DbLog.Infof("Data to be sent to database - SmProvisionedData: %+v", smDataBsonA)
+	return subscriberDocument{collName: smDataColl, filter: smProvisionedDataFilter(snssai, mcc, mnc, imsi), data: smDataBsonA}, nil
 }
 
-func updateSmProvisionedData(snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, mcc, mnc, imsi string) error {
+// smProvisionedDataFilter matches the SM data of imsi for a S-NSSAI of a PLMN
+func smProvisionedDataFilter(snssai *models.Snssai, mcc, mnc, imsi string) bson.M {
 	filter := bson.M{
-		"ueId":          "imsi-" + imsi,
-		"servingPlmnId": mcc + mnc,
+		"ueId":            "imsi-" + imsi,
+		"servingPlmnId":   mcc + mnc,
+		"singlenssai.sst": snssai.Sst,
 	}
-
-	smDataBsonA, err := buildSmProvisionedDataDocument(snssai, dnnMap, mcc, mnc, imsi)
-	if err != nil {
-		return err
-	}
-
-	logger.DbLog.Infof("Data to be sent to database - SmProvisionedData: %+v", smDataBsonA)
-	_, errPut := dbadapter.CommonDBClient.RestfulAPIPutOne(smDataColl, filter, smDataBsonA)
-	if errPut != nil {
-		logger.DbLog.Errorf("failed to update SM provisioned Data for IMSI %s: %+v", imsi, errPut)
-		return errPut
+	if snssai.Sd != nil {
+		filter["singlenssai.sd"] = *snssai.Sd
+	} else {
+		filter["singlenssai.sd"] = bson.M{"$exists": false}
 	}
-	logger.DbLog.Debugf("updated SM provisioned Data for IMSI %s", imsi)
-	return nil
+	return filter
 }
 
-func buildSmProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, mcc, mnc, imsi string) (map[string]interface{}, error) {
+// buildSmProvisionedDataDocument builds the SM subscription data of imsi. The default PDU
+// session type of a DNN is the first of its sessionTypes, only IPv4 being allowed on a DNN
+// without session types. A DNN without traffic class gets the session AMBR, 5QI and ARP of
+// defaultQos, the default QoS of the network slice.
+func buildSmProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sessionTypes map[string][]models.PduSessionType, defaultQos *configmodels.SliceQos, mcc, mnc, imsi string) (map[string]interface{}, error) {
 	dnnConfigurations := make(map[string]interface{}, len(dnnMap))
 
 	for dnn, ueDnnQosList := range dnnMap {
 		aggregatedQoS := aggregateQoS(ueDnnQosList)
+		if aggregatedQoS.TrafficClass == nil && defaultQos != nil && defaultQos.TrafficClass != nil {
+			aggregatedQoS = defaultQos.UeDnnQos()
+		}
+		if aggregatedQoS.TrafficClass == nil && len(ueDnnQosList) == 0 {
+			// a DNN without QoS is left out of the network slices without default QoS
+			continue
+		}
 		if aggregatedQoS.TrafficClass == nil {
 			logger.DbLog.Errorf("TrafficClass is nil for DNN %s, IMSI %s", dnn, imsi)
 			return nil, fmt.Errorf("traffic class missing for DNN %s", dnn)
 		}
 
+		arpPriorityLevel := aggregatedQoS.TrafficClass.Arp
+		if arpPriorityLevel == 0 {
+			arpPriorityLevel = 8
+		}
+		allowedSessionTypes := sessionTypes[dnn]
+		if len(allowedSessionTypes) == 0 {
+			allowedSessionTypes = []models.PduSessionType{models.PDUSESSIONTYPE_IPV4}
+		}
 		dnnConfigurations[dnn] = map[string]interface{}{
 			"pduSessionTypes": map[string]interface{}{
-				"defaultSessionType":  models.PDUSESSIONTYPE_IPV4,
-				"allowedSessionTypes": []models.PduSessionType{models.PDUSESSIONTYPE_IPV4},
+				"defaultSessionType":  allowedSessionTypes[0],
+				"allowedSessionTypes": allowedSessionTypes,
 			},
 			"sscModes": map[string]interface{}{
 				"defaultSscMode":  models.SSCMODE_SSC_MODE_1,
@@ -452,7 +589,7 @@ func buildSmProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]c
 			"5gQosProfile": map[string]interface{}{
 				"5qi": aggregatedQoS.TrafficClass.Qci,
 				"arp": map[string]interface{}{
-					"priorityLevel": int32(8),
+					"priorityLevel": arpPriorityLevel,
 					"preemptCap":    models.PREEMPTIONCAPABILITY_NOT_PREEMPT,
 					"preemptVuln":   models.PREEMPTIONVULNERABILITY_NOT_PREEMPTABLE,
 				},
@@ -476,7 +613,7 @@ func buildSmProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]c
 	}, nil
 }
 
-func updateSmfSelectionProvisionedData(snssai *models.Snssai, mcc, mnc string, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, imsi string) error {
+func smfSelectionDataDocument(snssais []models.Snssai, mcc, mnc string, dnnMap map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, imsi string) subscriberDocument {
 	smfSelData := models.SmfSelectionSubscriptionData{
 		SubscribedSnssaiInfos: &map[string]models.SnssaiInfo{},
 	}
@@ -501,7 +638,9 @@ func updateSmfSelectionProvisionedData(snssai *models.Snssai, mcc, mnc string, d
 			},
 		})
 	}
-	(*smfSelData.SubscribedSnssaiInfos)[SnssaiModelsToHex(*snssai)] = snssaiInfo
+	for _, snssai := range snssais {
+		(*smfSelData.SubscribedSnssaiInfos)[SnssaiModelsToHex(snssai)] = snssaiInfo
+	}
 	smfSelecDataBsonA := configmodels.ToBsonM(smfSelData)
 	smfSelecDataBsonA["ueId"] = "imsi-" + imsi
 	smfSelecDataBsonA["servingPlmnId"] = mcc + mnc
@@ -514,15 +653,7 @@ func updateSmfSelectionProvisionedData(snssai *models.Snssai, mcc, mnc string, d
 
 	// Log the data to be sent to the database
 	logger.DbLog.Infof("Data to be sent to database - smf selection: %+v", smfSelecDataBsonA)
-
-	// Perform the database post operation
-	_, errPost := dbadapter.CommonDBClient.RestfulAPIPost(smfSelDataColl, filter, smfSelecDataBsonA)
-	if errPost != nil {
-		logger.DbLog.Errorf("failed to update SMF selection provisioned data for IMSI %s: %+v", imsi, errPost)
-		return errPost
-	}
-	logger.DbLog.Debugf("updated SMF selection provisioned data for IMSI %s", imsi)
-	return nil
+	return subscriberDocument{collName: smfSelDataColl, filter: filter, data: smfSelecDataBsonA}
 }
 
 func SnssaiModelsToHex(snssai models.Snssai) string {
@@ -566,6 +697,48 @@ func getSlices() []*configmodels.Slice {
 	return slices
 }
 
+// MigrateSliceUpfs rewrites the stored network slices whose UPF port is a number, so that
+// their UPF reference is stored in its typed form with a string port. It returns the number
+// of network slices rewritten.
+func MigrateSliceUpfs() (int, error) {
+	filter := bson.M{"site-info.upf.upf-port": bson.M{"$exists": true, "$not": bson.M{"$type": "string"}}}
+	rawSlices, err := dbadapter.CommonDBClient.RestfulAPIGetMany(sliceDataColl, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch network slices: %w", err)
+	}
+	migrated := 0
+	for _, rawSlice := range rawSlices {
+		var slice configmodels.Slice
+		if err = json.Unmarshal(configmodels.MapToByte(rawSlice), &slice); err != nil {
+			return migrated, fmt.Errorf("failed to unmarshal network slice: %w", err)
+		}
+		sliceFilter := bson.M{"slice-name": slice.SliceName}
+		if _, err = dbadapter.CommonDBClient.RestfulAPIPost(sliceDataColl, sliceFilter, configmodels.ToBsonM(slice)); err != nil {
+			return migrated, fmt.Errorf("failed to store network slice %s: %w", slice.SliceName, err)
+		}
+		migrated++
+	}
+	if migrated > 0 {
+		logger.ConfigLog.Infof("converted the UPF reference of %d network slices", migrated)
+	}
+	return migrated, nil
+}
+
+// getConfiguredPlmns returns the distinct PLMNs the network slices are served in
+func getConfiguredPlmns() []identity.Plmn {
+	plmns := []identity.Plmn{}
+	for _, slice := range getSlices() {
+		for _, slicePlmn := range slice.SiteInfo.PlmnList() {
+			plmn := identity.Plmn{Mcc: slicePlmn.Mcc, Mnc: slicePlmn.Mnc}
+			if plmn.Mcc == "" || plmn.Mnc == "" || slices.Contains(plmns, plmn) {
+				continue
+			}
+			plmns = append(plmns, plmn)
+		}
+	}
+	return plmns
+}
+
 func getSliceByName(name string) *configmodels.Slice {
 	filter := bson.M{"slice-name": name}
 	sliceDataInterface, errGetOne := dbadapter.CommonDBClient.RestfulAPIGetOne(sliceDataColl, filter)
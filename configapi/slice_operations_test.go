@@ -14,6 +14,7 @@ import (
 	"os"
 	"os/exec"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -34,9 +35,10 @@ func networkSlice(name string) configmodels.Slice {
 }
 
 func networkSliceWithGnbParams(name string, gnbName string, gnbTac int32) configmodels.Slice {
-	upf := make(map[string]any, 0)
-	upf["upf-name"] = "upf"
-	upf["upf-port"] = "8805"
+	upf := &configmodels.SliceSiteInfoUpfReference{
+		UpfName: "upf",
+		UpfPort: "8805",
+	}
 	plmn := configmodels.SliceSiteInfoPlmn{
 		Mcc: "208",
 		Mnc: "93",
@@ -101,6 +103,10 @@ func (db *NetworkSliceMockDBClient) RestfulAPIGetMany(coll string, filter bson.M
 	return results, db.err
 }
 
+func (db *NetworkSliceMockDBClient) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	return 1, db.err
+}
+
 func (db *NetworkSliceMockDBClient) RestfulAPIPost(collName string, filter bson.M, postData map[string]any) (bool, error) {
 	params := map[string]any{
 		"coll":   collName,
@@ -576,7 +582,7 @@ func TestBuildSmProvisionedDataDocument(t *testing.T) {
 		},
 	}
 
-	doc, err := buildSmProvisionedDataDocument(snssai, dnnMap, "208", "93", "208930100007487")
+	doc, err := buildSmProvisionedDataDocument(snssai, dnnMap, nil, nil, "208", "93", "208930100007487")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -626,7 +632,133 @@ func TestBuildSmProvisionedDataDocument(t *testing.T) {
 	}
 }
 
-func TestUpdateSmProvisionedData_UsesPutOne(t *testing.T) {
+func TestBuildSmProvisionedDataDocument_SliceDefaultQos(t *testing.T) {
+	snssai := &models.Snssai{Sst: 1, Sd: openapi.PtrString("010203")}
+	dnnMap := map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		"internet": {{DnnMbrUplink: 2000000, DnnMbrDownlink: 5000000, TrafficClass: &configmodels.TrafficClassInfo{Qci: 9, Arp: 9}}},
+		"ims":      nil,
+	}
+	defaultQos := &configmodels.SliceQos{
+		Uplink:       1000000,
+		Downlink:     3000000,
+		TrafficClass: &configmodels.TrafficClassInfo{Qci: 5, Arp: 2},
+	}
+
+	doc, err := buildSmProvisionedDataDocument(snssai, dnnMap, nil, defaultQos, "208", "93", "208930100007487")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dnnConfigurations := doc["dnnconfigurations"].(map[string]interface{})
+	expected := map[string]struct {
+		fiveQi      int32
+		arp         int32
+		sessionAmbr map[string]interface{}
+	}{
+		"internet": {fiveQi: 9, arp: 9, sessionAmbr: map[string]interface{}{"uplink": "2 Mbps", "downlink": "5 Mbps"}},
+		"ims":      {fiveQi: 5, arp: 2, sessionAmbr: map[string]interface{}{"uplink": "1 Mbps", "downlink": "3 Mbps"}},
+	}
+	for dnn, e := range expected {
+		dnnConfiguration := dnnConfigurations[dnn].(map[string]interface{})
+		qos := dnnConfiguration["5gQosProfile"].(map[string]interface{})
+		if qos["5qi"] != e.fiveQi {
+			t.Errorf("expected 5QI %d for %s, got %v", e.fiveQi, dnn, qos["5qi"])
+		}
+		if arp := qos["arp"].(map[string]interface{}); arp["priorityLevel"] != e.arp {
+			t.Errorf("expected ARP %d for %s, got %v", e.arp, dnn, arp["priorityLevel"])
+		}
+		if !reflect.DeepEqual(dnnConfiguration["sessionAmbr"], e.sessionAmbr) {
+			t.Errorf("expected session AMBR %v for %s, got %v", e.sessionAmbr, dnn, dnnConfiguration["sessionAmbr"])
+		}
+	}
+
+	doc, err = buildSmProvisionedDataDocument(snssai, dnnMap, nil, nil, "208", "93", "208930100007487")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dnnConfigurations = doc["dnnconfigurations"].(map[string]interface{})
+	if _, ok := dnnConfigurations["ims"]; ok || len(dnnConfigurations) != 1 {
+		t.Errorf("expected the DNN without QoS to be left out of a network slice without default QoS, got %v", dnnConfigurations)
+	}
+}
+
+func TestBuildSmProvisionedDataDocument_DefaultArp(t *testing.T) {
+	snssai := &models.Snssai{Sst: 1, Sd: openapi.PtrString("010203")}
+	dnnMap := map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		"internet": {{DnnMbrUplink: 2000000, DnnMbrDownlink: 5000000, TrafficClass: &configmodels.TrafficClassInfo{Qci: 9}}},
+	}
+	doc, err := buildSmProvisionedDataDocument(snssai, dnnMap, nil, nil, "208", "93", "208930100007487")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qos := doc["dnnconfigurations"].(map[string]interface{})["internet"].(map[string]interface{})["5gQosProfile"].(map[string]interface{})
+	if arp := qos["arp"].(map[string]interface{}); arp["priorityLevel"] != int32(8) {
+		t.Errorf("expected ARP 8 for a traffic class without ARP, got %v", arp["priorityLevel"])
+	}
+}
+
+func TestDeviceGroupDnnQos(t *testing.T) {
+	devGroup := deviceGroup("group1")
+	devGroup.IpDomainsExpanded = append(devGroup.IpDomainsExpanded, configmodels.DeviceGroupsIpDomainExpanded{Dnn: "ims"})
+	withoutQos := []subscriberSlice{{mcc: "208", mnc: "93"}}
+	dnnMap := deviceGroupDnnQos(&devGroup, withoutQos)
+	if _, ok := dnnMap["ims"]; ok || len(dnnMap["internet"]) != 1 {
+		t.Errorf("expected only the DNN with QoS in a network slice without default QoS, got %+v", dnnMap)
+	}
+	withQos := []subscriberSlice{{mcc: "208", mnc: "93", qos: &configmodels.SliceQos{TrafficClass: &configmodels.TrafficClassInfo{Qci: 9, Arp: 1}}}}
+	dnnMap = deviceGroupDnnQos(&devGroup, withQos)
+	if qos, ok := dnnMap["ims"]; !ok || qos != nil {
+		t.Errorf("expected the DNN without QoS to get the default QoS of the network slice, got %+v", dnnMap)
+	}
+}
+
+func TestSubscribedUeAmbrQoS(t *testing.T) {
+	deviceGroupQoS := configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{DnnMbrUplink: 2000000, DnnMbrDownlink: 5000000}
+	plmnSlices := []subscriberSlice{
+		{qos: &configmodels.SliceQos{Uplink: 1000000, Downlink: 3000000}},
+		{},
+		{qos: &configmodels.SliceQos{Uplink: 1000000, Downlink: 1000000}},
+	}
+	if got := subscribedUeAmbrQoS(deviceGroupQoS, plmnSlices); !reflect.DeepEqual(got, deviceGroupQoS) {
+		t.Errorf("expected the QoS of the device group, got %+v", got)
+	}
+	got := subscribedUeAmbrQoS(configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{}, plmnSlices)
+	if got.DnnMbrUplink != 2000000 || got.DnnMbrDownlink != 4000000 {
+		t.Errorf("expected the sum of the default session AMBRs, got %+v", got)
+	}
+}
+
+func TestBuildSmProvisionedDataDocument_PduSessionTypes(t *testing.T) {
+	snssai := &models.Snssai{Sst: 1, Sd: openapi.PtrString("010203")}
+	qos := []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{{TrafficClass: &configmodels.TrafficClassInfo{Qci: 9}}}
+	dnnMap := map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{"internet": qos, "ims": qos}
+	sessionTypes := map[string][]models.PduSessionType{
+		"internet": {models.PDUSESSIONTYPE_IPV4_V6, models.PDUSESSIONTYPE_IPV4, models.PDUSESSIONTYPE_IPV6},
+	}
+
+	doc, err := buildSmProvisionedDataDocument(snssai, dnnMap, sessionTypes, nil, "208", "93", "208930100007487")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dnnConfigurations := doc["dnnconfigurations"].(map[string]interface{})
+	expected := map[string]map[string]interface{}{
+		"internet": {
+			"defaultSessionType":  models.PDUSESSIONTYPE_IPV4_V6,
+			"allowedSessionTypes": sessionTypes["internet"],
+		},
+		"ims": {
+			"defaultSessionType":  models.PDUSESSIONTYPE_IPV4,
+			"allowedSessionTypes": []models.PduSessionType{models.PDUSESSIONTYPE_IPV4},
+		},
+	}
+	for dnn, expectedSessionTypes := range expected {
+		dnnConfiguration := dnnConfigurations[dnn].(map[string]interface{})
+		if !reflect.DeepEqual(dnnConfiguration["pduSessionTypes"], expectedSessionTypes) {
+			t.Errorf("expected PDU session types %v for DNN %s, got %v", expectedSessionTypes, dnn, dnnConfiguration["pduSessionTypes"])
+		}
+	}
+}
+
+func TestWriteSmProvisionedData_UsesPutOne(t *testing.T) {
 	originalDBClient := dbadapter.CommonDBClient
 	defer func() { dbadapter.CommonDBClient = originalDBClient }()
 
@@ -644,7 +776,11 @@ func TestUpdateSmProvisionedData_UsesPutOne(t *testing.T) {
 		},
 	}
 
-	if err := updateSmProvisionedData(snssai, dnnMap, "208", "93", "208930100007487"); err != nil {
+	doc, err := smProvisionedDataDocument(snssai, dnnMap, nil, nil, "208", "93", "208930100007487")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = doc.write(); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if len(mock.postData) != 0 {
@@ -664,3 +800,227 @@ func TestUpdateSmProvisionedData_UsesPutOne(t *testing.T) {
 		t.Fatal("expected dnnconfigurations key in put payload")
 	}
 }
+
+func TestUpdatePolicyAndProvisionedData_MultipleSlices(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	mock := &NetworkSliceMockDBClient{}
+	dbadapter.CommonDBClient = mock
+
+	slice1 := networkSlice("slice1")
+	slice2 := networkSlice("slice2")
+	slice2.SliceId.Sst = "2"
+	slice3 := networkSlice("slice3")
+	slice3.SiteInfo.Plmn.Mnc = "94"
+	subscriberSlices, err := newSubscriberSlices([]*configmodels.Slice{&slice1, &slice2, &slice3, &slice1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subscriberSlices) != 3 {
+		t.Fatalf("expected the duplicate slice to be ignored, got %d slices", len(subscriberSlices))
+	}
+	dnnMap := map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		"internet": {{DnnMbrUplink: 1000, DnnMbrDownlink: 1000, TrafficClass: &configmodels.TrafficClassInfo{Qci: 9}}},
+	}
+	if err = updatePolicyAndProvisionedData("208930100007487", "", subscriberSlices, dnnMap, nil, dnnMap["internet"][0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snssaisByColl := map[string][][]string{}
+	for _, post := range mock.postData {
+		collName := post["coll"].(string)
+		data := post["data"].(map[string]any)
+		var snssais []string
+		switch collName {
+		case amDataColl:
+			var amData models.AccessAndMobilitySubscriptionData
+			if err = json.Unmarshal(configmodels.MapToByte(data), &amData); err != nil {
+				t.Fatalf("failed to unmarshal AM data: %v", err)
+			}
+			for _, snssai := range amData.Nssai.Get().SingleNssais {
+				snssais = append(snssais, data["servingPlmnId"].(string)+"/"+SnssaiModelsToHex(snssai))
+			}
+		case smfSelDataColl, smPolicyDataColl:
+			key := "subscribedSnssaiInfos"
+			if collName == smPolicyDataColl {
+				key = "smPolicySnssaiData"
+			}
+			for snssai := range data[key].(map[string]any) {
+				snssais = append(snssais, snssai)
+			}
+			slices.Sort(snssais)
+		default:
+			continue
+		}
+		snssaisByColl[collName] = append(snssaisByColl[collName], snssais)
+	}
+	expected := map[string][][]string{
+		amDataColl:       {{"20893/01010203", "20893/02010203"}, {"20894/01010203"}},
+		smfSelDataColl:   {{"01010203", "02010203"}, {"01010203"}},
+		smPolicyDataColl: {{"01010203", "02010203"}},
+	}
+	if !reflect.DeepEqual(snssaisByColl, expected) {
+		t.Errorf("expected S-NSSAIs %v, got %v", expected, snssaisByColl)
+	}
+	if len(mock.putData) != 3 {
+		t.Fatalf("expected an SM data document per S-NSSAI and PLMN, got %d", len(mock.putData))
+	}
+	for i, put := range mock.putData {
+		filter := put["filter"].(bson.M)
+		snssai := subscriberSlices[i].snssai
+		if filter["singlenssai.sst"] != snssai.Sst || filter["singlenssai.sd"] != snssai.GetSd() {
+			t.Errorf("expected the SM data filter to match S-NSSAI %s, got %v", SnssaiModelsToHex(*snssai), filter)
+		}
+	}
+}
+
+func TestNewSubscriberSlices_SharedRan(t *testing.T) {
+	slice1 := networkSlice("slice1")
+	slice1.SiteInfo.Plmns = []configmodels.SliceSiteInfoPlmn{{Mcc: "208", Mnc: "94"}, slice1.SiteInfo.Plmn}
+	slice1.SiteInfo.EquivalentPlmns = []configmodels.SliceSiteInfoPlmn{{Mcc: "001", Mnc: "01"}}
+	slice2 := networkSlice("slice2")
+	slice2.SiteInfo.Plmn.Mnc = "94"
+
+	subscriberSlices, err := newSubscriberSlices([]*configmodels.Slice{&slice1, &slice2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var servingPlmns []string
+	for _, s := range subscriberSlices {
+		servingPlmns = append(servingPlmns, s.mcc+s.mnc+"/"+SnssaiModelsToHex(*s.snssai))
+	}
+	expected := []string{"20893/01010203", "20894/01010203"}
+	if !reflect.DeepEqual(servingPlmns, expected) {
+		t.Errorf("expected subscriber slices %v, got %v", expected, servingPlmns)
+	}
+}
+
+func TestCleanupDeviceGroups_KeepsOtherSlices(t *testing.T) {
+	slice1 := networkSlice("slice1")
+	slice2 := networkSlice("slice2")
+	slice2.SliceId.Sst = "2"
+	otherPlmnSlice := networkSlice("slice3")
+	otherPlmnSlice.SiteInfo.Plmn.Mnc = "94"
+	updatedSlice1 := slice1
+	updatedSlice1.SiteDeviceGroup = []string{"group2"}
+	updatedSlice2 := slice2
+	updatedSlice2.SiteDeviceGroup = []string{"group2"}
+
+	testCases := []struct {
+		name            string
+		storedSlices    []configmodels.Slice
+		prevSlice       configmodels.Slice
+		slice           configmodels.Slice
+		expectedDeletes map[string][]any
+		expectUpdates   bool
+	}{
+		{
+			name:            "Other slice of the PLMN",
+			storedSlices:    []configmodels.Slice{updatedSlice1, slice2},
+			prevSlice:       slice1,
+			slice:           updatedSlice1,
+			expectedDeletes: map[string][]any{smDataColl: {"imsi-208930100007487"}},
+			expectUpdates:   true,
+		},
+		{
+			name:         "Other slice of another PLMN",
+			storedSlices: []configmodels.Slice{updatedSlice1, otherPlmnSlice},
+			prevSlice:    slice1,
+			slice:        updatedSlice1,
+			expectedDeletes: map[string][]any{
+				amDataColl:     {"imsi-208930100007487"},
+				smDataColl:     {"imsi-208930100007487"},
+				smfSelDataColl: {"imsi-208930100007487"},
+			},
+			expectUpdates: true,
+		},
+		{
+			name:         "No other slice",
+			storedSlices: []configmodels.Slice{updatedSlice1, updatedSlice2},
+			prevSlice:    slice1,
+			slice:        updatedSlice1,
+			expectedDeletes: map[string][]any{
+				amPolicyDataColl: {"imsi-208930100007487"},
+				smPolicyDataColl: {"imsi-208930100007487"},
+				amDataColl:       {"imsi-208930100007487"},
+				smDataColl:       {"imsi-208930100007487"},
+				smfSelDataColl:   {"imsi-208930100007487"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487"})
+			commonDb := newDeviceGroupMemberMockDBClient(&deviceGroup, tc.storedSlices...)
+			setupDeviceGroupMemberMocks(t, commonDb, []string{"imsi-208930100007487"})
+
+			if err := cleanupDeviceGroups(tc.slice, tc.prevSlice); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(commonDb.deletedColls, tc.expectedDeletes) {
+				t.Errorf("expected deletions %v, got %v", tc.expectedDeletes, commonDb.deletedColls)
+			}
+			if updated := len(commonDb.updatedColls[amDataColl]) > 0; updated != tc.expectUpdates {
+				t.Errorf("expected the data of the remaining slices to be rewritten: %t, got %v", tc.expectUpdates, commonDb.updatedColls)
+			}
+		})
+	}
+}
+
+type SliceMigrationMockDBClient struct {
+	NetworkSliceMockDBClient
+	rawSlices []map[string]any
+}
+
+func (db *SliceMigrationMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	return db.rawSlices, nil
+}
+
+func TestMigrateSliceUpfs(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	mock := &SliceMigrationMockDBClient{
+		rawSlices: []map[string]any{
+			{
+				"slice-name": "slice1",
+				"site-info": map[string]any{
+					"site-name": "demo",
+					"upf":       map[string]any{"upf-name": "upf1", "upf-port": float64(8805)},
+				},
+			},
+			{
+				"slice-name": "slice2",
+				"site-info": map[string]any{
+					"site-name": "demo",
+					"upf":       map[string]any{"upf-name": "upf2", "upf-port": int32(8806)},
+				},
+			},
+		},
+	}
+	dbadapter.CommonDBClient = mock
+
+	migrated, err := MigrateSliceUpfs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrated != 2 || len(mock.postData) != 2 {
+		t.Fatalf("expected 2 network slices to be migrated, got %d and %d stored", migrated, len(mock.postData))
+	}
+	expectedUpfs := []configmodels.SliceSiteInfoUpfReference{
+		{UpfName: "upf1", UpfPort: "8805"},
+		{UpfName: "upf2", UpfPort: "8806"},
+	}
+	for i, expectedUpf := range expectedUpfs {
+		var stored configmodels.Slice
+		if err = json.Unmarshal(configmodels.MapToByte(mock.postData[i]["data"].(map[string]any)), &stored); err != nil {
+			t.Fatalf("failed to unmarshal stored network slice: %v", err)
+		}
+		if stored.SiteInfo.Upf == nil || *stored.SiteInfo.Upf != expectedUpf {
+			t.Errorf("expected UPF %+v, got %+v", expectedUpf, stored.SiteInfo.Upf)
+		}
+		upf := mock.postData[i]["data"].(map[string]any)["site-info"].(map[string]any)["upf"].(map[string]any)
+		if _, ok := upf["upf-port"].(string); !ok {
+			t.Errorf("expected the UPF port to be stored as a string, got %T", upf["upf-port"])
+		}
+	}
+}
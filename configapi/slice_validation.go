@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// sliceValidationError lists the problems found with the fields of a network slice
+type sliceValidationError struct {
+	problems []configmodels.FieldError
+}
+
+func (e *sliceValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.problems))
+	for _, problem := range e.problems {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", problem.Field, problem.Message))
+	}
+	return "invalid network slice: " + strings.Join(descriptions, "; ")
+}
+
+// validateSlice checks the fields of slice and, once they are valid, that the device groups,
+// UPF and gNBs it references exist. The problems found are returned as a
+// *sliceValidationError, other errors being failures to fetch the references.
+func validateSlice(slice *configmodels.Slice) error {
+	if problems := sliceFieldErrors(slice); len(problems) > 0 {
+		return &sliceValidationError{problems: problems}
+	}
+	problems, err := sliceReferenceErrors(slice)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return &sliceValidationError{problems: problems}
+	}
+	return nil
+}
+
+// sliceFieldErrors returns the problems with the values of the fields of slice
+func sliceFieldErrors(slice *configmodels.Slice) []configmodels.FieldError {
+	var problems []configmodels.FieldError
+	addProblem := func(field string, format string, args ...any) {
+		problems = append(problems, configmodels.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !isValidSst(slice.SliceId.Sst) {
+		addProblem("slice-id.sst", "invalid SST `%s`: expected an integer from 0 to %d", slice.SliceId.Sst, MAX_SST)
+	}
+	if slice.SliceId.Sd != "" && !isValidSd(slice.SliceId.Sd) {
+		addProblem("slice-id.sd", "invalid SD `%s`: expected %d hexadecimal characters", slice.SliceId.Sd, SD_HEX_LENGTH)
+	}
+	addPlmnProblems := func(field string, plmn configmodels.SliceSiteInfoPlmn) {
+		if !isValidMcc(plmn.Mcc) {
+			addProblem(field+".mcc", "invalid MCC `%s`: expected 3 digits", plmn.Mcc)
+		}
+		if !isValidMnc(plmn.Mnc) {
+			addProblem(field+".mnc", "invalid MNC `%s`: expected 2 or 3 digits", plmn.Mnc)
+		}
+	}
+	addPlmnProblems("site-info.plmn", slice.SiteInfo.Plmn)
+	for i, plmn := range slice.SiteInfo.Plmns {
+		field := fmt.Sprintf("site-info.plmns[%d]", i)
+		addPlmnProblems(field, plmn)
+		if j := slices.Index(slice.SiteInfo.Plmns[:i], plmn); j >= 0 {
+			addProblem(field, "PLMN %s%s is already listed as site-info.plmns[%d]", plmn.Mcc, plmn.Mnc, j)
+		} else if plmn == slice.SiteInfo.Plmn {
+			addProblem(field, "PLMN %s%s is already the PLMN of site-info.plmn", plmn.Mcc, plmn.Mnc)
+		}
+	}
+	for i, plmn := range slice.SiteInfo.EquivalentPlmns {
+		field := fmt.Sprintf("site-info.equivalent-plmns[%d]", i)
+		addPlmnProblems(field, plmn)
+		if slices.Contains(slice.SiteInfo.PlmnList(), plmn) {
+			addProblem(field, "PLMN %s%s is a PLMN of the network slice and cannot be equivalent to itself", plmn.Mcc, plmn.Mnc)
+		}
+	}
+	for i, gnb := range slice.SiteInfo.GNodeBs {
+		if !isValidName(gnb.Name) {
+			addProblem(fmt.Sprintf("site-info.gNodeBs[%d].name", i), "invalid gNB name `%s`: expected to match %s", gnb.Name, NAME_PATTERN)
+		}
+		if !isValidGnbTac(gnb.Tac) {
+			addProblem(fmt.Sprintf("site-info.gNodeBs[%d].tac", i), "invalid TAC %d for gNB %s", gnb.Tac, gnb.Name)
+		}
+	}
+
+	if upf := slice.SiteInfo.Upf; upf != nil {
+		if len(slice.SiteInfo.Upfs) > 0 {
+			addProblem("site-info.upf", "a single UPF cannot be set together with site-info.upfs")
+		}
+		if upf.UpfName == "" {
+			addProblem("site-info.upf.upf-name", "UPF name is required")
+		}
+		if upf.UpfPort != "" && !isValidUpfPort(string(upf.UpfPort)) {
+			addProblem("site-info.upf.upf-port", "invalid UPF port `%s`: expected a port from 0 to %d", upf.UpfPort, MAX_PORT)
+		}
+	}
+	upfNames := make(map[string]int)
+	for i, upf := range slice.SiteInfo.Upfs {
+		field := fmt.Sprintf("site-info.upfs[%d]", i)
+		if upf.UpfName == "" {
+			addProblem(field+".upf-name", "UPF name is required")
+		} else if j, ok := upfNames[upf.UpfName]; ok {
+			addProblem(field+".upf-name", "UPF %s is already listed as site-info.upfs[%d]", upf.UpfName, j)
+		} else {
+			upfNames[upf.UpfName] = i
+		}
+		if upf.UpfPort != "" && !isValidUpfPort(upf.UpfPort) {
+			addProblem(field+".upf-port", "invalid UPF port `%s`: expected a port from 0 to %d", upf.UpfPort, MAX_PORT)
+		}
+		if upf.Priority < 0 || upf.Priority > MAX_UPF_PRIORITY {
+			addProblem(field+".priority", "invalid priority %d: expected an integer from 0 to %d", upf.Priority, MAX_UPF_PRIORITY)
+		}
+		if upf.Weight < 0 || upf.Weight > MAX_UPF_WEIGHT {
+			addProblem(field+".weight", "invalid weight %d: expected an integer from 0 to %d", upf.Weight, MAX_UPF_WEIGHT)
+		}
+	}
+
+	if qos := slice.Qos; qos != nil {
+		if qos.Uplink < 0 {
+			addProblem("qos.uplink", "invalid uplink %d: expected a positive data rate", qos.Uplink)
+		}
+		if qos.Downlink < 0 {
+			addProblem("qos.downlink", "invalid downlink %d: expected a positive data rate", qos.Downlink)
+		}
+		if qos.BitrateUnit != "" && !isValidBitrateUnit(qos.BitrateUnit) {
+			addProblem("qos.bitrate-unit", "invalid bitrate unit `%s`: expected bps, Kbps, Mbps or Gbps", qos.BitrateUnit)
+		}
+		if qos.TrafficClass == nil {
+			addProblem("qos.traffic-class", "default TrafficClass (5QI, ARP) required but not provided")
+		} else if !isTrafficClassReference(*qos.TrafficClass) {
+			if !isValid5qi(qos.TrafficClass.Qci) {
+				addProblem("qos.traffic-class.qci", "invalid 5QI %d: expected a standardized 5QI or an operator-specific 5QI from %d to %d", qos.TrafficClass.Qci, MIN_OPERATOR_5QI, MAX_OPERATOR_5QI)
+			}
+			if !isValidArp(qos.TrafficClass.Arp) {
+				addProblem("qos.traffic-class.arp", "invalid ARP %d: expected an integer from %d to %d", qos.TrafficClass.Arp, MIN_ARP, MAX_ARP)
+			}
+		}
+	}
+
+	ruleNames := make(map[string]int)
+	priorities := make(map[int32]int)
+	for i, rule := range slice.ApplicationFilteringRules {
+		field := fmt.Sprintf("application-filtering-rules[%d]", i)
+		if rule.TrafficClass == nil {
+			addProblem(field+".traffic-class", "TrafficClass (QCI, ARP) required but not provided")
+		} else if !isTrafficClassReference(*rule.TrafficClass) && !isValid5qi(rule.TrafficClass.Qci) {
+			addProblem(field+".traffic-class.qci", "invalid 5QI %d: expected a standardized 5QI or an operator-specific 5QI from %d to %d", rule.TrafficClass.Qci, MIN_OPERATOR_5QI, MAX_OPERATOR_5QI)
+		}
+		if !isValidProtocol(rule.Protocol) {
+			addProblem(field+".protocol", "invalid protocol %d: expected an IP protocol number from 0 to %d", rule.Protocol, MAX_PROTOCOL)
+		}
+		if !isValidPort(rule.StartPort) {
+			addProblem(field+".dest-port-start", "invalid port %d: expected a port from 0 to %d", rule.StartPort, MAX_PORT)
+		}
+		if !isValidPort(rule.EndPort) {
+			addProblem(field+".dest-port-end", "invalid port %d: expected a port from 0 to %d", rule.EndPort, MAX_PORT)
+		}
+		if rule.StartPort > rule.EndPort {
+			addProblem(field+".dest-port-end", "port range end %d is lower than its start %d", rule.EndPort, rule.StartPort)
+		}
+		if j, ok := ruleNames[rule.RuleName]; ok {
+			addProblem(field+".rule-name", "rule name `%s` is already used by application-filtering-rules[%d]", rule.RuleName, j)
+		} else {
+			ruleNames[rule.RuleName] = i
+		}
+		if j, ok := priorities[rule.Priority]; ok {
+			addProblem(field+".priority", "priority %d is already used by application-filtering-rules[%d]", rule.Priority, j)
+		} else {
+			priorities[rule.Priority] = i
+		}
+	}
+	return problems
+}
+
+// sliceReferenceErrors returns the device groups, UPFs and gNBs referenced by slice which do
+// not exist, the DNNs assigned to a UPF which none of its device groups serves, and the
+// other network slices with the same S-NSSAI in one of its PLMNs
+func sliceReferenceErrors(slice *configmodels.Slice) ([]configmodels.FieldError, error) {
+	var problems []configmodels.FieldError
+	plmns := slice.SiteInfo.PlmnList()
+	for _, otherSlice := range getSlices() {
+		if otherSlice.SliceName == slice.SliceName || otherSlice.SliceId != slice.SliceId {
+			continue
+		}
+		for _, plmn := range otherSlice.SiteInfo.PlmnList() {
+			if slices.Contains(plmns, plmn) {
+				problems = append(problems, configmodels.FieldError{
+					Field: "slice-id",
+					Message: fmt.Sprintf("S-NSSAI %s/%s is already used by network slice %s in PLMN %s%s",
+						slice.SliceId.Sst, slice.SliceId.Sd, otherSlice.SliceName, plmn.Mcc, plmn.Mnc),
+				})
+			}
+		}
+	}
+	for i, groupName := range slice.SiteDeviceGroup {
+		exists, err := documentExists(devGroupDataColl, bson.M{"group-name": groupName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch device group %s: %w", groupName, err)
+		}
+		if !exists {
+			problems = append(problems, configmodels.FieldError{
+				Field:   fmt.Sprintf("site-device-group[%d]", i),
+				Message: fmt.Sprintf("device group %s does not exist", groupName),
+			})
+		}
+	}
+	for i, upfName := range sliceUpfNames(slice) {
+		exists, err := documentExists(configmodels.UpfDataColl, bson.M{"hostname": upfName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch UPF %s: %w", upfName, err)
+		}
+		if !exists {
+			field := "site-info.upf.upf-name"
+			if len(slice.SiteInfo.Upfs) > 0 {
+				field = fmt.Sprintf("site-info.upfs[%d].upf-name", i)
+			}
+			problems = append(problems, configmodels.FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("UPF %s does not exist in the inventory", upfName),
+			})
+		}
+	}
+	problems = append(problems, sliceUpfDnnErrors(slice)...)
+	trafficClassProblems, err := resolveSliceTrafficClasses(slice)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, trafficClassProblems...)
+	for i, gnb := range slice.SiteInfo.GNodeBs {
+		exists, err := documentExists(configmodels.GnbDataColl, bson.M{"name": gnb.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch gNB %s: %w", gnb.Name, err)
+		}
+		if !exists {
+			problems = append(problems, configmodels.FieldError{
+				Field:   fmt.Sprintf("site-info.gNodeBs[%d].name", i),
+				Message: fmt.Sprintf("gNB %s does not exist in the inventory", gnb.Name),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// sliceUpfDnnErrors returns the DNNs assigned to a UPF of slice which none of the device
+// groups of slice serves
+func sliceUpfDnnErrors(slice *configmodels.Slice) []configmodels.FieldError {
+	if !slices.ContainsFunc(slice.SiteInfo.Upfs, func(upf configmodels.SliceSiteInfoUpf) bool { return len(upf.Dnns) > 0 }) {
+		return nil
+	}
+	dnns := make(map[string]bool)
+	for _, groupName := range slice.SiteDeviceGroup {
+		devGroup := getDeviceGroupByName(groupName)
+		if devGroup == nil {
+			continue
+		}
+		for _, ipDomain := range devGroup.IpDomainsExpanded {
+			dnns[ipDomain.Dnn] = true
+		}
+	}
+	var problems []configmodels.FieldError
+	for i, upf := range slice.SiteInfo.Upfs {
+		for j, dnn := range upf.Dnns {
+			if !dnns[dnn] {
+				problems = append(problems, configmodels.FieldError{
+					Field:   fmt.Sprintf("site-info.upfs[%d].dnns[%d]", i, j),
+					Message: fmt.Sprintf("DNN %s is not served by any device group of the network slice", dnn),
+				})
+			}
+		}
+	}
+	return problems
+}
+
+func documentExists(collName string, filter bson.M) (bool, error) {
+	count, err := dbadapter.CommonDBClient.RestfulAPICount(collName, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// resolveSliceTrafficClasses replaces the traffic classes of slice referenced by name by their
+// definition, and returns the problems with the references
+func resolveSliceTrafficClasses(slice *configmodels.Slice) ([]configmodels.FieldError, error) {
+	var problems []configmodels.FieldError
+	resolve := func(field string, trafficClass *configmodels.TrafficClassInfo) error {
+		err := resolveTrafficClass(trafficClass)
+		if errors.Is(err, errInvalidTrafficClassReference) {
+			problems = append(problems, configmodels.FieldError{Field: field, Message: err.Error()})
+			return nil
+		}
+		return err
+	}
+	for i, rule := range slice.ApplicationFilteringRules {
+		if rule.TrafficClass == nil {
+			continue
+		}
+		if err := resolve(fmt.Sprintf("application-filtering-rules[%d].traffic-class", i), rule.TrafficClass); err != nil {
+			return nil, err
+		}
+	}
+	if slice.Qos != nil && slice.Qos.TrafficClass != nil {
+		if err := resolve("qos.traffic-class", slice.Qos.TrafficClass); err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
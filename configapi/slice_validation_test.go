@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type SliceValidationMockDBClient struct {
+	dbadapter.DBInterface
+	slices       []configmodels.Slice
+	deviceGroups []string
+	gnbs         []string
+	upfs         []string
+}
+
+func (db *SliceValidationMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	groupName, _ := filter["group-name"].(string)
+	if coll == devGroupDataColl && slices.Contains(db.deviceGroups, groupName) {
+		return configmodels.ToBsonM(deviceGroup(groupName)), nil
+	}
+	return nil, nil
+}
+
+func (db *SliceValidationMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	if coll == sliceDataColl {
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	}
+	return results, nil
+}
+
+func (db *SliceValidationMockDBClient) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	var names []string
+	var name any
+	switch coll {
+	case devGroupDataColl:
+		names, name = db.deviceGroups, filter["group-name"]
+	case configmodels.GnbDataColl:
+		names, name = db.gnbs, filter["name"]
+	case configmodels.UpfDataColl:
+		names, name = db.upfs, filter["hostname"]
+	}
+	for _, n := range names {
+		if n == name {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func applicationFilteringRule(name string, priority int32) configmodels.SliceApplicationFilteringRules {
+	return configmodels.SliceApplicationFilteringRules{
+		RuleName:     name,
+		Priority:     priority,
+		Action:       "permit",
+		Endpoint:     "0.0.0.0/0",
+		Protocol:     6,
+		StartPort:    80,
+		EndPort:      443,
+		TrafficClass: &configmodels.TrafficClassInfo{Name: "platinum", Qci: 8, Arp: 6},
+	}
+}
+
+func TestSliceFieldErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		modify         func(*configmodels.Slice)
+		expectedFields []string
+	}{
+		{
+			name:   "Valid network slice",
+			modify: func(s *configmodels.Slice) {},
+		},
+		{
+			name:   "Network slice without SD",
+			modify: func(s *configmodels.Slice) { s.SliceId.Sd = "" },
+		},
+		{
+			name:           "SST out of range",
+			modify:         func(s *configmodels.Slice) { s.SliceId.Sst = "256" },
+			expectedFields: []string{"slice-id.sst"},
+		},
+		{
+			name:           "SD not hexadecimal",
+			modify:         func(s *configmodels.Slice) { s.SliceId.Sd = "01020g" },
+			expectedFields: []string{"slice-id.sd"},
+		},
+		{
+			name: "Invalid MCC and MNC",
+			modify: func(s *configmodels.Slice) {
+				s.SiteInfo.Plmn.Mcc = "20"
+				s.SiteInfo.Plmn.Mnc = "9a"
+			},
+			expectedFields: []string{"site-info.plmn.mcc", "site-info.plmn.mnc"},
+		},
+		{
+			name: "Invalid shared RAN and equivalent PLMNs",
+			modify: func(s *configmodels.Slice) {
+				s.SiteInfo.Plmns = []configmodels.SliceSiteInfoPlmn{
+					{Mcc: "208", Mnc: "01"},
+					s.SiteInfo.Plmn,
+					{Mcc: "208", Mnc: "01"},
+					{Mcc: "2080", Mnc: "01"},
+				}
+				s.SiteInfo.EquivalentPlmns = []configmodels.SliceSiteInfoPlmn{{Mcc: "208", Mnc: "01"}, {Mcc: "208", Mnc: "02"}}
+			},
+			expectedFields: []string{
+				"site-info.plmns[1]",
+				"site-info.plmns[2]",
+				"site-info.plmns[3].mcc",
+				"site-info.equivalent-plmns[0]",
+			},
+		},
+		{
+			name:           "Invalid gNB TAC",
+			modify:         func(s *configmodels.Slice) { s.SiteInfo.GNodeBs[0].Tac = 0 },
+			expectedFields: []string{"site-info.gNodeBs[0].tac"},
+		},
+		{
+			name: "Invalid rule ports and protocol",
+			modify: func(s *configmodels.Slice) {
+				s.ApplicationFilteringRules[0].Protocol = 256
+				s.ApplicationFilteringRules[0].StartPort = 70000
+				s.ApplicationFilteringRules[0].EndPort = 8080
+			},
+			expectedFields: []string{
+				"application-filtering-rules[0].protocol",
+				"application-filtering-rules[0].dest-port-start",
+				"application-filtering-rules[0].dest-port-end",
+			},
+		},
+		{
+			name: "Duplicate rule name and priority",
+			modify: func(s *configmodels.Slice) {
+				s.ApplicationFilteringRules = append(s.ApplicationFilteringRules, applicationFilteringRule("rule1", 1))
+			},
+			expectedFields: []string{"application-filtering-rules[2].rule-name", "application-filtering-rules[2].priority"},
+		},
+		{
+			name: "Single UPF with UPFs list",
+			modify: func(s *configmodels.Slice) {
+				s.SiteInfo.Upfs = []configmodels.SliceSiteInfoUpf{{UpfName: "upf"}}
+			},
+			expectedFields: []string{"site-info.upf"},
+		},
+		{
+			name: "Invalid UPFs list",
+			modify: func(s *configmodels.Slice) {
+				s.SiteInfo.Upf = nil
+				s.SiteInfo.Upfs = []configmodels.SliceSiteInfoUpf{
+					{UpfName: "upf1", UpfPort: "88050", Priority: -1},
+					{UpfName: "upf1", Weight: 70000},
+					{},
+				}
+			},
+			expectedFields: []string{
+				"site-info.upfs[0].upf-port",
+				"site-info.upfs[0].priority",
+				"site-info.upfs[1].upf-name",
+				"site-info.upfs[1].weight",
+				"site-info.upfs[2].upf-name",
+			},
+		},
+		{
+			name: "Invalid default QoS",
+			modify: func(s *configmodels.Slice) {
+				s.Qos = &configmodels.SliceQos{
+					Uplink:       -1,
+					BitrateUnit:  "tbps",
+					TrafficClass: &configmodels.TrafficClassInfo{Qci: 0, Arp: 16},
+				}
+			},
+			expectedFields: []string{"qos.uplink", "qos.bitrate-unit", "qos.traffic-class.qci", "qos.traffic-class.arp"},
+		},
+		{
+			name:           "Default QoS without traffic class",
+			modify:         func(s *configmodels.Slice) { s.Qos = &configmodels.SliceQos{Uplink: 10, BitrateUnit: "Mbps"} },
+			expectedFields: []string{"qos.traffic-class"},
+		},
+		{
+			name:           "Rule without traffic class",
+			modify:         func(s *configmodels.Slice) { s.ApplicationFilteringRules[1].TrafficClass = nil },
+			expectedFields: []string{"application-filtering-rules[1].traffic-class"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slice := networkSlice("slice1")
+			slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{
+				applicationFilteringRule("rule1", 1),
+				applicationFilteringRule("rule2", 2),
+			}
+			tc.modify(&slice)
+
+			var fields []string
+			for _, problem := range sliceFieldErrors(&slice) {
+				fields = append(fields, problem.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expectedFields) {
+				t.Errorf("expected problems with %v, got %v", tc.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestSliceReferenceErrors(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	existingSlice := networkSlice("slice2")
+	existingSlice.SliceId.Sd = "040506"
+	dbadapter.CommonDBClient = &SliceValidationMockDBClient{
+		slices:       []configmodels.Slice{networkSlice("slice1"), existingSlice},
+		deviceGroups: []string{"group1"},
+		upfs:         []string{"upf"},
+		gnbs:         []string{"demo-gnb1"},
+	}
+
+	testCases := []struct {
+		name           string
+		modify         func(*configmodels.Slice)
+		expectedFields []string
+	}{
+		{
+			name:           "Unknown device group",
+			modify:         func(s *configmodels.Slice) {},
+			expectedFields: []string{"site-device-group[1]"},
+		},
+		{
+			name: "Unknown UPF and gNB",
+			modify: func(s *configmodels.Slice) {
+				s.SiteDeviceGroup = []string{"group1"}
+				s.SiteInfo.Upf.UpfName = "other-upf"
+				s.SiteInfo.GNodeBs[0].Name = "other-gnb"
+			},
+			expectedFields: []string{"site-info.upf.upf-name", "site-info.gNodeBs[0].name"},
+		},
+		{
+			name: "S-NSSAI used by another network slice",
+			modify: func(s *configmodels.Slice) {
+				s.SiteDeviceGroup = []string{"group1"}
+				s.SliceId.Sd = "040506"
+			},
+			expectedFields: []string{"slice-id"},
+		},
+		{
+			name: "S-NSSAI used by another network slice in a shared RAN PLMN",
+			modify: func(s *configmodels.Slice) {
+				s.SiteDeviceGroup = []string{"group1"}
+				s.SiteInfo.Plmn = configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "01"}
+				s.SiteInfo.Plmns = []configmodels.SliceSiteInfoPlmn{existingSlice.SiteInfo.Plmn}
+				s.SliceId.Sd = "040506"
+			},
+			expectedFields: []string{"slice-id"},
+		},
+		{
+			name: "Unknown UPF and DNN in UPFs list",
+			modify: func(s *configmodels.Slice) {
+				s.SiteDeviceGroup = []string{"group1"}
+				s.SiteInfo.Upf = nil
+				s.SiteInfo.Upfs = []configmodels.SliceSiteInfoUpf{
+					{UpfName: "upf", Dnns: []string{"internet"}},
+					{UpfName: "other-upf", Dnns: []string{"ims"}},
+				}
+			},
+			expectedFields: []string{"site-info.upfs[1].upf-name", "site-info.upfs[1].dnns[0]"},
+		},
+		{
+			name:   "Valid references",
+			modify: func(s *configmodels.Slice) { s.SiteDeviceGroup = []string{"group1"} },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slice := networkSlice("slice1")
+			tc.modify(&slice)
+
+			problems, err := sliceReferenceErrors(&slice)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var fields []string
+			for _, problem := range problems {
+				fields = append(fields, problem.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expectedFields) {
+				t.Errorf("expected problems with %v, got %v", tc.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestNetworkSlicePostHandler_FieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	slice := networkSlice("slice1")
+	slice.SliceId.Sst = "invalid"
+	slice.SiteInfo.Plmn.Mcc = "2080"
+	jsonBody, err := json.Marshal(slice)
+	if err != nil {
+		t.Fatalf("failed to marshal network slice: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, "/config/v1/network-slice/slice1", bytes.NewReader(jsonBody))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+	}
+
+	var response struct {
+		Errors []configmodels.FieldError `json:"errors"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	var fields []string
+	for _, problem := range response.Errors {
+		fields = append(fields, problem.Field)
+	}
+	expectedFields := []string{"slice-id.sst", "site-info.plmn.mcc"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("expected problems with %v, got %v", expectedFields, fields)
+	}
+}
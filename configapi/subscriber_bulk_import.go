@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/identity"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const csvContentType = "text/csv"
+
+// subscriberBulkImportCsvColumns lists the CSV header names, in the same
+// format as the JSON field names of configmodels.SubsBulkImportRow.
+var subscriberBulkImportCsvColumns = []string{"ueId", "opc", "key", "sqn", "plmnID"}
+
+func parseSubscriberBulkImportJSON(body []byte) ([]configmodels.SubsBulkImportRow, error) {
+	var rows []configmodels.SubsBulkImportRow
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return rows, nil
+}
+
+func parseSubscriberBulkImportCSV(body io.Reader) ([]configmodels.SubsBulkImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV header is missing")
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range subscriberBulkImportCsvColumns {
+		if _, ok := columns[name]; !ok && name != "plmnID" {
+			return nil, fmt.Errorf("CSV header is missing column %s", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	rows := make([]configmodels.SubsBulkImportRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		rows = append(rows, configmodels.SubsBulkImportRow{
+			UeId:           field(record, "ueId"),
+			OPc:            field(record, "opc"),
+			Key:            field(record, "key"),
+			SequenceNumber: field(record, "sqn"),
+			PlmnID:         field(record, "plmnID"),
+		})
+	}
+	return rows, nil
+}
+
+// validateSubscriberBulkImportRow validates row, normalizes its ueId and returns its
+// authentication subscription. The IMSI must belong to one of plmns when any is configured,
+// and to the PLMN of the row when it has one.
+func validateSubscriberBulkImportRow(row *configmodels.SubsBulkImportRow, plmns []identity.Plmn) (*models.AuthenticationSubscription, error) {
+	supi, err := identity.Parse(row.UeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ueId: %w", err)
+	}
+	if err = supi.CheckPlmn(plmns); err != nil {
+		return nil, fmt.Errorf("invalid ueId: %w", err)
+	}
+	row.UeId = supi.String()
+	if row.PlmnID != "" {
+		if !isValidPlmnId(row.PlmnID) {
+			return nil, fmt.Errorf("invalid plmnID %q: expected 5 or 6 digits", row.PlmnID)
+		}
+		if err = supi.CheckPlmn([]identity.Plmn{{Mcc: row.PlmnID[:3], Mnc: row.PlmnID[3:]}}); err != nil {
+			return nil, fmt.Errorf("invalid plmnID %q: %w", row.PlmnID, err)
+		}
+	}
+	return newAuthenticationSubscription(configmodels.SubsOverrideData{
+		PlmnID:         row.PlmnID,
+		OPc:            row.OPc,
+		Key:            row.Key,
+		SequenceNumber: row.SequenceNumber,
+	})
+}
+
+// getExistingSubscribers returns the set of the given UE IDs which are already provisioned.
+func getExistingSubscribers(ueIds []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(ueIds) == 0 {
+		return existing, nil
+	}
+	amDataList, err := dbadapter.CommonDBClient.RestfulAPIGetMany(amDataColl, bson.M{"ueId": bson.M{"$in": ueIds}})
+	if err != nil {
+		return nil, err
+	}
+	for _, amData := range amDataList {
+		if ueId, ok := amData["ueId"].(string); ok {
+			existing[ueId] = true
+		}
+	}
+	return existing, nil
+}
+
+// subscriberBulkImport validates every row and, unless dryRun is set, creates the valid
+// subscribers. Subscribers are written in transactions of chunkSize rows (all rows in a
+// single transaction when chunkSize is 0); if a transaction fails, every row of its chunk
+// is reported as failed.
+func subscriberBulkImport(ctx context.Context, rows []configmodels.SubsBulkImportRow, chunkSize int, dryRun bool) (configmodels.SubsBulkImportReport, error) {
+	report := configmodels.SubsBulkImportReport{
+		DryRun:  dryRun,
+		Total:   len(rows),
+		Results: make([]configmodels.SubsBulkImportRowResult, len(rows)),
+	}
+	plmns := getConfiguredPlmns()
+	seen := make(map[string]bool)
+	validIdx := make([]int, 0, len(rows))
+	authSubsData := make([]*models.AuthenticationSubscription, len(rows))
+	for i := range rows {
+		report.Results[i] = configmodels.SubsBulkImportRowResult{Row: i + 1, UeId: rows[i].UeId}
+		var err error
+		authSubsData[i], err = validateSubscriberBulkImportRow(&rows[i], plmns)
+		if err == nil && seen[rows[i].UeId] {
+			err = fmt.Errorf("duplicate ueId %s in request", rows[i].UeId)
+		}
+		if err != nil {
+			report.Results[i].Status = configmodels.SubsBulkImportStatusInvalid
+			report.Results[i].Error = err.Error()
+			continue
+		}
+		report.Results[i].UeId = rows[i].UeId
+		seen[rows[i].UeId] = true
+		validIdx = append(validIdx, i)
+	}
+
+	ueIds := make([]string, 0, len(validIdx))
+	for _, i := range validIdx {
+		ueIds = append(ueIds, rows[i].UeId)
+	}
+	existing, err := getExistingSubscribers(ueIds)
+	if err != nil {
+		return report, fmt.Errorf("failed to check subscribers existence: %w", err)
+	}
+	toCreate := make([]int, 0, len(validIdx))
+	for _, i := range validIdx {
+		if existing[rows[i].UeId] {
+			report.Results[i].Status = configmodels.SubsBulkImportStatusInvalid
+			report.Results[i].Error = fmt.Sprintf("subscriber %s already exists", rows[i].UeId)
+			continue
+		}
+		report.Results[i].Status = configmodels.SubsBulkImportStatusValid
+		toCreate = append(toCreate, i)
+	}
+
+	if !dryRun {
+		if chunkSize <= 0 {
+			chunkSize = len(toCreate)
+		}
+		for start := 0; start < len(toCreate); start += chunkSize {
+			chunk := toCreate[start:min(start+chunkSize, len(toCreate))]
+			sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+			err := sessionRunner(ctx, func(sc context.Context) error {
+				for _, i := range chunk {
+					if err := subscriberAuthenticationDataCreateWithContext(sc, rows[i].UeId, authSubsData[i]); err != nil {
+						return fmt.Errorf("failed to create subscriber %s: %w", rows[i].UeId, err)
+					}
+				}
+				return nil
+			})
+			for _, i := range chunk {
+				if err != nil {
+					report.Results[i].Status = configmodels.SubsBulkImportStatusFailed
+					report.Results[i].Error = err.Error()
+				} else {
+					report.Results[i].Status = configmodels.SubsBulkImportStatusCreated
+				}
+			}
+			if err != nil {
+				logger.DbLog.Errorf("bulk import of rows %d to %d failed: %+v", chunk[0]+1, chunk[len(chunk)-1]+1, err)
+			}
+		}
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case configmodels.SubsBulkImportStatusCreated:
+			report.Created++
+		case configmodels.SubsBulkImportStatusValid:
+			report.Valid++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
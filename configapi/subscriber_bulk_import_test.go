@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/identity"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	bulkTestOpc = "8e27b6af0e692e750f32667a3b14605d"
+	bulkTestKey = "8baf473f2f8fd09487cccbd7097c6862"
+	bulkTestSqn = "16f3b3f70fc2"
+)
+
+type BulkImportMockDBClient struct {
+	dbadapter.DBInterface
+	existing       []string
+	failUeId       string
+	getManyErr     error
+	sessions       int
+	createdAuth    []string
+	createdAmData  []string
+	receivedFilter bson.M
+}
+
+func (db *BulkImportMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	db.receivedFilter = filter
+	if db.getManyErr != nil {
+		return nil, db.getManyErr
+	}
+	var results []map[string]any
+	for _, ueId := range db.existing {
+		results = append(results, map[string]any{"ueId": ueId})
+	}
+	return results, nil
+}
+
+func (db *BulkImportMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	db.sessions++
+	return nil, nil
+}
+
+func (db *BulkImportMockDBClient) RestfulAPIPostOnDB(ctx context.Context, dbName string, collName string, filter bson.M, postData map[string]any) (bool, error) {
+	if filter["ueId"] == db.failUeId {
+		return false, fmt.Errorf("mock error")
+	}
+	db.createdAuth = append(db.createdAuth, filter["ueId"].(string))
+	return true, nil
+}
+
+func (db *BulkImportMockDBClient) RestfulAPIPostWithContext(ctx context.Context, collName string, filter bson.M, postData map[string]any) (bool, error) {
+	db.createdAmData = append(db.createdAmData, filter["ueId"].(string))
+	return true, nil
+}
+
+func bulkImportRow(ueId string) configmodels.SubsBulkImportRow {
+	return configmodels.SubsBulkImportRow{
+		UeId:           ueId,
+		OPc:            bulkTestOpc,
+		Key:            bulkTestKey,
+		SequenceNumber: bulkTestSqn,
+		PlmnID:         "20893",
+	}
+}
+
+func TestParseSubscriberBulkImportCSV(t *testing.T) {
+	csvData := "ueId,opc,key,sqn,plmnID\n" +
+		"imsi-208930100007487," + bulkTestOpc + "," + bulkTestKey + "," + bulkTestSqn + ",20893\n" +
+		"imsi-208930100007488, " + bulkTestOpc + "," + bulkTestKey + "," + bulkTestSqn + ",\n"
+	rows, err := parseSubscriberBulkImportCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []configmodels.SubsBulkImportRow{
+		bulkImportRow("imsi-208930100007487"),
+		bulkImportRow("imsi-208930100007488"),
+	}
+	expected[1].PlmnID = ""
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i := range expected {
+		if rows[i] != expected[i] {
+			t.Errorf("expected row %+v, got %+v", expected[i], rows[i])
+		}
+	}
+}
+
+func TestParseSubscriberBulkImportCSVMissingColumn(t *testing.T) {
+	_, err := parseSubscriberBulkImportCSV(strings.NewReader("ueId,opc,key\nimsi-208930100007487,a,b\n"))
+	if err == nil || !strings.Contains(err.Error(), "missing column sqn") {
+		t.Errorf("expected missing column error, got %v", err)
+	}
+}
+
+func TestValidateSubscriberBulkImportRow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(row *configmodels.SubsBulkImportRow)
+		expected string
+	}{
+		{"valid row", func(row *configmodels.SubsBulkImportRow) {}, ""},
+		{"missing imsi prefix", func(row *configmodels.SubsBulkImportRow) { row.UeId = "208930100007487" }, ""},
+		{"malformed ueId", func(row *configmodels.SubsBulkImportRow) { row.UeId = "imsi-20893010000748a" }, "invalid ueId"},
+		{"unknown PLMN", func(row *configmodels.SubsBulkImportRow) { row.UeId = "imsi-310260000000001" }, "does not match any configured PLMN"},
+		{"short OPc", func(row *configmodels.SubsBulkImportRow) { row.OPc = "8e27" }, "invalid OPc"},
+		{"non hex key", func(row *configmodels.SubsBulkImportRow) { row.Key = strings.Repeat("z", 32) }, "invalid key"},
+		{"missing sqn", func(row *configmodels.SubsBulkImportRow) { row.SequenceNumber = "" }, "missing required authentication data"},
+		{"invalid sqn", func(row *configmodels.SubsBulkImportRow) { row.SequenceNumber = "16f3" }, "invalid sequence number"},
+		{"invalid PLMN", func(row *configmodels.SubsBulkImportRow) { row.PlmnID = "2089" }, "invalid plmnID"},
+		{"PLMN not matching the IMSI", func(row *configmodels.SubsBulkImportRow) { row.PlmnID = "20894" }, "invalid plmnID \"20894\""},
+		{"no PLMN", func(row *configmodels.SubsBulkImportRow) { row.PlmnID = "" }, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := bulkImportRow("imsi-208930100007487")
+			tc.modify(&row)
+			authSubsData, err := validateSubscriberBulkImportRow(&row, []identity.Plmn{{Mcc: "208", Mnc: "93"}})
+			if tc.expected == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.expected == "" && authSubsData.GetAuthenticationManagementField() != DEFAULT_AUTHENTICATION_MANAGEMENT_FIELD {
+				t.Errorf("expected the default authentication management field, got %+v", authSubsData)
+			}
+			if tc.expected == "" && row.UeId != "imsi-208930100007487" {
+				t.Errorf("expected normalized ueId imsi-208930100007487, got %s", row.UeId)
+			}
+			if tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)) {
+				t.Errorf("expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestImportSubscribers(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+
+	validRows := []configmodels.SubsBulkImportRow{
+		bulkImportRow("imsi-208930100007487"),
+		bulkImportRow("imsi-208930100007488"),
+		bulkImportRow("imsi-208930100007489"),
+	}
+	tests := []struct {
+		name            string
+		dbAdapter       *BulkImportMockDBClient
+		query           string
+		rows            []configmodels.SubsBulkImportRow
+		expectedCode    int
+		expectedStatus  []string
+		expectedCreated []string
+		expectedSession int
+	}{
+		{
+			name:            "All rows are created in a single transaction",
+			dbAdapter:       &BulkImportMockDBClient{},
+			rows:            validRows,
+			expectedCode:    http.StatusOK,
+			expectedStatus:  []string{"created", "created", "created"},
+			expectedCreated: []string{"imsi-208930100007487", "imsi-208930100007488", "imsi-208930100007489"},
+			expectedSession: 1,
+		},
+		{
+			name:            "Rows are created in chunks",
+			dbAdapter:       &BulkImportMockDBClient{},
+			query:           "?chunkSize=2",
+			rows:            validRows,
+			expectedCode:    http.StatusOK,
+			expectedStatus:  []string{"created", "created", "created"},
+			expectedCreated: []string{"imsi-208930100007487", "imsi-208930100007488", "imsi-208930100007489"},
+			expectedSession: 2,
+		},
+		{
+			name:           "Dry run does not write",
+			dbAdapter:      &BulkImportMockDBClient{},
+			query:          "?dryRun=true",
+			rows:           validRows,
+			expectedCode:   http.StatusOK,
+			expectedStatus: []string{"valid", "valid", "valid"},
+		},
+		{
+			name:      "Invalid, duplicated and existing rows are reported",
+			dbAdapter: &BulkImportMockDBClient{existing: []string{"imsi-208930100007489"}},
+			rows: []configmodels.SubsBulkImportRow{
+				bulkImportRow("imsi-208930100007487"),
+				bulkImportRow("imsi-208930100007487"),
+				{UeId: "imsi-208930100007488", OPc: "bad"},
+				bulkImportRow("imsi-208930100007489"),
+			},
+			expectedCode:    http.StatusOK,
+			expectedStatus:  []string{"created", "invalid", "invalid", "invalid"},
+			expectedCreated: []string{"imsi-208930100007487"},
+			expectedSession: 1,
+		},
+		{
+			name:            "Failed chunk is reported for all of its rows",
+			dbAdapter:       &BulkImportMockDBClient{failUeId: "imsi-208930100007489"},
+			query:           "?chunkSize=2",
+			rows:            validRows,
+			expectedCode:    http.StatusOK,
+			expectedStatus:  []string{"created", "created", "failed"},
+			expectedCreated: []string{"imsi-208930100007487", "imsi-208930100007488"},
+			expectedSession: 2,
+		},
+		{
+			name:         "Invalid chunk size is rejected",
+			dbAdapter:    &BulkImportMockDBClient{},
+			query:        "?chunkSize=-1",
+			rows:         validRows,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Empty request is rejected",
+			dbAdapter:    &BulkImportMockDBClient{},
+			rows:         []configmodels.SubsBulkImportRow{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "DB error returns internal server error",
+			dbAdapter:    &BulkImportMockDBClient{getManyErr: fmt.Errorf("mock error")},
+			rows:         validRows,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			AddApiService(router)
+			origDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = origDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+
+			body, err := json.Marshal(tc.rows)
+			if err != nil {
+				t.Fatalf("failed to marshal rows: %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/api/subscriber-import"+tc.query, strings.NewReader(string(body)))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v` (%s)", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			var report configmodels.SubsBulkImportReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to unmarshal report: %v", err)
+			}
+			if len(report.Results) != len(tc.expectedStatus) {
+				t.Fatalf("expected %d results, got %d", len(tc.expectedStatus), len(report.Results))
+			}
+			for i, status := range tc.expectedStatus {
+				if report.Results[i].Status != status {
+					t.Errorf("row %d: expected status %s, got %s (%s)", i+1, status, report.Results[i].Status, report.Results[i].Error)
+				}
+			}
+			if strings.Join(tc.dbAdapter.createdAmData, ",") != strings.Join(tc.expectedCreated, ",") {
+				t.Errorf("expected created subscribers %v, got %v", tc.expectedCreated, tc.dbAdapter.createdAmData)
+			}
+			if tc.dbAdapter.sessions != tc.expectedSession {
+				t.Errorf("expected %d transactions, got %d", tc.expectedSession, tc.dbAdapter.sessions)
+			}
+		})
+	}
+}
+
+func TestImportSubscribersCSV(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+	origDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = origDBClient }()
+	dbAdapter := &BulkImportMockDBClient{}
+	dbadapter.CommonDBClient = dbAdapter
+
+	csvData := "ueId,opc,key,sqn,plmnID\nimsi-208930100007487," + bulkTestOpc + "," + bulkTestKey + "," + bulkTestSqn + ",20893\n"
+	req, err := http.NewRequest(http.MethodPost, "/api/subscriber-import", strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	if len(dbAdapter.createdAuth) != 1 || dbAdapter.createdAuth[0] != "imsi-208930100007487" {
+		t.Errorf("expected subscriber imsi-208930100007487 to be created, got %v", dbAdapter.createdAuth)
+	}
+}
@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/auth"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	SUBSCRIBERS_EXPORT_PAGE_SIZE = 500
+
+	exportFormatJSONLines = "jsonl"
+	exportFormatCSV       = "csv"
+)
+
+var subscriberExportCsvColumns = []string{
+	"ueId", "plmnID", "deviceGroup", "networkSlice",
+	"authenticationMethod", "authenticationManagementField", "sqn", "opc", "key",
+	"AccessAndMobilitySubscriptionData", "SessionManagementSubscriptionData",
+	"SmfSelectionSubscriptionData", "AmPolicyData", "SmPolicyData",
+}
+
+// isAdminRequest reports whether the request was made by an admin user.
+// When authentication is disabled every request is considered to be made by an admin.
+func isAdminRequest(c *gin.Context) bool {
+	if factory.WebUIConfig == nil || factory.WebUIConfig.Configuration == nil || !factory.WebUIConfig.Configuration.EnableAuthentication {
+		return true
+	}
+	role, exists := c.Get(auth.RoleContextKey)
+	return exists && role == configmodels.AdminRole
+}
+
+// subscriberMembership resolves the device group of every IMSI and the network slices
+// of every device group, so that they are queried once for the whole export.
+type subscriberMembership struct {
+	deviceGroupByImsi map[string]string
+	imsiRanges        []deviceGroupImsiRange
+	slicesByGroup     map[string][]*configmodels.Slice
+}
+
+// networkSlicesOf returns the comma-separated names of the network slices of deviceGroup
+// serving plmnId
+func (m *subscriberMembership) networkSlicesOf(deviceGroup string, plmnId string) string {
+	var sliceNames []string
+	for _, slice := range m.slicesByGroup[deviceGroup] {
+		if plmnId == "" || slices.ContainsFunc(slice.SiteInfo.PlmnList(), func(plmn configmodels.SliceSiteInfoPlmn) bool {
+			return plmn.Mcc+plmn.Mnc == plmnId
+		}) {
+			sliceNames = append(sliceNames, slice.SliceName)
+		}
+	}
+	return strings.Join(sliceNames, ",")
+}
+
+// deviceGroupOf returns the device group of ueId, or an empty string if it belongs to none
+func (m *subscriberMembership) deviceGroupOf(ueId string) string {
+	if deviceGroup, exists := m.deviceGroupByImsi[ueId]; exists {
+		return deviceGroup
+	}
+	imsi, isImsi := strings.CutPrefix(ueId, "imsi-")
+	if !isImsi {
+		return ""
+	}
+	return deviceGroupOfImsiRanges(m.imsiRanges, imsi)
+}
+
+func getSubscriberMembership() (*subscriberMembership, error) {
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve device groups: %w", err)
+	}
+	membership := &subscriberMembership{
+		deviceGroupByImsi: make(map[string]string),
+		slicesByGroup:     make(map[string][]*configmodels.Slice),
+	}
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		var deviceGroup configmodels.DeviceGroups
+		if err := json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
+			logger.DbLog.Errorf("could not unmarshal device group %v: %+v", rawDeviceGroup, err)
+			continue
+		}
+		for _, imsi := range deviceGroup.Imsis {
+			if _, exists := membership.deviceGroupByImsi["imsi-"+imsi]; !exists {
+				membership.deviceGroupByImsi["imsi-"+imsi] = deviceGroup.DeviceGroupName
+			}
+		}
+		membership.imsiRanges = appendDeviceGroupImsiRanges(membership.imsiRanges, &deviceGroup)
+		membership.slicesByGroup[deviceGroup.DeviceGroupName] = findSlicesByDeviceGroup(deviceGroup.DeviceGroupName)
+	}
+	return membership, nil
+}
+
+// getManyByUeIds returns the documents of a collection belonging to the given UE IDs, grouped by UE ID.
+func getManyByUeIds(client dbadapter.DBInterface, collName string, ueIds []string) (map[string][]map[string]any, error) {
+	documents, err := client.RestfulAPIGetMany(collName, bson.M{"ueId": bson.M{"$in": ueIds}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s: %w", collName, err)
+	}
+	byUeId := make(map[string][]map[string]any)
+	for _, document := range documents {
+		if ueId, ok := document["ueId"].(string); ok {
+			byUeId[ueId] = append(byUeId[ueId], document)
+		}
+	}
+	return byUeId, nil
+}
+
+func unmarshalFirst(documents []map[string]any, v any) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	return json.Unmarshal(configmodels.MapToByte(documents[0]), v)
+}
+
+// getSubscribersExportPage builds the export records of a page of amData documents
+// reading every subscription collection once for the whole page.
+func getSubscribersExportPage(amDataList []map[string]any, membership *subscriberMembership, includeKeys bool) ([]configmodels.SubsExportData, error) {
+	ueIds := make([]string, 0, len(amDataList))
+	for _, amData := range amDataList {
+		if ueId, ok := amData["ueId"].(string); ok {
+			ueIds = append(ueIds, ueId)
+		}
+	}
+	authData, err := getManyByUeIds(dbadapter.AuthDBClient, authSubsDataColl, ueIds)
+	if err != nil {
+		return nil, err
+	}
+	collections := []string{smDataColl, smfSelDataColl, amPolicyDataColl, smPolicyDataColl}
+	commonData := make(map[string]map[string][]map[string]any, len(collections))
+	for _, collName := range collections {
+		if commonData[collName], err = getManyByUeIds(dbadapter.CommonDBClient, collName, ueIds); err != nil {
+			return nil, err
+		}
+	}
+
+	records := make([]configmodels.SubsExportData, 0, len(amDataList))
+	for _, amData := range amDataList {
+		ueId, ok := amData["ueId"].(string)
+		if !ok {
+			continue
+		}
+		record := configmodels.SubsExportData{UeId: ueId}
+		record.PlmnID, _ = amData["servingPlmnId"].(string)
+		record.DeviceGroup = membership.deviceGroupOf(ueId)
+		record.NetworkSlice = membership.networkSlicesOf(record.DeviceGroup, record.PlmnID)
+
+		if err := json.Unmarshal(configmodels.MapToByte(amData), &record.AccessAndMobilitySubscriptionData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal am data of %s: %w", ueId, err)
+		}
+		if len(authData[ueId]) > 0 {
+			record.AuthenticationSubscription = &models.AuthenticationSubscription{}
+			if err := unmarshalFirst(authData[ueId], record.AuthenticationSubscription); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal authentication subscription of %s: %w", ueId, err)
+			}
+			if !includeKeys {
+				record.AuthenticationSubscription.EncOpcKey = nil
+				record.AuthenticationSubscription.EncPermanentKey = nil
+				record.AuthenticationSubscription.EncTopcKey = nil
+			} else if err := decryptAuthenticationSubscription(record.AuthenticationSubscription); err != nil {
+				return nil, fmt.Errorf("failed to decrypt authentication subscription of %s: %w", ueId, err)
+			}
+		}
+		if smData := commonData[smDataColl][ueId]; len(smData) > 0 {
+			bytesData, err := sliceToByte(smData)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(bytesData, &record.SessionManagementSubscriptionData); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal sm data of %s: %w", ueId, err)
+			}
+		}
+		if err := unmarshalFirst(commonData[smfSelDataColl][ueId], &record.SmfSelectionSubscriptionData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal smf selection data of %s: %w", ueId, err)
+		}
+		if err := unmarshalFirst(commonData[amPolicyDataColl][ueId], &record.AmPolicyData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal am policy data of %s: %w", ueId, err)
+		}
+		if err := unmarshalFirst(commonData[smPolicyDataColl][ueId], &record.SmPolicyData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sm policy data of %s: %w", ueId, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func subscriberExportCsvRow(record configmodels.SubsExportData) ([]string, error) {
+	row := []string{record.UeId, record.PlmnID, record.DeviceGroup, record.NetworkSlice}
+	if authSubs := record.AuthenticationSubscription; authSubs != nil {
+		sqn := ""
+		if authSubs.SequenceNumber != nil {
+			sqn = authSubs.SequenceNumber.GetSqn()
+		}
+		row = append(row, string(authSubs.AuthenticationMethod), authSubs.GetAuthenticationManagementField(), sqn,
+			authSubs.GetEncOpcKey(), authSubs.GetEncPermanentKey())
+	} else {
+		row = append(row, "", "", "", "", "")
+	}
+	sections := []any{
+		record.AccessAndMobilitySubscriptionData, record.SessionManagementSubscriptionData,
+		record.SmfSelectionSubscriptionData, record.AmPolicyData, record.SmPolicyData,
+	}
+	for _, section := range sections {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, string(data))
+	}
+	return row, nil
+}
+
+// subscriberExportWriter writes export records in JSON Lines or CSV format.
+type subscriberExportWriter struct {
+	format     string
+	csvWriter  *csv.Writer
+	jsonWriter *json.Encoder
+}
+
+func newSubscriberExportWriter(w io.Writer, format string) (*subscriberExportWriter, error) {
+	writer := &subscriberExportWriter{format: format}
+	if format == exportFormatCSV {
+		writer.csvWriter = csv.NewWriter(w)
+		if err := writer.csvWriter.Write(subscriberExportCsvColumns); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	}
+	writer.jsonWriter = json.NewEncoder(w)
+	return writer, nil
+}
+
+func (writer *subscriberExportWriter) write(record configmodels.SubsExportData) error {
+	if writer.format != exportFormatCSV {
+		return writer.jsonWriter.Encode(record)
+	}
+	row, err := subscriberExportCsvRow(record)
+	if err != nil {
+		return err
+	}
+	return writer.csvWriter.Write(row)
+}
+
+func (writer *subscriberExportWriter) flush() error {
+	if writer.csvWriter == nil {
+		return nil
+	}
+	writer.csvWriter.Flush()
+	return writer.csvWriter.Error()
+}
+
+// streamSubscribersExport writes every subscriber, one page of amData documents at a
+// time, flushing the response after each page. It returns the number of exported subscribers.
+func streamSubscribersExport(c *gin.Context, writer *subscriberExportWriter, membership *subscriberMembership, includeKeys bool) (int, error) {
+	exported := 0
+	query := subscriberListQuery{sortField: "ueId", limit: SUBSCRIBERS_EXPORT_PAGE_SIZE}
+	for {
+		filter := bson.M{}
+		if query.cursor != nil {
+			filter = subscriberListCursorFilter(query)
+		}
+		amDataList, err := dbadapter.CommonDBClient.RestfulAPIGetManyPaged(amDataColl, filter, subscriberListSort(query), query.limit)
+		if err != nil {
+			return exported, fmt.Errorf("failed to retrieve subscribers: %w", err)
+		}
+		if len(amDataList) == 0 {
+			return exported, nil
+		}
+		records, err := getSubscribersExportPage(amDataList, membership, includeKeys)
+		if err != nil {
+			return exported, err
+		}
+		for _, record := range records {
+			if err := writer.write(record); err != nil {
+				return exported, fmt.Errorf("failed to write subscriber %s: %w", record.UeId, err)
+			}
+			exported++
+		}
+		if err := writer.flush(); err != nil {
+			return exported, err
+		}
+		c.Writer.Flush()
+		if int64(len(amDataList)) < query.limit {
+			return exported, nil
+		}
+		cursor := subscriberListCursorOf(amDataList[len(amDataList)-1])
+		if cursor.UeId == "" {
+			return exported, fmt.Errorf("subscriber without ueId in amData")
+		}
+		query.cursor = &cursor
+	}
+}
+
+func parseSubscriberExportFormat(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "", exportFormatJSONLines:
+		return exportFormatJSONLines, nil
+	case exportFormatCSV:
+		return exportFormatCSV, nil
+	default:
+		return "", fmt.Errorf("invalid format %q: expected %s or %s", value, exportFormatJSONLines, exportFormatCSV)
+	}
+}
@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/auth"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ExportSubscribersMockDBClient struct {
+	dbadapter.DBInterface
+	collections  map[string][]map[string]any
+	pagedCalls   int
+	amDataPages  [][]map[string]any
+	pagedFilters []bson.M
+	pagedSorts   []bson.D
+}
+
+func (db *ExportSubscribersMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	return db.collections[coll], nil
+}
+
+func (db *ExportSubscribersMockDBClient) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	db.pagedCalls++
+	if db.amDataPages != nil {
+		db.pagedFilters = append(db.pagedFilters, filter)
+		db.pagedSorts = append(db.pagedSorts, sort)
+		page := db.amDataPages[0]
+		db.amDataPages = db.amDataPages[1:]
+		return page, nil
+	}
+	if len(filter) > 0 {
+		return nil, nil
+	}
+	return db.collections[coll], nil
+}
+
+func newExportSubscribersMockDBClient() *ExportSubscribersMockDBClient {
+	slice := configmodels.Slice{
+		SliceName:       "slice1",
+		SiteDeviceGroup: []string{"group1"},
+		SiteInfo:        configmodels.SliceSiteInfo{Plmn: configmodels.SliceSiteInfoPlmn{Mcc: "208", Mnc: "93"}},
+	}
+	return &ExportSubscribersMockDBClient{
+		collections: map[string][]map[string]any{
+			amDataColl: {
+				{"ueId": "imsi-208930100007487", "servingPlmnId": "20893"},
+				{"ueId": "imsi-208930100007488"},
+			},
+			authSubsDataColl: {
+				{
+					"ueId":                          "imsi-208930100007487",
+					"authenticationMethod":          "5G_AKA",
+					"authenticationManagementField": "8000",
+					"encOpcKey":                     "8e27b6af0e692e750f32667a3b14605d",
+					"encPermanentKey":               "8baf473f2f8fd09487cccbd7097c6862",
+					"sequenceNumber":                map[string]any{"sqn": "16f3b3f70fc2"},
+				},
+			},
+			devGroupDataColl: {configmodels.ToBsonM(deviceGroupWithImsis("group1", []string{"208930100007487"}))},
+			sliceDataColl:    {configmodels.ToBsonM(slice)},
+			smDataColl: {
+				{"ueId": "imsi-208930100007487", "singleNssai": map[string]any{"sst": 1, "sd": "010203"}},
+			},
+		},
+	}
+}
+
+func performExportRequest(t *testing.T, query string, role *int) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	if role != nil {
+		AddApiService(router, func(c *gin.Context) {
+			c.Set(auth.RoleContextKey, *role)
+			c.Next()
+		})
+	} else {
+		AddApiService(router)
+	}
+	req, err := http.NewRequest(http.MethodGet, "/api/subscriber-export"+query, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestExportSubscribersJSONLines(t *testing.T) {
+	origDBClient := dbadapter.CommonDBClient
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = origDBClient
+		dbadapter.AuthDBClient = origAuthDBClient
+	}()
+	dbAdapter := newExportSubscribersMockDBClient()
+	dbadapter.CommonDBClient = dbAdapter
+	dbadapter.AuthDBClient = dbAdapter
+
+	w := performExportRequest(t, "", nil)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("expected content type application/x-ndjson, got %s", ct)
+	}
+	var records []configmodels.SubsExportData
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var record configmodels.SubsExportData
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("failed to unmarshal line %s: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	first := records[0]
+	if first.UeId != "imsi-208930100007487" || first.PlmnID != "20893" || first.DeviceGroup != "group1" || first.NetworkSlice != "slice1" {
+		t.Errorf("unexpected record %+v", first)
+	}
+	if first.AuthenticationSubscription == nil {
+		t.Fatalf("expected authentication subscription to be exported")
+	}
+	if first.AuthenticationSubscription.EncOpcKey != nil || first.AuthenticationSubscription.EncPermanentKey != nil {
+		t.Errorf("expected keys to be redacted")
+	}
+	if first.AuthenticationSubscription.GetAuthenticationManagementField() != "8000" {
+		t.Errorf("expected authentication metadata to be exported")
+	}
+	if len(first.SessionManagementSubscriptionData) != 1 {
+		t.Errorf("expected 1 session management subscription, got %d", len(first.SessionManagementSubscriptionData))
+	}
+	if records[1].AuthenticationSubscription != nil || records[1].DeviceGroup != "" || records[1].NetworkSlice != "" {
+		t.Errorf("expected subscriber without device group, got %+v", records[1])
+	}
+	if dbAdapter.pagedCalls != 1 {
+		t.Errorf("expected 1 page query, got %d", dbAdapter.pagedCalls)
+	}
+}
+
+func TestExportSubscribersCSVWithKeys(t *testing.T) {
+	origDBClient := dbadapter.CommonDBClient
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = origDBClient
+		dbadapter.AuthDBClient = origAuthDBClient
+	}()
+	dbAdapter := newExportSubscribersMockDBClient()
+	dbadapter.CommonDBClient = dbAdapter
+	dbadapter.AuthDBClient = dbAdapter
+
+	w := performExportRequest(t, "?format=csv&includeKeys=true", nil)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d rows", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(subscriberExportCsvColumns, ",") {
+		t.Errorf("unexpected header %v", rows[0])
+	}
+	expected := []string{"imsi-208930100007487", "20893", "group1", "slice1", "5G_AKA", "8000", "16f3b3f70fc2", "8e27b6af0e692e750f32667a3b14605d", "8baf473f2f8fd09487cccbd7097c6862"}
+	if strings.Join(rows[1][:len(expected)], ",") != strings.Join(expected, ",") {
+		t.Errorf("expected row %v, got %v", expected, rows[1][:len(expected)])
+	}
+}
+
+func TestExportSubscribersKeysRequireAdmin(t *testing.T) {
+	origConfig := factory.WebUIConfig
+	defer func() { factory.WebUIConfig = origConfig }()
+	factory.WebUIConfig = &factory.Config{
+		Configuration: &factory.Configuration{EnableAuthentication: true},
+	}
+	origDBClient := dbadapter.CommonDBClient
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = origDBClient
+		dbadapter.AuthDBClient = origAuthDBClient
+	}()
+	dbAdapter := newExportSubscribersMockDBClient()
+	dbadapter.CommonDBClient = dbAdapter
+	dbadapter.AuthDBClient = dbAdapter
+
+	userRole := configmodels.UserRole
+	w := performExportRequest(t, "?includeKeys=true", &userRole)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected `%v`, got `%v`", http.StatusForbidden, w.Code)
+	}
+
+	adminRole := configmodels.AdminRole
+	w = performExportRequest(t, "?includeKeys=true", &adminRole)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "8baf473f2f8fd09487cccbd7097c6862") {
+		t.Errorf("expected keys to be exported for admin")
+	}
+}
+
+func TestExportSubscribersInvalidFormat(t *testing.T) {
+	w := performExportRequest(t, "?format=xml", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestExportSubscribersPagesBetweenPlmnsOfASubscriber(t *testing.T) {
+	origDBClient := dbadapter.CommonDBClient
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = origDBClient
+		dbadapter.AuthDBClient = origAuthDBClient
+	}()
+	firstPage := []map[string]any{}
+	for i := range SUBSCRIBERS_EXPORT_PAGE_SIZE - 1 {
+		firstPage = append(firstPage, map[string]any{"ueId": fmt.Sprintf("imsi-20893010000%04d", i), "servingPlmnId": "20893"})
+	}
+	firstPage = append(firstPage, map[string]any{"ueId": "imsi-208930100009999", "servingPlmnId": "20893"})
+	secondPage := []map[string]any{{"ueId": "imsi-208930100009999", "servingPlmnId": "20894"}}
+	dbAdapter := newExportSubscribersMockDBClient()
+	dbAdapter.amDataPages = [][]map[string]any{firstPage, secondPage}
+	dbadapter.CommonDBClient = dbAdapter
+	dbadapter.AuthDBClient = dbAdapter
+
+	w := performExportRequest(t, "", nil)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	if lines := strings.Count(w.Body.String(), "\n"); lines != SUBSCRIBERS_EXPORT_PAGE_SIZE+1 {
+		t.Errorf("expected %d records, got %d", SUBSCRIBERS_EXPORT_PAGE_SIZE+1, lines)
+	}
+	expectedSort := bson.D{{Key: "ueId", Value: 1}, {Key: "servingPlmnId", Value: 1}}
+	if !reflect.DeepEqual(dbAdapter.pagedSorts[0], expectedSort) {
+		t.Errorf("expected sort %v, got %v", expectedSort, dbAdapter.pagedSorts[0])
+	}
+	expectedFilter := bson.M{"$or": []bson.M{
+		{"ueId": bson.M{"$gt": "imsi-208930100009999"}},
+		{"ueId": "imsi-208930100009999", "servingPlmnId": bson.M{"$gt": "20893"}},
+	}}
+	if len(dbAdapter.pagedFilters) != 2 || !reflect.DeepEqual(dbAdapter.pagedFilters[1], expectedFilter) {
+		t.Errorf("expected second page filter %v, got %v", expectedFilter, dbAdapter.pagedFilters)
+	}
+}
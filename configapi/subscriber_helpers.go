@@ -5,17 +5,28 @@ package configapi
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
-	"net/http"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/omec-project/openapi/v2"
 	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/util/milenage"
+	"github.com/omec-project/webconsole/backend/encryption"
 	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// errSubscriberNotFound is returned when a subscriber does not exist
+var errSubscriberNotFound = errors.New("subscriber not found")
+
 func subscriberAuthenticationDataGet(imsi string) (authSubData *models.AuthenticationSubscription) {
 	filter := bson.M{"ueId": imsi}
 	authSubDataInterface, err := dbadapter.AuthDBClient.RestfulAPIGetOne(authSubsDataColl, filter)
@@ -31,71 +42,264 @@ func subscriberAuthenticationDataGet(imsi string) (authSubData *models.Authentic
 	return authSubData
 }
 
+const DEFAULT_AUTHENTICATION_MANAGEMENT_FIELD = "8000"
+
+// newAuthenticationSubscription builds the authentication subscription of a provisioning
+// request. Either the OPc or the OP must be provided: the OPc is derived from the OP and
+// the key when only the OP is known.
+func newAuthenticationSubscription(subsOverrideData configmodels.SubsOverrideData) (*models.AuthenticationSubscription, error) {
+	if subsOverrideData.Key == "" || subsOverrideData.SequenceNumber == "" || (subsOverrideData.OPc == "" && subsOverrideData.OP == "") {
+		return nil, fmt.Errorf("missing required authentication data: Key, Sequence number and OPc or OP must be provided")
+	}
+	if subsOverrideData.OPc != "" && subsOverrideData.OP != "" {
+		return nil, fmt.Errorf("only one of OPc and OP can be provided")
+	}
+	if !isValidAuthKey(subsOverrideData.Key) {
+		return nil, fmt.Errorf("invalid key: expected %d hexadecimal characters", AUTH_KEY_HEX_LENGTH)
+	}
+	if !isValidSqn(subsOverrideData.SequenceNumber) {
+		return nil, fmt.Errorf("invalid sequence number: expected %d hexadecimal characters", SQN_HEX_LENGTH)
+	}
+	method := subsOverrideData.AuthenticationMethod
+	if method == "" {
+		method = string(models.AUTHMETHOD__5_G_AKA)
+	}
+	if !isValidAuthenticationMethod(method) {
+		return nil, fmt.Errorf("invalid authentication method %q: expected %s or %s", method, models.AUTHMETHOD__5_G_AKA, models.AUTHMETHOD_EAP_AKA_PRIME)
+	}
+	amf := subsOverrideData.AuthenticationManagementField
+	if amf == "" {
+		amf = DEFAULT_AUTHENTICATION_MANAGEMENT_FIELD
+	}
+	if !isValidAmf(amf) {
+		return nil, fmt.Errorf("invalid authentication management field: expected %d hexadecimal characters", AMF_HEX_LENGTH)
+	}
+	opc := subsOverrideData.OPc
+	if opc != "" && !isValidAuthKey(opc) {
+		return nil, fmt.Errorf("invalid OPc: expected %d hexadecimal characters", AUTH_KEY_HEX_LENGTH)
+	}
+	if subsOverrideData.OP != "" {
+		if !isValidAuthKey(subsOverrideData.OP) {
+			return nil, fmt.Errorf("invalid OP: expected %d hexadecimal characters", AUTH_KEY_HEX_LENGTH)
+		}
+		var err error
+		if opc, err = deriveOpc(subsOverrideData.Key, subsOverrideData.OP); err != nil {
+			return nil, err
+		}
+	}
+	return &models.AuthenticationSubscription{
+		AuthenticationManagementField: openapi.PtrString(amf),
+		AuthenticationMethod:          models.AuthMethod(method),
+		EncOpcKey:                     openapi.PtrString(opc),
+		EncPermanentKey:               openapi.PtrString(subsOverrideData.Key),
+		SequenceNumber: &models.SequenceNumber{
+			Sqn: openapi.PtrString(subsOverrideData.SequenceNumber),
+		},
+	}, nil
+}
+
+// deriveOpc computes the OPc from the hex encoded key and OP as specified in TS 35.206.
+func deriveOpc(key, op string) (string, error) {
+	k, err := hex.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid key: %w", err)
+	}
+	opBytes, err := hex.DecodeString(op)
+	if err != nil {
+		return "", fmt.Errorf("invalid OP: %w", err)
+	}
+	opc, err := milenage.GenerateOPC(k, opBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive OPc: %w", err)
+	}
+	return hex.EncodeToString(opc), nil
+}
+
 func subscriberAuthenticationDataCreate(imsi string, authSubData *models.AuthenticationSubscription) error {
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	return sessionRunner(context.TODO(), func(sc context.Context) error {
+		return subscriberAuthenticationDataCreateWithContext(sc, imsi, authSubData)
+	})
+}
+
+// subscriberAuthenticationDataCreateWithContext writes the authentication subscription
+// and the basic amData of a subscriber using the provided (session) context, so that
+// several subscribers can be created within the same transaction.
+func subscriberAuthenticationDataCreateWithContext(sc context.Context, imsi string, authSubData *models.AuthenticationSubscription) error {
 	filter := bson.M{"ueId": imsi}
-	logger.WebUILog.Infof("%+v", authSubData)
-	authDataBsonA := configmodels.ToBsonM(authSubData)
+	encryptedAuthSubData, err := encryptAuthenticationSubscription(authSubData)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to encrypt authentication subscription of %s: %+v", imsi, err)
+		return err
+	}
+	authDataBsonA := configmodels.ToBsonM(encryptedAuthSubData)
 	authDataBsonA["ueId"] = imsi
 	basicAmData := map[string]any{"ueId": imsi}
 	basicDataBson := configmodels.ToBsonM(basicAmData)
 	authDbName := factory.WebUIConfig.Configuration.Mongodb.AuthKeysDbName
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPostOnDB(sc, authDbName, authSubsDataColl, filter, authDataBsonA); err != nil {
+		logger.DbLog.Errorf("failed to create authentication subscription error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Infof("created authentication subscription in authenticationSubscription collection: %s", imsi)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPostWithContext(sc, amDataColl, filter, basicDataBson); err != nil {
+		logger.DbLog.Errorf("failed to create amData error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Infof("successfully created authentication subscription in amData collection: %s", imsi)
+	return nil
+}
+
+func subscriberAuthenticationDataUpdate(imsi string, authSubData *models.AuthenticationSubscription) error {
 	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
 	return sessionRunner(context.TODO(), func(sc context.Context) error {
-		if _, err := dbadapter.CommonDBClient.RestfulAPIPostOnDB(sc, authDbName, authSubsDataColl, filter, authDataBsonA); err != nil {
-			logger.DbLog.Errorf("failed to create authentication subscription error: %+v", err)
-			return err
-		}
-		logger.WebUILog.Infof("created authentication subscription in authenticationSubscription collection: %s", imsi)
-		if _, err := dbadapter.CommonDBClient.RestfulAPIPostWithContext(sc, amDataColl, filter, basicDataBson); err != nil {
-			logger.DbLog.Errorf("failed to create amData error: %+v", err)
-			return err
-		}
-		logger.WebUILog.Infof("successfully created authentication subscription in amData collection: %s", imsi)
-		return nil
+		return subscriberAuthenticationDataUpdateWithContext(sc, imsi, authSubData)
 	})
 }
 
-func subscriberAuthenticationDataUpdate(imsi string, authSubData *models.AuthenticationSubscription) error {
+// subscriberAuthenticationDataUpdateWithContext updates the authentication subscription
+// of a subscriber within the transaction of sc.
+func subscriberAuthenticationDataUpdateWithContext(sc context.Context, imsi string, authSubData *models.AuthenticationSubscription) error {
 	filter := bson.M{"ueId": imsi}
-	authDataBsonA := configmodels.ToBsonM(authSubData)
+	encryptedAuthSubData, err := encryptAuthenticationSubscription(authSubData)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to encrypt authentication subscription of %s: %+v", imsi, err)
+		return err
+	}
+	authDataBsonA := configmodels.ToBsonM(encryptedAuthSubData)
 	authDataBsonA["ueId"] = imsi
 	basicAmData := map[string]any{"ueId": imsi}
 	basicDataBson := configmodels.ToBsonM(basicAmData)
 	authDbName := factory.WebUIConfig.Configuration.Mongodb.AuthKeysDbName
-	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
-	return sessionRunner(context.TODO(), func(sc context.Context) error {
-		if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneOnDB(sc, authDbName, authSubsDataColl, filter, authDataBsonA); err != nil {
-			logger.DbLog.Errorf("failed to update authentication subscription error: %+v", err)
-			return err
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneOnDB(sc, authDbName, authSubsDataColl, filter, authDataBsonA); err != nil {
+		logger.DbLog.Errorf("failed to update authentication subscription error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Debugf("updated authentication subscription in authenticationSubscription collection: %s", imsi)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneWithContext(sc, amDataColl, filter, basicDataBson); err != nil {
+		logger.DbLog.Errorf("failed to update amData error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Debugf("successfully updated authentication subscription in amData collection: %s", imsi)
+	return nil
+}
+
+// authSubscriptionPatchableFields maps the JSON pointer of every authentication
+// subscription field that can be changed with a PATCH request to its validator.
+var authSubscriptionPatchableFields = map[string]func(string) bool{
+	"/encOpcKey":                     isValidAuthKey,
+	"/encPermanentKey":               isValidAuthKey,
+	"/sequenceNumber/sqn":            isValidSqn,
+	"/authenticationManagementField": isValidAmf,
+	"/authenticationMethod":          isValidAuthenticationMethod,
+}
+
+func validateAuthSubscriptionPatchValue(path string, value any) error {
+	validator, ok := authSubscriptionPatchableFields[path]
+	if !ok {
+		return fmt.Errorf("path %s cannot be patched", path)
+	}
+	if value == nil {
+		return fmt.Errorf("path %s cannot be removed", path)
+	}
+	strValue, ok := value.(string)
+	if !ok || !validator(strValue) {
+		return fmt.Errorf("invalid value for path %s", path)
+	}
+	return nil
+}
+
+func validateAuthSubscriptionMergePatch(patchData map[string]any) error {
+	if len(patchData) == 0 {
+		return fmt.Errorf("merge patch document is empty")
+	}
+	for field, value := range patchData {
+		if field != "sequenceNumber" {
+			if err := validateAuthSubscriptionPatchValue("/"+field, value); err != nil {
+				return err
+			}
+			continue
 		}
-		logger.WebUILog.Debugf("updated authentication subscription in authenticationSubscription collection: %s", imsi)
-		if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneWithContext(sc, amDataColl, filter, basicDataBson); err != nil {
-			logger.DbLog.Errorf("failed to update amData error: %+v", err)
-			return err
+		sequenceNumber, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid value for path /sequenceNumber")
 		}
-		logger.WebUILog.Debugf("successfully updated authentication subscription in amData collection: %s", imsi)
-		return nil
-	})
+		for subField, subValue := range sequenceNumber {
+			if err := validateAuthSubscriptionPatchValue("/sequenceNumber/"+subField, subValue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateAuthSubscriptionJSONPatch(patchOperations []dbadapter.PatchOperation) error {
+	if len(patchOperations) == 0 {
+		return fmt.Errorf("JSON patch document is empty")
+	}
+	for _, operation := range patchOperations {
+		switch operation.Op {
+		case "add", "replace":
+			if err := validateAuthSubscriptionPatchValue(operation.Path, operation.Value); err != nil {
+				return err
+			}
+		case "test":
+			if _, ok := authSubscriptionPatchableFields[operation.Path]; !ok {
+				return fmt.Errorf("path %s cannot be tested", operation.Path)
+			}
+			if encryption.SubscriberKeyRing != nil && slices.Contains(authSubscriptionSecretFields, strings.TrimPrefix(operation.Path, "/")) {
+				return fmt.Errorf("path %s is encrypted and cannot be tested", operation.Path)
+			}
+		default:
+			return fmt.Errorf("unsupported JSON patch operation %s", operation.Op)
+		}
+	}
+	return nil
 }
 
-func subscriberAuthenticationDataDelete(imsi string) error {
-	logger.WebUILog.Debugf("delete authentication subscription from authenticationSubscription collection: %s", imsi)
+func subscriberAuthenticationDataMergePatch(imsi string, patchData map[string]any) error {
 	filter := bson.M{"ueId": imsi}
-	authDbName := factory.WebUIConfig.Configuration.Mongodb.AuthKeysDbName
-	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
-	return sessionRunner(context.TODO(), func(sc context.Context) error {
-		if err := dbadapter.CommonDBClient.RestfulAPIDeleteOneOnDB(sc, authDbName, authSubsDataColl, filter); err != nil {
-			logger.DbLog.Errorf("failed to delete authentication subscription: %+v", err)
+	encryptedPatchData := make(map[string]any, len(patchData))
+	for field, value := range patchData {
+		encryptedValue, err := encryptAuthSubscriptionPatchValue("/"+field, value)
+		if err != nil {
+			logger.WebUILog.Errorf("failed to encrypt authentication subscription of %s: %+v", imsi, err)
 			return err
 		}
-		logger.WebUILog.Debugf("successfully deleted authentication subscription from authenticationSubscription collection: %v", imsi)
-		if err := dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, amDataColl, filter); err != nil {
-			logger.DbLog.Errorf("failed to delete amData: %+v", err)
-			return err
+		encryptedPatchData[field] = encryptedValue
+	}
+	if err := dbadapter.AuthDBClient.RestfulAPIMergePatch(authSubsDataColl, filter, encryptedPatchData); err != nil {
+		logger.DbLog.Errorf("failed to merge patch authentication subscription error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Debugf("merge patched authentication subscription in authenticationSubscription collection: %s", imsi)
+	return nil
+}
+
+func subscriberAuthenticationDataJSONPatch(ctx context.Context, imsi string, patchOperations []dbadapter.PatchOperation) error {
+	filter := bson.M{"ueId": imsi}
+	encryptedOperations := make([]dbadapter.PatchOperation, 0, len(patchOperations))
+	for _, operation := range patchOperations {
+		if operation.Op != "test" {
+			encryptedValue, err := encryptAuthSubscriptionPatchValue(operation.Path, operation.Value)
+			if err != nil {
+				logger.WebUILog.Errorf("failed to encrypt authentication subscription of %s: %+v", imsi, err)
+				return err
+			}
+			operation.Value = encryptedValue
 		}
-		logger.WebUILog.Debugf("successfully deleted authentication subscription from amData collection: %s", imsi)
-		return nil
-	})
+		encryptedOperations = append(encryptedOperations, operation)
+	}
+	patchJSON, err := json.Marshal(encryptedOperations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON patch: %w", err)
+	}
+	if err := dbadapter.AuthDBClient.RestfulAPIJSONPatchWithContext(ctx, authSubsDataColl, filter, patchJSON); err != nil {
+		logger.DbLog.Errorf("failed to JSON patch authentication subscription error: %+v", err)
+		return err
+	}
+	logger.WebUILog.Debugf("JSON patched authentication subscription in authenticationSubscription collection: %s", imsi)
+	return nil
 }
 
 func getDeletedImsisList(group, prevGroup *configmodels.DeviceGroups) (dimsis []string) {
@@ -108,13 +312,7 @@ func getDeletedImsisList(group, prevGroup *configmodels.DeviceGroups) (dimsis []
 	}
 
 	for _, pimsi := range prevGroup.Imsis {
-		var found bool
-		for _, imsi := range group.Imsis {
-			if pimsi == imsi {
-				found = true
-			}
-		}
-		if !found {
+		if !group.ContainsImsi(pimsi) {
 			dimsis = append(dimsis, pimsi)
 		}
 	}
@@ -139,62 +337,169 @@ func removeSubscriberEntriesRelatedToDeviceGroups(mcc, mnc, imsi string) error {
 			logger.DbLog.Errorf("failed to delete SM policy data for IMSI %s: %+v", imsi, err)
 			return err
 		}
-		// AM data
-		err = dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, amDataColl, filter)
+		return deleteSubscriberPlmnEntries(sc, filter, imsi)
+	})
+	if err != nil {
+		logger.DbLog.Errorf("failed to delete subscriber entries related to device groups for IMSI %s: %+v", imsi, err)
+		return err
+	}
+	logger.DbLog.Debugf("succeeded to delete subscriber entries related to device groups for IMSI %s", imsi)
+	return nil
+}
+
+// removeSubscriberPlmnEntries deletes the provisioned data of imsi for a PLMN none of the
+// network slices of its device group belong to any more. The policy data is kept.
+func removeSubscriberPlmnEntries(mcc, mnc, imsi string) error {
+	filter := bson.M{"ueId": "imsi-" + imsi, "servingPlmnId": mcc + mnc}
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	err := sessionRunner(context.TODO(), func(sc context.Context) error {
+		return deleteSubscriberPlmnEntries(sc, filter, imsi)
+	})
+	if err != nil {
+		logger.DbLog.Errorf("failed to delete subscriber entries of PLMN %s%s for IMSI %s: %+v", mcc, mnc, imsi, err)
+		return err
+	}
+	logger.DbLog.Debugf("succeeded to delete subscriber entries of PLMN %s%s for IMSI %s", mcc, mnc, imsi)
+	return nil
+}
+
+// deleteSubscriberPlmnEntries deletes the AM, SM and SMF selection data matching filter, a
+// filter on the UE ID and serving PLMN. There is an SM data document per S-NSSAI.
+func deleteSubscriberPlmnEntries(sc context.Context, filter bson.M, imsi string) error {
+	// AM data
+	err := dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, amDataColl, filter)
+	if err != nil {
+		logger.DbLog.Errorf("failed to delete AM data for IMSI %s: %+v", imsi, err)
+		return err
+	}
+	// SM data
+	err = dbadapter.CommonDBClient.RestfulAPIDeleteManyWithContext(sc, smDataColl, filter)
+	if err != nil {
+		logger.DbLog.Errorf("failed to delete SM data for IMSI %s: %+v", imsi, err)
+		return err
+	}
+	// SMF selection
+	err = dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, smfSelDataColl, filter)
+	if err != nil {
+		logger.DbLog.Errorf("failed to delete SMF selection data for IMSI %s: %+v", imsi, err)
+		return err
+	}
+	return nil
+}
+
+// removeSubscriberSliceEntries deletes the SM data of imsi for the S-NSSAI of a network slice
+// its device group no longer belongs to. The data shared with the other network slices of
+// the PLMN is rewritten when the device group is synchronised.
+func removeSubscriberSliceEntries(snssai *models.Snssai, mcc, mnc, imsi string) error {
+	if err := dbadapter.CommonDBClient.RestfulAPIDeleteOne(smDataColl, smProvisionedDataFilter(snssai, mcc, mnc, imsi)); err != nil {
+		logger.DbLog.Errorf("failed to delete SM data of S-NSSAI %s for IMSI %s: %+v", SnssaiModelsToHex(*snssai), imsi, err)
+		return err
+	}
+	logger.DbLog.Debugf("succeeded to delete SM data of S-NSSAI %s for IMSI %s", SnssaiModelsToHex(*snssai), imsi)
+	return nil
+}
+
+// subscriberDataColls are the CommonDB collections holding the provisioned and policy data of a subscriber
+var subscriberDataColls = []string{amDataColl, smDataColl, smfSelDataColl, amPolicyDataColl, smPolicyDataColl}
+
+// subscriberDelete deletes the authentication subscription, the provisioned and policy data and
+// the device group membership of a subscriber in a single transaction. errSubscriberNotFound is
+// returned when none of them exist.
+func subscriberDelete(supi identity.Supi) error {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	ueId := supi.String()
+	filter := bson.M{"ueId": ueId}
+
+	var deviceGroups []configmodels.DeviceGroups
+	// device groups only reference subscribers by IMSI
+	if supi.IsImsi() {
+		rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{"imsis": supi.Value})
 		if err != nil {
-			logger.DbLog.Errorf("failed to delete AM data for IMSI %s: %+v", imsi, err)
+			logger.DbLog.Errorf("failed to fetch device groups: %+v", err)
 			return err
 		}
-		// SM data
-		err = dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, smDataColl, filter)
+		for _, rawDeviceGroup := range rawDeviceGroups {
+			var deviceGroup configmodels.DeviceGroups
+			if err = json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
+				logger.DbLog.Errorf("error unmarshaling device group: %+v", err)
+				return err
+			}
+			deviceGroups = append(deviceGroups, deviceGroup)
+		}
+	}
+	if len(deviceGroups) == 0 {
+		exists, err := subscriberDataExists(filter)
 		if err != nil {
-			logger.DbLog.Errorf("failed to delete SM data for IMSI %s: %+v", imsi, err)
 			return err
 		}
-		// SMF selection
-		err = dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, smfSelDataColl, filter)
-		if err != nil {
-			logger.DbLog.Errorf("failed to delete SMF selection data for IMSI %s: %+v", imsi, err)
+		if !exists {
+			return errSubscriberNotFound
+		}
+	}
+
+	authDbName := factory.WebUIConfig.Configuration.Mongodb.AuthKeysDbName
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	return sessionRunner(context.TODO(), func(sc context.Context) error {
+		if err := dbadapter.CommonDBClient.RestfulAPIDeleteOneOnDB(sc, authDbName, authSubsDataColl, filter); err != nil {
+			logger.DbLog.Errorf("failed to delete authentication subscription of %s: %+v", ueId, err)
 			return err
 		}
+		for _, collName := range subscriberDataColls {
+			if err := dbadapter.CommonDBClient.RestfulAPIDeleteManyWithContext(sc, collName, filter); err != nil {
+				logger.DbLog.Errorf("failed to delete %s data of %s: %+v", collName, ueId, err)
+				return err
+			}
+		}
+		for _, deviceGroup := range deviceGroups {
+			removeImsiFromDeviceGroup(&deviceGroup, supi.Value)
+			groupFilter := bson.M{"group-name": deviceGroup.DeviceGroupName}
+			if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneWithContext(sc, devGroupDataColl, groupFilter, configmodels.ToBsonM(deviceGroup)); err != nil {
+				logger.DbLog.Errorf("failed to remove %s from device group %s: %+v", ueId, deviceGroup.DeviceGroupName, err)
+				return err
+			}
+		}
+		logger.WebUILog.Debugf("successfully deleted subscriber %s", ueId)
 		return nil
 	})
-	if err != nil {
-		logger.DbLog.Errorf("failed to delete subscriber entries related to device groups for IMSI %s: %+v", imsi, err)
-		return err
-	}
-	logger.DbLog.Debugf("succeeded to delete subscriber entries related to device groups for IMSI %s", imsi)
-	return nil
 }
 
-func updateSubscriberInDeviceGroups(imsi string) (int, error) {
-	filterByImsi := bson.M{
-		"imsis": imsi,
-	}
-	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, filterByImsi)
+// subscriberDataExists reports whether the authentication subscription or any provisioned or
+// policy data matching filter exists.
+func subscriberDataExists(filter bson.M) (bool, error) {
+	authSubsData, err := dbadapter.AuthDBClient.RestfulAPIGetOne(authSubsDataColl, filter)
 	if err != nil {
-		logger.DbLog.Errorf("failed to fetch device groups: %+v", err)
-		return http.StatusInternalServerError, err
-	}
-	for _, rawDeviceGroup := range rawDeviceGroups {
-		var deviceGroup configmodels.DeviceGroups
-		if err = json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
-			logger.DbLog.Errorf("error unmarshaling device group: %+v", err)
-			return http.StatusInternalServerError, err
-		}
-		filteredImsis := []string{}
-		for _, currImsi := range deviceGroup.Imsis {
-			if currImsi != imsi {
-				filteredImsis = append(filteredImsis, currImsi)
-			}
+		logger.DbLog.Errorf("failed to fetch authentication subscription: %+v", err)
+		return false, err
+	}
+	if authSubsData != nil {
+		return true, nil
+	}
+	for _, collName := range subscriberDataColls {
+		data, err := dbadapter.CommonDBClient.RestfulAPIGetOne(collName, filter)
+		if err != nil {
+			logger.DbLog.Errorf("failed to fetch %s data: %+v", collName, err)
+			return false, err
 		}
-		deviceGroup.Imsis = filteredImsis
-		prevDevGroup := getDeviceGroupByName(deviceGroup.DeviceGroupName)
-		if statusCode, err := handleDeviceGroupPost(&deviceGroup, prevDevGroup); err != nil {
-			logger.ConfigLog.Errorf("error posting device group %+v: %+v", deviceGroup, err)
-			return statusCode, err
+		if data != nil {
+			return true, nil
 		}
 	}
+	return false, nil
+}
 
-	return http.StatusOK, nil
+// removeImsiFromDeviceGroup removes imsi, and its MSISDN unless it still belongs to an IMSI
+// range of deviceGroup, from deviceGroup
+func removeImsiFromDeviceGroup(deviceGroup *configmodels.DeviceGroups, imsi string) {
+	deviceGroup.ConvertLegacyMsisdns()
+	filteredImsis := []string{}
+	for _, currImsi := range deviceGroup.Imsis {
+		if currImsi != imsi {
+			filteredImsis = append(filteredImsis, currImsi)
+		}
+	}
+	deviceGroup.Imsis = filteredImsis
+	if !deviceGroup.ContainsImsi(imsi) {
+		delete(deviceGroup.ImsiMsisdns, imsi)
+	}
 }
@@ -6,13 +6,16 @@ package configapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/omec-project/openapi/v2"
 	"github.com/omec-project/openapi/v2/models"
 	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/identity"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -54,12 +57,17 @@ type txMockDB struct {
 	receivedPutOneWithCtx []map[string]any
 	receivedDeleteOnDB    []map[string]any
 	receivedDeleteWithCtx []map[string]any
+	receivedPostManyCtx   []map[string]any
+	receivedDeleteManyCtx []map[string]any
+	deviceGroups          []map[string]any
 	postOnDBErr           error
 	postWithCtxErr        error
 	putOneOnDBErr         error
 	putOneWithCtxErr      error
 	deleteOnDBErr         error
 	deleteWithCtxErr      error
+	postManyWithCtxErr    error
+	deleteManyWithCtxErr  error
 }
 
 func (m *txMockDB) StartSession() (dbadapter.DBSession, error) {
@@ -91,6 +99,17 @@ func (m *txMockDB) RestfulAPIPostWithContext(ctx context.Context, collName strin
 	return true, nil
 }
 
+func (m *txMockDB) RestfulAPIPostManyWithContext(ctx context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if m.postManyWithCtxErr != nil {
+		return m.postManyWithCtxErr
+	}
+	m.receivedPostManyCtx = append(m.receivedPostManyCtx, map[string]any{
+		"coll": collName,
+		"data": postDataArray,
+	})
+	return nil
+}
+
 func (m *txMockDB) RestfulAPIPutOneOnDB(ctx context.Context, dbName string, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	if m.putOneOnDBErr != nil {
 		return false, m.putOneOnDBErr
@@ -128,6 +147,21 @@ func (m *txMockDB) RestfulAPIDeleteOneOnDB(ctx context.Context, dbName string, c
 	return nil
 }
 
+func (m *txMockDB) RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]any, error) {
+	return m.deviceGroups, nil
+}
+
+func (m *txMockDB) RestfulAPIDeleteManyWithContext(ctx context.Context, collName string, filter bson.M) error {
+	if m.deleteManyWithCtxErr != nil {
+		return m.deleteManyWithCtxErr
+	}
+	m.receivedDeleteManyCtx = append(m.receivedDeleteManyCtx, map[string]any{
+		"coll":   collName,
+		"filter": filter,
+	})
+	return nil
+}
+
 func (m *txMockDB) RestfulAPIDeleteOneWithContext(ctx context.Context, collName string, filter bson.M) error {
 	if m.deleteWithCtxErr != nil {
 		return m.deleteWithCtxErr
@@ -212,74 +246,103 @@ func TestSubscriberAuthenticationDataCreate_CommonDBFails_TransactionAborts(t *t
 	}
 }
 
-func TestSubscriberAuthenticationDataDelete_Success(t *testing.T) {
+func setupSubscriberDeleteTest(t *testing.T, mock *txMockDB) {
+	t.Helper()
 	cleanupFactory := setupTestFactory()
-	defer cleanupFactory()
-
-	mock := &txMockDB{}
 	origCommonDB := dbadapter.CommonDBClient
-	defer func() { dbadapter.CommonDBClient = origCommonDB }()
+	origAuthDB := dbadapter.AuthDBClient
+	t.Cleanup(func() {
+		cleanupFactory()
+		dbadapter.CommonDBClient = origCommonDB
+		dbadapter.AuthDBClient = origAuthDB
+	})
 	dbadapter.CommonDBClient = mock
+	dbadapter.AuthDBClient = &AuthDBMockDBClient{subscribers: []string{"imsi-208930100007487"}}
+}
 
-	err := subscriberAuthenticationDataDelete("imsi-12345")
-	if err != nil {
-		t.Errorf("expected no error, got %v", err)
+func TestSubscriberDelete_Success(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007488"})
+	deviceGroup.Msisdns = []string{"1234567890", "1234567891"}
+	mock := &txMockDB{deviceGroups: []map[string]any{configmodels.ToBsonM(deviceGroup)}}
+	setupSubscriberDeleteTest(t, mock)
+
+	supi, _ := identity.Parse("imsi-208930100007487")
+	if err := subscriberDelete(supi); err != nil {
+		t.Fatalf("expected no error, got %v", err)
 	}
+	expectedFilter := bson.M{"ueId": "imsi-208930100007487"}
 	if len(mock.receivedDeleteOnDB) != 1 {
 		t.Fatalf("expected 1 DeleteOneOnDB call, got %d", len(mock.receivedDeleteOnDB))
 	}
-	if mock.receivedDeleteOnDB[0]["dbName"] != testAuthDbName {
-		t.Errorf("expected auth DB name %q, got %q", testAuthDbName, mock.receivedDeleteOnDB[0]["dbName"])
+	if mock.receivedDeleteOnDB[0]["dbName"] != testAuthDbName || mock.receivedDeleteOnDB[0]["coll"] != authSubsDataColl {
+		t.Errorf("expected authentication subscription to be deleted from %s, got %v", testAuthDbName, mock.receivedDeleteOnDB[0])
+	}
+	if !reflect.DeepEqual(mock.receivedDeleteOnDB[0]["filter"], expectedFilter) {
+		t.Errorf("expected filter %v, got %v", expectedFilter, mock.receivedDeleteOnDB[0]["filter"])
+	}
+	deletedColls := []string{}
+	for _, received := range mock.receivedDeleteManyCtx {
+		deletedColls = append(deletedColls, received["coll"].(string))
+		if !reflect.DeepEqual(received["filter"], expectedFilter) {
+			t.Errorf("expected filter %v, got %v", expectedFilter, received["filter"])
+		}
 	}
-	if mock.receivedDeleteOnDB[0]["coll"] != authSubsDataColl {
-		t.Errorf("expected collection %q, got %q", authSubsDataColl, mock.receivedDeleteOnDB[0]["coll"])
+	if !reflect.DeepEqual(deletedColls, subscriberDataColls) {
+		t.Errorf("expected %v to be deleted, got %v", subscriberDataColls, deletedColls)
 	}
-	if len(mock.receivedDeleteWithCtx) != 1 {
-		t.Fatalf("expected 1 DeleteOneWithContext call, got %d", len(mock.receivedDeleteWithCtx))
+	if len(mock.receivedPutOneWithCtx) != 1 {
+		t.Fatalf("expected 1 device group update, got %d", len(mock.receivedPutOneWithCtx))
 	}
-	if mock.receivedDeleteWithCtx[0]["coll"] != amDataColl {
-		t.Errorf("expected collection %q, got %q", amDataColl, mock.receivedDeleteWithCtx[0]["coll"])
+	data := mock.receivedPutOneWithCtx[0]["data"].(map[string]any)
+	imsis, _ := data["imsis"].([]any)
+	msisdns, _ := data["imsi-msisdns"].(map[string]any)
+	if len(imsis) != 1 || imsis[0] != "208930100007488" || len(msisdns) != 1 || msisdns["208930100007488"] != "1234567891" {
+		t.Errorf("expected only the remaining IMSI and its MSISDN, got %v and %v", imsis, msisdns)
 	}
 }
 
-func TestSubscriberAuthenticationDataDelete_AuthDBDeleteFails_TransactionAborts(t *testing.T) {
-	cleanupFactory := setupTestFactory()
-	defer cleanupFactory()
+func TestSubscriberDelete_NotFound(t *testing.T) {
+	setupSubscriberDeleteTest(t, &txMockDB{})
+	dbadapter.AuthDBClient = &AuthDBMockDBClient{}
+	dbadapter.CommonDBClient = &DeleteSubscriberMockDBClient{}
 
-	mock := &txMockDB{
-		deleteOnDBErr: fmt.Errorf("fail on authdb delete"),
+	supi, _ := identity.Parse("imsi-208930100007487")
+	if err := subscriberDelete(supi); !errors.Is(err, errSubscriberNotFound) {
+		t.Errorf("expected errSubscriberNotFound, got %v", err)
 	}
-	origCommonDB := dbadapter.CommonDBClient
-	defer func() { dbadapter.CommonDBClient = origCommonDB }()
-	dbadapter.CommonDBClient = mock
+}
 
-	err := subscriberAuthenticationDataDelete("imsi-12345")
-	if err == nil {
+func TestSubscriberDelete_AuthDBDeleteFails_TransactionAborts(t *testing.T) {
+	mock := &txMockDB{deleteOnDBErr: fmt.Errorf("fail on authdb delete")}
+	setupSubscriberDeleteTest(t, mock)
+
+	supi, _ := identity.Parse("imsi-208930100007487")
+	if err := subscriberDelete(supi); err == nil {
 		t.Fatal("expected error but got nil")
 	}
-	if len(mock.receivedDeleteWithCtx) != 0 {
+	if len(mock.receivedDeleteManyCtx) != 0 {
 		t.Error("expected no CommonDB delete when auth delete fails")
 	}
 }
 
-func TestSubscriberAuthenticationDataDelete_CommonDBDeleteFails_TransactionAborts(t *testing.T) {
-	cleanupFactory := setupTestFactory()
-	defer cleanupFactory()
-
+func TestSubscriberDelete_CommonDBDeleteFails_TransactionAborts(t *testing.T) {
+	deviceGroup := deviceGroupWithImsis("group1", []string{"208930100007487"})
 	mock := &txMockDB{
-		deleteWithCtxErr: fmt.Errorf("fail on commondb delete"),
+		deviceGroups:         []map[string]any{configmodels.ToBsonM(deviceGroup)},
+		deleteManyWithCtxErr: fmt.Errorf("fail on commondb delete"),
 	}
-	origCommonDB := dbadapter.CommonDBClient
-	defer func() { dbadapter.CommonDBClient = origCommonDB }()
-	dbadapter.CommonDBClient = mock
+	setupSubscriberDeleteTest(t, mock)
 
-	err := subscriberAuthenticationDataDelete("imsi-12345")
-	if err == nil {
+	supi, _ := identity.Parse("imsi-208930100007487")
+	if err := subscriberDelete(supi); err == nil {
 		t.Fatal("expected error but got nil")
 	}
 	if len(mock.receivedDeleteOnDB) != 1 {
 		t.Error("expected auth delete to have been attempted")
 	}
+	if len(mock.receivedPutOneWithCtx) != 0 {
+		t.Error("expected no device group update when the subscriber data delete fails")
+	}
 }
 
 func Test_handleSubscriberPost(t *testing.T) {
@@ -330,43 +393,6 @@ func Test_handleSubscriberPost(t *testing.T) {
 	}
 }
 
-func Test_handleSubscriberDelete(t *testing.T) {
-	cleanupFactory := setupTestFactory()
-	defer cleanupFactory()
-	origCommonDBClient := dbadapter.CommonDBClient
-	defer func() { dbadapter.CommonDBClient = origCommonDBClient }()
-	ueId := "imsi-208930100007487"
-	mock := &txMockDB{}
-	dbadapter.CommonDBClient = mock
-	delErr := subscriberAuthenticationDataDelete(ueId)
-	if delErr != nil {
-		t.Errorf("could not handle subscriber delete: %v", delErr)
-	}
-	if len(mock.receivedDeleteOnDB) != 1 {
-		t.Fatalf("expected 1 DeleteOneOnDB call, got %d", len(mock.receivedDeleteOnDB))
-	}
-	if mock.receivedDeleteOnDB[0]["coll"] != authSubsDataColl {
-		t.Errorf("expected collection %v, got %v", authSubsDataColl, mock.receivedDeleteOnDB[0]["coll"])
-	}
-	if mock.receivedDeleteOnDB[0]["dbName"] != testAuthDbName {
-		t.Errorf("expected dbName %v, got %v", testAuthDbName, mock.receivedDeleteOnDB[0]["dbName"])
-	}
-
-	expectedFilter := bson.M{"ueId": ueId}
-	if !reflect.DeepEqual(mock.receivedDeleteOnDB[0]["filter"], expectedFilter) {
-		t.Errorf("expected filter %v, got %v", expectedFilter, mock.receivedDeleteOnDB[0]["filter"])
-	}
-	if len(mock.receivedDeleteWithCtx) != 1 {
-		t.Fatalf("expected 1 DeleteOneWithContext call, got %d", len(mock.receivedDeleteWithCtx))
-	}
-	if mock.receivedDeleteWithCtx[0]["coll"] != amDataColl {
-		t.Errorf("expected collection %v, got %v", amDataColl, mock.receivedDeleteWithCtx[0]["coll"])
-	}
-	if !reflect.DeepEqual(mock.receivedDeleteWithCtx[0]["filter"], expectedFilter) {
-		t.Errorf("expected filter %v, got %v", expectedFilter, mock.receivedDeleteWithCtx[0]["filter"])
-	}
-}
-
 func Test_handleSubscriberGet(t *testing.T) {
 	origAuthDBClient := dbadapter.AuthDBClient
 	defer func() { dbadapter.AuthDBClient = origAuthDBClient }()
@@ -377,3 +403,145 @@ func Test_handleSubscriberGet(t *testing.T) {
 		t.Errorf("expected subscriber %v, got %v", &subscriber, subscriberResult)
 	}
 }
+
+func Test_newAuthenticationSubscription(t *testing.T) {
+	tests := []struct {
+		name             string
+		subsOverrideData configmodels.SubsOverrideData
+		expected         *models.AuthenticationSubscription
+		expectedError    string
+	}{
+		{
+			name: "Defaults to 5G AKA and AMF 8000",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:            "8e27b6af0e692e750f32667a3b14605d",
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expected: authenticationSubscription(),
+		},
+		{
+			name: "EAP-AKA' with custom AMF",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:                           "8e27b6af0e692e750f32667a3b14605d",
+				Key:                           "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber:                "16f3b3f70fc2",
+				AuthenticationMethod:          "EAP_AKA_PRIME",
+				AuthenticationManagementField: "9001",
+			},
+			expected: &models.AuthenticationSubscription{
+				AuthenticationManagementField: openapi.PtrString("9001"),
+				AuthenticationMethod:          models.AUTHMETHOD_EAP_AKA_PRIME,
+				EncOpcKey:                     openapi.PtrString("8e27b6af0e692e750f32667a3b14605d"),
+				EncPermanentKey:               openapi.PtrString("8baf473f2f8fd09487cccbd7097c6862"),
+				SequenceNumber:                &models.SequenceNumber{Sqn: openapi.PtrString("16f3b3f70fc2")},
+			},
+		},
+		{
+			name: "OPc is derived from the OP",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OP:             "cdc202d5123e20f62b6d676ac72cb318",
+				Key:            "465b5ce8b199b49faa5f0a2ee238a6bc",
+				SequenceNumber: "ff9bb4d0b607",
+			},
+			expected: &models.AuthenticationSubscription{
+				AuthenticationManagementField: openapi.PtrString("8000"),
+				AuthenticationMethod:          models.AUTHMETHOD__5_G_AKA,
+				EncOpcKey:                     openapi.PtrString("cd63cb71954a9f4e48a5994e37a02baf"),
+				EncPermanentKey:               openapi.PtrString("465b5ce8b199b49faa5f0a2ee238a6bc"),
+				SequenceNumber:                &models.SequenceNumber{Sqn: openapi.PtrString("ff9bb4d0b607")},
+			},
+		},
+		{
+			name: "Missing OPc and OP",
+			subsOverrideData: configmodels.SubsOverrideData{
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expectedError: "missing required authentication data",
+		},
+		{
+			name: "Both OPc and OP",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:            "8e27b6af0e692e750f32667a3b14605d",
+				OP:             "cdc202d5123e20f62b6d676ac72cb318",
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expectedError: "only one of OPc and OP",
+		},
+		{
+			name: "Invalid OPc length",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:            "8e27b6af0e692e750f32667a3b1460",
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expectedError: "invalid OPc",
+		},
+		{
+			name: "Invalid OP",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OP:             "not-hex",
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expectedError: "invalid OP",
+		},
+		{
+			name: "Invalid key",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:            "8e27b6af0e692e750f32667a3b14605d",
+				Key:            "8baf473f2f8fd09487cccbd7097c686z",
+				SequenceNumber: "16f3b3f70fc2",
+			},
+			expectedError: "invalid key",
+		},
+		{
+			name: "Invalid sequence number",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:            "8e27b6af0e692e750f32667a3b14605d",
+				Key:            "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber: "16f3b3f70f",
+			},
+			expectedError: "invalid sequence number",
+		},
+		{
+			name: "Invalid authentication method",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:                  "8e27b6af0e692e750f32667a3b14605d",
+				Key:                  "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber:       "16f3b3f70fc2",
+				AuthenticationMethod: "EAP_TLS",
+			},
+			expectedError: "invalid authentication method",
+		},
+		{
+			name: "Invalid AMF",
+			subsOverrideData: configmodels.SubsOverrideData{
+				OPc:                           "8e27b6af0e692e750f32667a3b14605d",
+				Key:                           "8baf473f2f8fd09487cccbd7097c6862",
+				SequenceNumber:                "16f3b3f70fc2",
+				AuthenticationManagementField: "80000",
+			},
+			expectedError: "invalid authentication management field",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authSubData, err := newAuthenticationSubscription(tc.subsOverrideData)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, authSubData) {
+				t.Errorf("expected %+v, got %+v", tc.expected, authSubData)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"fmt"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/encryption"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const REENCRYPTION_PAGE_SIZE = 500
+
+// authSubscriptionSecretFields are the authentication subscription fields encrypted at rest
+var authSubscriptionSecretFields = []string{"encPermanentKey", "encOpcKey", "encTopcKey"}
+
+func authSubscriptionSecrets(authSubData *models.AuthenticationSubscription) []**string {
+	return []**string{&authSubData.EncPermanentKey, &authSubData.EncOpcKey, &authSubData.EncTopcKey}
+}
+
+// encryptAuthenticationSubscription returns a copy of authSubData with its secrets
+// encrypted, or authSubData itself when subscriber key encryption is disabled.
+func encryptAuthenticationSubscription(authSubData *models.AuthenticationSubscription) (*models.AuthenticationSubscription, error) {
+	keyRing := encryption.SubscriberKeyRing
+	if keyRing == nil {
+		return authSubData, nil
+	}
+	encrypted := *authSubData
+	for _, secret := range authSubscriptionSecrets(&encrypted) {
+		if *secret == nil || encryption.IsEncrypted(**secret) {
+			continue
+		}
+		value, err := keyRing.Encrypt(**secret)
+		if err != nil {
+			return nil, err
+		}
+		*secret = &value
+	}
+	return &encrypted, nil
+}
+
+// decryptAuthenticationSubscription decrypts the secrets of authSubData in place.
+func decryptAuthenticationSubscription(authSubData *models.AuthenticationSubscription) error {
+	for _, secret := range authSubscriptionSecrets(authSubData) {
+		if *secret == nil || !encryption.IsEncrypted(**secret) {
+			continue
+		}
+		if encryption.SubscriberKeyRing == nil {
+			return fmt.Errorf("subscriber key encryption is not configured")
+		}
+		value, err := encryption.SubscriberKeyRing.Decrypt(**secret)
+		if err != nil {
+			return err
+		}
+		*secret = &value
+	}
+	return nil
+}
+
+// encryptAuthSubscriptionPatchValue encrypts the value of a patched secret field.
+// Other fields are returned unchanged.
+func encryptAuthSubscriptionPatchValue(path string, value any) (any, error) {
+	keyRing := encryption.SubscriberKeyRing
+	if keyRing == nil {
+		return value, nil
+	}
+	for _, field := range authSubscriptionSecretFields {
+		if path != "/"+field {
+			continue
+		}
+		plaintext, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of %s must be a string", path)
+		}
+		return keyRing.Encrypt(plaintext)
+	}
+	return value, nil
+}
+
+// ReencryptSubscriberKeys encrypts with the active key every subscriber secret which is
+// stored in plaintext or encrypted with a previous key. It returns the number of updated
+// subscribers.
+func ReencryptSubscriberKeys() (int, error) {
+	keyRing := encryption.SubscriberKeyRing
+	if keyRing == nil {
+		return 0, fmt.Errorf("subscriber key encryption is not configured")
+	}
+	updated := 0
+	lastUeId := ""
+	for {
+		filter := bson.M{}
+		if lastUeId != "" {
+			filter = bson.M{"ueId": bson.M{"$gt": lastUeId}}
+		}
+		authDataList, err := dbadapter.AuthDBClient.RestfulAPIGetManyPaged(authSubsDataColl, filter, bson.D{{Key: "ueId", Value: 1}}, REENCRYPTION_PAGE_SIZE)
+		if err != nil {
+			return updated, fmt.Errorf("failed to retrieve authentication subscriptions: %w", err)
+		}
+		for _, authData := range authDataList {
+			ueId, _ := authData["ueId"].(string)
+			patchData, err := reencryptedSecrets(keyRing, authData)
+			if err != nil {
+				return updated, fmt.Errorf("failed to re-encrypt subscriber %s: %w", ueId, err)
+			}
+			if len(patchData) == 0 {
+				continue
+			}
+			if err = dbadapter.AuthDBClient.RestfulAPIMergePatch(authSubsDataColl, bson.M{"ueId": ueId}, patchData); err != nil {
+				return updated, fmt.Errorf("failed to update subscriber %s: %w", ueId, err)
+			}
+			logger.DbLog.Debugf("re-encrypted authentication subscription of %s", ueId)
+			updated++
+		}
+		if len(authDataList) < REENCRYPTION_PAGE_SIZE {
+			return updated, nil
+		}
+		lastUeId, _ = authDataList[len(authDataList)-1]["ueId"].(string)
+		if lastUeId == "" {
+			return updated, fmt.Errorf("authentication subscription without ueId")
+		}
+	}
+}
+
+func reencryptedSecrets(keyRing *encryption.KeyRing, authData map[string]any) (map[string]any, error) {
+	patchData := map[string]any{}
+	for _, field := range authSubscriptionSecretFields {
+		value, ok := authData[field].(string)
+		if !ok || !keyRing.NeedsReencryption(value) {
+			continue
+		}
+		plaintext, err := keyRing.Decrypt(value)
+		if err != nil {
+			return nil, err
+		}
+		if patchData[field], err = keyRing.Encrypt(plaintext); err != nil {
+			return nil, err
+		}
+	}
+	return patchData, nil
+}
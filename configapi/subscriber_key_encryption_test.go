@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/omec-project/webconsole/backend/encryption"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	testPermanentKey = "8baf473f2f8fd09487cccbd7097c6862"
+	testOpcKey       = "8e27b6af0e692e750f32667a3b14605d"
+)
+
+func setupTestKeyRing(t *testing.T, activeKeyId string) *encryption.KeyRing {
+	t.Helper()
+	t.Setenv("TEST_SUBSCRIBER_KEY_1", "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	t.Setenv("TEST_SUBSCRIBER_KEY_2", "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100")
+	keyRing, err := encryption.NewKeyRing(&factory.SubscriberKeyEncryption{
+		ActiveKeyId: activeKeyId,
+		Keys: []factory.EncryptionKey{
+			{Id: "key1", EnvVar: "TEST_SUBSCRIBER_KEY_1"},
+			{Id: "key2", EnvVar: "TEST_SUBSCRIBER_KEY_2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create key ring: %v", err)
+	}
+	origKeyRing := encryption.SubscriberKeyRing
+	encryption.SubscriberKeyRing = keyRing
+	t.Cleanup(func() { encryption.SubscriberKeyRing = origKeyRing })
+	return keyRing
+}
+
+type ReencryptMockDBClient struct {
+	dbadapter.DBInterface
+	authData           []map[string]any
+	receivedMergePatch map[string]map[string]any
+}
+
+func (db *ReencryptMockDBClient) RestfulAPIGetManyPaged(collName string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	after := ""
+	if condition, ok := filter["ueId"].(bson.M); ok {
+		after, _ = condition["$gt"].(string)
+	}
+	page := []map[string]any{}
+	for _, authData := range db.authData {
+		if authData["ueId"].(string) > after && int64(len(page)) < limit {
+			page = append(page, authData)
+		}
+	}
+	return page, nil
+}
+
+func (db *ReencryptMockDBClient) RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]any) error {
+	if db.receivedMergePatch == nil {
+		db.receivedMergePatch = map[string]map[string]any{}
+	}
+	db.receivedMergePatch[filter["ueId"].(string)] = patchData
+	return nil
+}
+
+func TestEncryptDecryptAuthenticationSubscription(t *testing.T) {
+	setupTestKeyRing(t, "key1")
+	authSubData := authenticationSubscription()
+
+	encrypted, err := encryptAuthenticationSubscription(authSubData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *authSubData.EncPermanentKey != testPermanentKey {
+		t.Errorf("expected the original authentication subscription to be unchanged")
+	}
+	if !encryption.IsEncrypted(*encrypted.EncPermanentKey) || !encryption.IsEncrypted(*encrypted.EncOpcKey) {
+		t.Fatalf("expected encrypted K and OPc, got %s and %s", *encrypted.EncPermanentKey, *encrypted.EncOpcKey)
+	}
+	if encrypted.EncTopcKey != nil {
+		t.Errorf("expected unset OP to remain unset")
+	}
+	if *encrypted.SequenceNumber.Sqn != *authSubData.SequenceNumber.Sqn {
+		t.Errorf("expected the SQN not to be encrypted")
+	}
+
+	if err = decryptAuthenticationSubscription(encrypted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *encrypted.EncPermanentKey != testPermanentKey || *encrypted.EncOpcKey != testOpcKey {
+		t.Errorf("expected decrypted K and OPc, got %s and %s", *encrypted.EncPermanentKey, *encrypted.EncOpcKey)
+	}
+}
+
+func TestEncryptAuthenticationSubscription_Disabled(t *testing.T) {
+	origKeyRing := encryption.SubscriberKeyRing
+	encryption.SubscriberKeyRing = nil
+	defer func() { encryption.SubscriberKeyRing = origKeyRing }()
+
+	authSubData := authenticationSubscription()
+	encrypted, err := encryptAuthenticationSubscription(authSubData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *encrypted.EncPermanentKey != testPermanentKey {
+		t.Errorf("expected plaintext K, got %s", *encrypted.EncPermanentKey)
+	}
+}
+
+func TestSubscriberAuthenticationDataCreate_EncryptsSecrets(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+	setupTestKeyRing(t, "key1")
+
+	mock := &txMockDB{}
+	origCommonDB := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = origCommonDB }()
+	dbadapter.CommonDBClient = mock
+
+	if err := subscriberAuthenticationDataCreate("imsi-1", authenticationSubscription()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.receivedPostOnDB) != 1 {
+		t.Fatalf("expected 1 PostOnDB call, got %d", len(mock.receivedPostOnDB))
+	}
+	data := mock.receivedPostOnDB[0]["data"].(map[string]any)
+	for _, field := range []string{"encPermanentKey", "encOpcKey"} {
+		value, _ := data[field].(string)
+		if !encryption.IsEncrypted(value) {
+			t.Errorf("expected %s to be encrypted, got %q", field, value)
+		}
+	}
+}
+
+func TestSubscriberAuthenticationDataPatch_EncryptsSecrets(t *testing.T) {
+	setupTestKeyRing(t, "key1")
+	mock := &PatchSubscriberMockDBClient{}
+	origAuthDB := dbadapter.AuthDBClient
+	defer func() { dbadapter.AuthDBClient = origAuthDB }()
+	dbadapter.AuthDBClient = mock
+
+	err := subscriberAuthenticationDataMergePatch("imsi-1", map[string]any{"encOpcKey": testOpcKey, "authenticationManagementField": "8000"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	patchData := mock.receivedMergePatch[0]
+	if !encryption.IsEncrypted(patchData["encOpcKey"].(string)) {
+		t.Errorf("expected encrypted OPc, got %v", patchData["encOpcKey"])
+	}
+	if patchData["authenticationManagementField"] != "8000" {
+		t.Errorf("expected plaintext AMF, got %v", patchData["authenticationManagementField"])
+	}
+
+	operations := []dbadapter.PatchOperation{{Op: "replace", Path: "/encPermanentKey", Value: testPermanentKey}}
+	if err = subscriberAuthenticationDataJSONPatch(context.Background(), "imsi-1", operations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(mock.receivedJSONPatch[0]), testPermanentKey) {
+		t.Errorf("expected encrypted K in JSON patch, got %s", mock.receivedJSONPatch[0])
+	}
+	var patchOperations []dbadapter.PatchOperation
+	if err = json.Unmarshal(mock.receivedJSONPatch[0], &patchOperations); err != nil {
+		t.Fatalf("failed to unmarshal JSON patch: %v", err)
+	}
+	if value, _ := patchOperations[0].Value.(string); !encryption.IsEncrypted(value) {
+		t.Errorf("expected encrypted K, got %v", patchOperations[0].Value)
+	}
+}
+
+func TestValidateAuthSubscriptionJSONPatch_TestEncryptedField(t *testing.T) {
+	operations := []dbadapter.PatchOperation{{Op: "test", Path: "/encOpcKey", Value: testOpcKey}}
+	if err := validateAuthSubscriptionJSONPatch(operations); err != nil {
+		t.Fatalf("expected test operation to be accepted without encryption, got %v", err)
+	}
+	setupTestKeyRing(t, "key1")
+	err := validateAuthSubscriptionJSONPatch(operations)
+	if err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("expected encrypted field error, got %v", err)
+	}
+}
+
+func TestReencryptSubscriberKeys(t *testing.T) {
+	keyRing := setupTestKeyRing(t, "key1")
+	encryptedWithKey1, err := keyRing.Encrypt(testOpcKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyRing = setupTestKeyRing(t, "key2")
+	encryptedWithKey2, err := keyRing.Encrypt(testOpcKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock := &ReencryptMockDBClient{
+		authData: []map[string]any{
+			{"ueId": "imsi-208930100007487", "encPermanentKey": testPermanentKey, "encOpcKey": encryptedWithKey1},
+			{"ueId": "imsi-208930100007488", "encPermanentKey": encryptedWithKey2, "encOpcKey": encryptedWithKey2},
+			{"ueId": "imsi-208930100007489", "encPermanentKey": encryptedWithKey2, "encOpcKey": testOpcKey},
+		},
+	}
+	origAuthDB := dbadapter.AuthDBClient
+	defer func() { dbadapter.AuthDBClient = origAuthDB }()
+	dbadapter.AuthDBClient = mock
+
+	updated, err := ReencryptSubscriberKeys()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != 2 {
+		t.Fatalf("expected 2 updated subscribers, got %d", updated)
+	}
+	if _, ok := mock.receivedMergePatch["imsi-208930100007488"]; ok {
+		t.Errorf("expected subscriber encrypted with the active key not to be updated")
+	}
+	patchData := mock.receivedMergePatch["imsi-208930100007487"]
+	if len(patchData) != 2 {
+		t.Fatalf("expected K and OPc to be updated, got %v", patchData)
+	}
+	expected := map[string]string{"encPermanentKey": testPermanentKey, "encOpcKey": testOpcKey}
+	for field, value := range patchData {
+		if encryption.KeyId(value.(string)) != "key2" {
+			t.Errorf("expected %s to be encrypted with key2, got %v", field, value)
+		}
+		plaintext, err := keyRing.Decrypt(value.(string))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plaintext != expected[field] {
+			t.Errorf("expected %s %s, got %s", field, expected[field], plaintext)
+		}
+	}
+	if patchData := mock.receivedMergePatch["imsi-208930100007489"]; len(patchData) != 1 || patchData["encOpcKey"] == nil {
+		t.Errorf("expected only OPc to be updated, got %v", patchData)
+	}
+}
+
+func TestReencryptSubscriberKeys_Disabled(t *testing.T) {
+	origKeyRing := encryption.SubscriberKeyRing
+	encryption.SubscriberKeyRing = nil
+	defer func() { encryption.SubscriberKeyRing = origKeyRing }()
+
+	if _, err := ReencryptSubscriberKeys(); err == nil {
+		t.Errorf("expected error when subscriber key encryption is not configured")
+	}
+}
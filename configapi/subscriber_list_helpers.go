@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	MAX_SUBSCRIBERS_PAGE_SIZE = 1000
+	// SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE is the number of amData documents checked
+	// against the authentication data at a time when filtering on hasAuthData
+	SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE = 500
+
+	totalCountHeader = "X-Total-Count"
+	nextCursorHeader = "X-Next-Cursor"
+)
+
+// subscriberSortFields maps the values accepted by the sort query parameter
+// to the amData document fields.
+var subscriberSortFields = map[string]string{
+	"ueId":   "ueId",
+	"plmnID": "servingPlmnId",
+}
+
+type subscriberListQuery struct {
+	limit       int64
+	sortField   string
+	descending  bool
+	cursor      *subscriberListCursor
+	imsiPrefix  string
+	plmnID      string
+	deviceGroup string
+	hasAuthData *bool
+}
+
+// subscriberListCursor identifies the last amData document of a page by its ueId and
+// servingPlmnId, which together are unique across the amData documents.
+type subscriberListCursor struct {
+	PlmnID *string `json:"p,omitempty"`
+	UeId   string  `json:"u"`
+}
+
+// subscriberListCursorOf returns the cursor identifying amData
+func subscriberListCursorOf(amData map[string]any) subscriberListCursor {
+	cursor := subscriberListCursor{}
+	cursor.UeId, _ = amData["ueId"].(string)
+	if plmnID, ok := amData["servingPlmnId"].(string); ok {
+		cursor.PlmnID = &plmnID
+	}
+	return cursor
+}
+
+func (cursor subscriberListCursor) encode() string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSubscriberListCursor(value string) (*subscriberListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor subscriberListCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.UeId == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+func parseSubscriberListQuery(c *gin.Context) (subscriberListQuery, error) {
+	query := subscriberListQuery{sortField: "ueId"}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MAX_SUBSCRIBERS_PAGE_SIZE {
+			return query, fmt.Errorf("invalid limit %q: expected a number between 1 and %d", value, MAX_SUBSCRIBERS_PAGE_SIZE)
+		}
+		query.limit = int64(limit)
+	}
+	if value := c.Query("sort"); value != "" {
+		field, ok := subscriberSortFields[strings.TrimPrefix(value, "-")]
+		if !ok {
+			return query, fmt.Errorf("invalid sort %q: expected ueId or plmnID, optionally prefixed with '-'", value)
+		}
+		query.sortField = field
+		query.descending = strings.HasPrefix(value, "-")
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := decodeSubscriberListCursor(value)
+		if err != nil {
+			return query, err
+		}
+		query.cursor = cursor
+	}
+	if value := c.Query("imsiPrefix"); value != "" {
+		prefix := strings.TrimPrefix(value, "imsi-")
+		if prefixMatch, err := regexp.MatchString("^[0-9]{1,15}$", prefix); err != nil || !prefixMatch {
+			return query, fmt.Errorf("invalid imsiPrefix %q: expected up to 15 digits", value)
+		}
+		query.imsiPrefix = prefix
+	}
+	if value := c.Query("plmnID"); value != "" {
+		if !isValidPlmnId(value) {
+			return query, fmt.Errorf("invalid plmnID %q: expected 5 or 6 digits", value)
+		}
+		query.plmnID = value
+	}
+	query.deviceGroup = c.Query("deviceGroup")
+	if value := c.Query("hasAuthData"); value != "" {
+		hasAuthData, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid hasAuthData %q: expected true or false", value)
+		}
+		query.hasAuthData = &hasAuthData
+	}
+	return query, nil
+}
+
+// subscriberListFilter builds the amData filter matching the query filters, without
+// the pagination cursor and the hasAuthData filter, which is applied to each page.
+func subscriberListFilter(query subscriberListQuery) bson.M {
+	conditions := []bson.M{}
+	if query.imsiPrefix != "" {
+		conditions = append(conditions, bson.M{"ueId": bson.M{"$regex": "^imsi-" + query.imsiPrefix}})
+	}
+	if query.plmnID != "" {
+		conditions = append(conditions, bson.M{"servingPlmnId": query.plmnID})
+	}
+	if query.deviceGroup != "" {
+		ueIds := []string{}
+		memberConditions := []bson.M{}
+		if devGroup := getDeviceGroupByName(query.deviceGroup); devGroup != nil {
+			for _, imsi := range devGroup.Imsis {
+				ueIds = append(ueIds, "imsi-"+imsi)
+			}
+			memberConditions = imsiRangeConditions(devGroup)
+		}
+		memberConditions = append(memberConditions, bson.M{"ueId": bson.M{"$in": ueIds}})
+		if len(memberConditions) == 1 {
+			conditions = append(conditions, memberConditions[0])
+		} else {
+			conditions = append(conditions, bson.M{"$or": memberConditions})
+		}
+	}
+	return combineFilters(conditions)
+}
+
+// imsiRangeConditions matches the UE IDs of the IMSI ranges of devGroup without listing them
+func imsiRangeConditions(devGroup *configmodels.DeviceGroups) []bson.M {
+	conditions := []bson.M{}
+	for _, imsiRange := range devGroup.ImsiRanges {
+		parsedRange, err := imsiRange.Parse()
+		if err != nil {
+			logger.ConfigLog.Warnf("skipping invalid IMSI range of device group %s: %+v", devGroup.DeviceGroupName, err)
+			continue
+		}
+		conditions = append(conditions, bson.M{"ueId": bson.M{
+			"$gte":   "imsi-" + parsedRange.Start(),
+			"$lte":   "imsi-" + parsedRange.End(),
+			"$regex": fmt.Sprintf("^imsi-[0-9]{%d}$", len(parsedRange.Start())),
+		}})
+	}
+	return conditions
+}
+
+// subscriberListSortFields returns the amData fields the subscribers are ordered by: the
+// sort field, then the other of ueId and servingPlmnId so that every document has a
+// distinct position and pages never end between documents sharing the sort value.
+func subscriberListSortFields(query subscriberListQuery) []string {
+	if query.sortField == "ueId" {
+		return []string{"ueId", "servingPlmnId"}
+	}
+	return []string{query.sortField, "ueId"}
+}
+
+func subscriberListSort(query subscriberListQuery) bson.D {
+	direction := 1
+	if query.descending {
+		direction = -1
+	}
+	sort := bson.D{}
+	for _, field := range subscriberListSortFields(query) {
+		sort = append(sort, bson.E{Key: field, Value: direction})
+	}
+	return sort
+}
+
+// subscriberListCursorFilter matches the subscribers which come after the cursor in
+// the sort order. Subscribers without a servingPlmnId sort before any other value.
+func subscriberListCursorFilter(query subscriberListQuery) bson.M {
+	cursorValues := map[string]*string{
+		"ueId":          &query.cursor.UeId,
+		"servingPlmnId": query.cursor.PlmnID,
+	}
+	conditions := []bson.M{}
+	equal := bson.M{}
+	for _, field := range subscriberListSortFields(query) {
+		value := cursorValues[field]
+		for _, after := range valuesAfter(value, field != "ueId", query.descending) {
+			condition := bson.M{field: after}
+			maps.Copy(condition, equal)
+			conditions = append(conditions, condition)
+		}
+		if value == nil {
+			equal[field] = nil
+		} else {
+			equal[field] = *value
+		}
+	}
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return bson.M{"$or": conditions}
+}
+
+// valuesAfter returns the conditions matching the values of a field which come after
+// value in the sort order. The missing values of a nullable field sort before any other value.
+func valuesAfter(value *string, nullable bool, descending bool) []any {
+	switch {
+	case value == nil && descending:
+		return nil
+	case value == nil:
+		return []any{bson.M{"$ne": nil}}
+	case descending && nullable:
+		return []any{bson.M{"$lt": *value}, nil}
+	case descending:
+		return []any{bson.M{"$lt": *value}}
+	default:
+		return []any{bson.M{"$gt": *value}}
+	}
+}
+
+func combineFilters(filters []bson.M) bson.M {
+	conditions := make([]bson.M, 0, len(filters))
+	for _, filter := range filters {
+		if len(filter) > 0 {
+			conditions = append(conditions, filter)
+		}
+	}
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0]
+	default:
+		return bson.M{"$and": conditions}
+	}
+}
+
+// getSubscribersPage returns a page of amData documents matching the query, the total
+// number of matching amData documents and the cursor of the next page, which is empty
+// when there are no more subscribers. A subscriber served in several PLMNs has one amData
+// document per PLMN. The total is -1 when the query filters on authentication data, as
+// counting it would require checking every subscriber.
+func getSubscribersPage(query subscriberListQuery) ([]map[string]any, int64, string, error) {
+	filter := subscriberListFilter(query)
+	total := int64(-1)
+	if query.hasAuthData == nil {
+		var err error
+		total, err = dbadapter.CommonDBClient.RestfulAPICount(amDataColl, filter)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to count subscribers: %w", err)
+		}
+	}
+	batchSize := query.limit
+	if query.hasAuthData != nil && batchSize < SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE {
+		batchSize = SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE
+	}
+	amDataList := []map[string]any{}
+	for {
+		pageFilter := filter
+		if query.cursor != nil {
+			pageFilter = combineFilters([]bson.M{filter, subscriberListCursorFilter(query)})
+		}
+		batch, err := dbadapter.CommonDBClient.RestfulAPIGetManyPaged(amDataColl, pageFilter, subscriberListSort(query), batchSize)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to retrieve subscribers: %w", err)
+		}
+		matching, err := filterSubscribersByAuthData(batch, query.hasAuthData)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		for _, amData := range matching {
+			if query.limit > 0 && int64(len(amDataList)) == query.limit {
+				break
+			}
+			amDataList = append(amDataList, amData)
+		}
+		pageFull := query.limit > 0 && int64(len(amDataList)) == query.limit
+		if pageFull || batchSize == 0 || int64(len(batch)) < batchSize {
+			break
+		}
+		cursor := subscriberListCursorOf(batch[len(batch)-1])
+		if cursor.UeId == "" {
+			return nil, 0, "", fmt.Errorf("subscriber without ueId in amData")
+		}
+		query.cursor = &cursor
+	}
+	nextCursor := ""
+	if query.limit > 0 && int64(len(amDataList)) == query.limit {
+		nextCursor = subscriberListCursorOf(amDataList[len(amDataList)-1]).encode()
+	}
+	return amDataList, total, nextCursor, nil
+}
+
+// filterSubscribersByAuthData keeps the amData documents of the subscribers with
+// (hasAuthData true) or without (hasAuthData false) authentication data. Only the
+// authentication data of the given subscribers is queried.
+func filterSubscribersByAuthData(amDataList []map[string]any, hasAuthData *bool) ([]map[string]any, error) {
+	if hasAuthData == nil || len(amDataList) == 0 {
+		return amDataList, nil
+	}
+	ueIds := make([]string, 0, len(amDataList))
+	for _, amData := range amDataList {
+		if ueId, ok := amData["ueId"].(string); ok {
+			ueIds = append(ueIds, ueId)
+		}
+	}
+	authUeIds, err := dbadapter.AuthDBClient.RestfulAPIDistinct(authSubsDataColl, "ueId", bson.M{"ueId": bson.M{"$in": ueIds}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subscribers with authentication data: %w", err)
+	}
+	withAuthData := map[string]bool{}
+	for _, ueId := range authUeIds {
+		if value, ok := ueId.(string); ok {
+			withAuthData[value] = true
+		}
+	}
+	matching := []map[string]any{}
+	for _, amData := range amDataList {
+		ueId, _ := amData["ueId"].(string)
+		if withAuthData[ueId] == *hasAuthData {
+			matching = append(matching, amData)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,355 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type PagedSubscribersMockDBClient struct {
+	dbadapter.DBInterface
+	amData         []map[string]any
+	deviceGroup    map[string]any
+	authUeIds      []any
+	countFilter    bson.M
+	pagedFilter    bson.M
+	pagedSort      bson.D
+	pagedLimit     int64
+	distinctFilter bson.M
+}
+
+func (db *PagedSubscribersMockDBClient) RestfulAPICount(coll string, filter bson.M) (int64, error) {
+	db.countFilter = filter
+	return int64(len(db.amData)), nil
+}
+
+func (db *PagedSubscribersMockDBClient) RestfulAPIGetManyPaged(coll string, filter bson.M, sort bson.D, limit int64) ([]map[string]any, error) {
+	db.pagedFilter = filter
+	db.pagedSort = sort
+	db.pagedLimit = limit
+	if limit > 0 && int64(len(db.amData)) > limit {
+		return db.amData[:limit], nil
+	}
+	return db.amData, nil
+}
+
+func (db *PagedSubscribersMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	return db.deviceGroup, nil
+}
+
+func (db *PagedSubscribersMockDBClient) RestfulAPIDistinct(coll string, fieldName string, filter bson.M) ([]any, error) {
+	db.distinctFilter = filter
+	return db.authUeIds, nil
+}
+
+func amDataWithUeId(ueId, plmnID string) map[string]any {
+	return map[string]any{"ueId": ueId, "servingPlmnId": plmnID}
+}
+
+func TestGetSubscribersPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+
+	dbAdapter := &PagedSubscribersMockDBClient{
+		amData: []map[string]any{
+			amDataWithUeId("imsi-208930100007487", "20893"),
+			amDataWithUeId("imsi-208930100007488", "20893"),
+			amDataWithUeId("imsi-208930100007489", "20893"),
+		},
+	}
+	origDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = origDBClient }()
+	dbadapter.CommonDBClient = dbAdapter
+
+	req, err := http.NewRequest(http.MethodGet, "/api/subscriber?limit=2&imsiPrefix=20893&plmnID=20893", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	expectedBody := `[{"plmnID":"20893","ueId":"imsi-208930100007487"},{"plmnID":"20893","ueId":"imsi-208930100007488"}]`
+	if w.Body.String() != expectedBody {
+		t.Errorf("expected `%v`, got `%v`", expectedBody, w.Body.String())
+	}
+	if total := w.Header().Get(totalCountHeader); total != "3" {
+		t.Errorf("expected total count 3, got %s", total)
+	}
+	expectedFilter := bson.M{"$and": []bson.M{
+		{"ueId": bson.M{"$regex": "^imsi-20893"}},
+		{"servingPlmnId": "20893"},
+	}}
+	if !reflect.DeepEqual(dbAdapter.countFilter, expectedFilter) {
+		t.Errorf("expected filter %v, got %v", expectedFilter, dbAdapter.countFilter)
+	}
+	if dbAdapter.pagedLimit != 2 {
+		t.Errorf("expected limit 2, got %d", dbAdapter.pagedLimit)
+	}
+
+	nextCursor := w.Header().Get(nextCursorHeader)
+	if nextCursor == "" {
+		t.Fatalf("expected a next cursor")
+	}
+	req, err = http.NewRequest(http.MethodGet, "/api/subscriber?limit=2&cursor="+nextCursor, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	expectedPageFilter := bson.M{"$or": []bson.M{
+		{"ueId": bson.M{"$gt": "imsi-208930100007488"}},
+		{"ueId": "imsi-208930100007488", "servingPlmnId": bson.M{"$gt": "20893"}},
+	}}
+	if !reflect.DeepEqual(dbAdapter.pagedFilter, expectedPageFilter) {
+		t.Errorf("expected page filter %v, got %v", expectedPageFilter, dbAdapter.pagedFilter)
+	}
+	expectedSort := bson.D{{Key: "ueId", Value: 1}, {Key: "servingPlmnId", Value: 1}}
+	if !reflect.DeepEqual(dbAdapter.pagedSort, expectedSort) {
+		t.Errorf("expected sort %v, got %v", expectedSort, dbAdapter.pagedSort)
+	}
+}
+
+func TestGetSubscribersPage_HasAuthData(t *testing.T) {
+	hasAuthData := true
+	dbAdapter := &PagedSubscribersMockDBClient{
+		amData: []map[string]any{
+			amDataWithUeId("imsi-208930100007487", "20893"),
+			amDataWithUeId("imsi-208930100007488", "20893"),
+			amDataWithUeId("imsi-208930100007488", "20894"),
+			amDataWithUeId("imsi-208930100007489", "20893"),
+		},
+		authUeIds: []any{"imsi-208930100007488"},
+	}
+	origDBClient := dbadapter.CommonDBClient
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() {
+		dbadapter.CommonDBClient = origDBClient
+		dbadapter.AuthDBClient = origAuthDBClient
+	}()
+	dbadapter.CommonDBClient = dbAdapter
+	dbadapter.AuthDBClient = dbAdapter
+
+	amDataList, total, nextCursor, err := getSubscribersPage(subscriberListQuery{sortField: "ueId", limit: 2, hasAuthData: &hasAuthData})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []map[string]any{
+		amDataWithUeId("imsi-208930100007488", "20893"),
+		amDataWithUeId("imsi-208930100007488", "20894"),
+	}
+	if !reflect.DeepEqual(amDataList, expected) {
+		t.Errorf("expected subscribers %v, got %v", expected, amDataList)
+	}
+	if total != -1 {
+		t.Errorf("expected an unknown total, got %d", total)
+	}
+	if dbAdapter.pagedLimit != SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE {
+		t.Errorf("expected batches of %d subscribers, got %d", SUBSCRIBERS_AUTH_FILTER_BATCH_SIZE, dbAdapter.pagedLimit)
+	}
+	expectedAuthFilter := bson.M{"ueId": bson.M{"$in": []string{
+		"imsi-208930100007487", "imsi-208930100007488", "imsi-208930100007488", "imsi-208930100007489",
+	}}}
+	if !reflect.DeepEqual(dbAdapter.distinctFilter, expectedAuthFilter) {
+		t.Errorf("expected authentication data filter %v, got %v", expectedAuthFilter, dbAdapter.distinctFilter)
+	}
+	plmnID := "20894"
+	expectedCursor := subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-208930100007488"}.encode()
+	if nextCursor != expectedCursor {
+		t.Errorf("expected next cursor %s, got %s", expectedCursor, nextCursor)
+	}
+}
+
+func TestGetSubscribersInvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{"limit too large", "limit=1001"},
+		{"limit not a number", "limit=abc"},
+		{"unknown sort field", "sort=key"},
+		{"invalid cursor", "cursor=not-a-cursor"},
+		{"invalid IMSI prefix", "imsiPrefix=abc"},
+		{"invalid PLMN", "plmnID=123"},
+		{"invalid hasAuthData", "hasAuthData=maybe"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/api/subscriber?"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestSubscriberListFilter(t *testing.T) {
+	dbAdapter := &PagedSubscribersMockDBClient{
+		deviceGroup: configmodels.ToBsonM(configmodels.DeviceGroups{
+			DeviceGroupName: "group1",
+			Imsis:           []string{"208930100007487"},
+		}),
+	}
+	origDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = origDBClient }()
+	dbadapter.CommonDBClient = dbAdapter
+
+	filter := subscriberListFilter(subscriberListQuery{deviceGroup: "group1"})
+	expectedFilter := bson.M{"ueId": bson.M{"$in": []string{"imsi-208930100007487"}}}
+	if !reflect.DeepEqual(filter, expectedFilter) {
+		t.Errorf("expected filter %v, got %v", expectedFilter, filter)
+	}
+}
+
+func TestFilterSubscribersByAuthData(t *testing.T) {
+	amDataList := []map[string]any{
+		amDataWithUeId("imsi-208930100007487", "20893"),
+		amDataWithUeId("imsi-208930100007488", "20893"),
+	}
+	dbAdapter := &PagedSubscribersMockDBClient{authUeIds: []any{"imsi-208930100007488"}}
+	origAuthDBClient := dbadapter.AuthDBClient
+	defer func() { dbadapter.AuthDBClient = origAuthDBClient }()
+	dbadapter.AuthDBClient = dbAdapter
+
+	hasAuthData := false
+	matching, err := filterSubscribersByAuthData(amDataList, &hasAuthData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []map[string]any{amDataWithUeId("imsi-208930100007487", "20893")}
+	if !reflect.DeepEqual(matching, expected) {
+		t.Errorf("expected subscribers %v, got %v", expected, matching)
+	}
+	expectedAuthFilter := bson.M{"ueId": bson.M{"$in": []string{"imsi-208930100007487", "imsi-208930100007488"}}}
+	if !reflect.DeepEqual(dbAdapter.distinctFilter, expectedAuthFilter) {
+		t.Errorf("expected authentication data filter %v, got %v", expectedAuthFilter, dbAdapter.distinctFilter)
+	}
+}
+
+func TestSubscriberListFilter_ImsiRange(t *testing.T) {
+	dbAdapter := &PagedSubscribersMockDBClient{
+		deviceGroup: configmodels.ToBsonM(configmodels.DeviceGroups{
+			DeviceGroupName: "group1",
+			Imsis:           []string{"208930100007487"},
+			ImsiRanges:      []configmodels.ImsiRange{{Start: "208930100000001", Count: 1000}},
+		}),
+	}
+	origDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = origDBClient }()
+	dbadapter.CommonDBClient = dbAdapter
+
+	filter := subscriberListFilter(subscriberListQuery{deviceGroup: "group1"})
+	expectedFilter := bson.M{"$or": []bson.M{
+		{"ueId": bson.M{"$gte": "imsi-208930100000001", "$lte": "imsi-208930100001000", "$regex": "^imsi-[0-9]{15}$"}},
+		{"ueId": bson.M{"$in": []string{"imsi-208930100007487"}}},
+	}}
+	if !reflect.DeepEqual(filter, expectedFilter) {
+		t.Errorf("expected filter %v, got %v", expectedFilter, filter)
+	}
+}
+
+func TestSubscriberListCursorFilter(t *testing.T) {
+	plmnID := "20893"
+	testCases := []struct {
+		name     string
+		query    subscriberListQuery
+		expected bson.M
+	}{
+		{
+			name:  "ascending ueId",
+			query: subscriberListQuery{sortField: "ueId", cursor: &subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-1"}},
+			expected: bson.M{"$or": []bson.M{
+				{"ueId": bson.M{"$gt": "imsi-1"}},
+				{"ueId": "imsi-1", "servingPlmnId": bson.M{"$gt": plmnID}},
+			}},
+		},
+		{
+			name:  "descending ueId",
+			query: subscriberListQuery{sortField: "ueId", descending: true, cursor: &subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-1"}},
+			expected: bson.M{"$or": []bson.M{
+				{"ueId": bson.M{"$lt": "imsi-1"}},
+				{"ueId": "imsi-1", "servingPlmnId": bson.M{"$lt": plmnID}},
+				{"ueId": "imsi-1", "servingPlmnId": nil},
+			}},
+		},
+		{
+			name:     "descending ueId after subscriber without PLMN",
+			query:    subscriberListQuery{sortField: "ueId", descending: true, cursor: &subscriberListCursor{UeId: "imsi-1"}},
+			expected: bson.M{"ueId": bson.M{"$lt": "imsi-1"}},
+		},
+		{
+			name:  "ascending PLMN",
+			query: subscriberListQuery{sortField: "servingPlmnId", cursor: &subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-1"}},
+			expected: bson.M{"$or": []bson.M{
+				{"servingPlmnId": bson.M{"$gt": plmnID}},
+				{"servingPlmnId": plmnID, "ueId": bson.M{"$gt": "imsi-1"}},
+			}},
+		},
+		{
+			name:  "ascending PLMN after subscriber without PLMN",
+			query: subscriberListQuery{sortField: "servingPlmnId", cursor: &subscriberListCursor{UeId: "imsi-1"}},
+			expected: bson.M{"$or": []bson.M{
+				{"servingPlmnId": bson.M{"$ne": nil}},
+				{"servingPlmnId": nil, "ueId": bson.M{"$gt": "imsi-1"}},
+			}},
+		},
+		{
+			name:  "descending PLMN",
+			query: subscriberListQuery{sortField: "servingPlmnId", descending: true, cursor: &subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-1"}},
+			expected: bson.M{"$or": []bson.M{
+				{"servingPlmnId": bson.M{"$lt": plmnID}},
+				{"servingPlmnId": nil},
+				{"servingPlmnId": plmnID, "ueId": bson.M{"$lt": "imsi-1"}},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := subscriberListCursorFilter(tc.query)
+			if !reflect.DeepEqual(filter, tc.expected) {
+				got, _ := json.Marshal(filter)
+				expected, _ := json.Marshal(tc.expected)
+				t.Errorf("expected filter %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestSubscriberListCursorEncoding(t *testing.T) {
+	plmnID := "20893"
+	cursor := subscriberListCursor{PlmnID: &plmnID, UeId: "imsi-208930100007487"}
+	decoded, err := decodeSubscriberListCursor(cursor.encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*decoded, cursor) {
+		t.Errorf("expected cursor %+v, got %+v", cursor, *decoded)
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// deviceGroupDataColls are the collections written by updatePolicyAndProvisionedData for the
+// IMSIs of a device group. The AM data is also created with the authentication subscription,
+// only its documents with a serving PLMN are related to a device group.
+var deviceGroupDataColls = []string{smDataColl, smfSelDataColl, amPolicyDataColl, smPolicyDataColl}
+
+var amDataDeviceGroupFilter = bson.M{"servingPlmnId": bson.M{"$exists": true}}
+
+var (
+	// reconciliationMutex prevents concurrent reconciliations
+	reconciliationMutex sync.Mutex
+	reportMutex         sync.RWMutex
+	lastReport          *configmodels.SubsReconciliationReport
+)
+
+// getLastReconciliationReport returns the report of the last reconciliation, or nil if none ran
+func getLastReconciliationReport() *configmodels.SubsReconciliationReport {
+	reportMutex.RLock()
+	defer reportMutex.RUnlock()
+	return lastReport
+}
+
+// RunSubscriberReconciliation reconciles the subscription data every interval until ctx is done
+func RunSubscriberReconciliation(ctx context.Context, interval time.Duration, repair bool) {
+	logger.WebUILog.Infof("subscriber reconciliation scheduled every %s (repair: %t)", interval, repair)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := ReconcileSubscriberData(repair); err != nil {
+				logger.WebUILog.Errorf("subscriber reconciliation failed: %+v", err)
+			}
+		}
+	}
+}
+
+// ReconcileSubscriberData scans the subscription collections for orphaned records and
+// inconsistencies. When repair is set the orphaned records are deleted, otherwise they
+// are only reported.
+func ReconcileSubscriberData(repair bool) (*configmodels.SubsReconciliationReport, error) {
+	reconciliationMutex.Lock()
+	defer reconciliationMutex.Unlock()
+	if repair {
+		// prevent device group updates from writing the records being deleted
+		rwLock.Lock()
+		defer rwLock.Unlock()
+	}
+	report := &configmodels.SubsReconciliationReport{
+		StartedAt: time.Now().UTC(),
+		Repair:    repair,
+		Findings:  []configmodels.SubsReconciliationFinding{},
+	}
+
+	authUeIds, err := distinctUeIds(dbadapter.AuthDBClient, authSubsDataColl, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	deviceGroupByImsi, imsiRanges, err := getDeviceGroupByImsi()
+	if err != nil {
+		return nil, err
+	}
+	ueIdsByColl := make(map[string]map[string]bool, len(subscriberDataColls))
+	for _, collName := range subscriberDataColls {
+		if ueIdsByColl[collName], err = distinctUeIds(dbadapter.CommonDBClient, collName, bson.M{}); err != nil {
+			return nil, err
+		}
+	}
+	amDataDeviceGroupUeIds, err := distinctUeIds(dbadapter.CommonDBClient, amDataColl, amDataDeviceGroupFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ueId := range sortedUeIds(ueIdsByColl) {
+		finding := configmodels.SubsReconciliationFinding{UeId: ueId}
+		if !authUeIds[ueId] {
+			finding.Issue = configmodels.SubsReconciliationNoAuthentication
+			for _, collName := range subscriberDataColls {
+				if ueIdsByColl[collName][ueId] {
+					finding.Collections = append(finding.Collections, collName)
+				}
+			}
+		} else if imsi, isImsi := strings.CutPrefix(ueId, "imsi-"); isImsi && deviceGroupByImsi[imsi] == "" &&
+			deviceGroupOfImsiRanges(imsiRanges, imsi) == "" {
+			finding.Issue = configmodels.SubsReconciliationNoDeviceGroup
+			if amDataDeviceGroupUeIds[ueId] {
+				finding.Collections = append(finding.Collections, amDataColl)
+			}
+			for _, collName := range deviceGroupDataColls {
+				if ueIdsByColl[collName][ueId] {
+					finding.Collections = append(finding.Collections, collName)
+				}
+			}
+		}
+		if len(finding.Collections) == 0 {
+			continue
+		}
+		if repair {
+			if err := repairSubscriberFinding(finding); err != nil {
+				logger.WebUILog.Errorf("failed to repair %s of %s: %+v", finding.Issue, ueId, err)
+				finding.Error = err.Error()
+			} else {
+				finding.Repaired = true
+				report.Repaired++
+			}
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+
+	imsis := make([]string, 0, len(deviceGroupByImsi))
+	for imsi := range deviceGroupByImsi {
+		imsis = append(imsis, imsi)
+	}
+	slices.Sort(imsis)
+	for _, imsi := range imsis {
+		if !authUeIds["imsi-"+imsi] {
+			report.Findings = append(report.Findings, configmodels.SubsReconciliationFinding{
+				UeId:        "imsi-" + imsi,
+				Issue:       configmodels.SubsReconciliationDeviceGroupWithoutAuthentication,
+				DeviceGroup: deviceGroupByImsi[imsi],
+			})
+		}
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	logger.WebUILog.Infof("subscriber reconciliation found %d issues, repaired %d", len(report.Findings), report.Repaired)
+	reportMutex.Lock()
+	lastReport = report
+	reportMutex.Unlock()
+	return report, nil
+}
+
+// repairSubscriberFinding deletes the orphaned records of finding in a single transaction
+func repairSubscriberFinding(finding configmodels.SubsReconciliationFinding) error {
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	return sessionRunner(context.TODO(), func(sc context.Context) error {
+		for _, collName := range finding.Collections {
+			filter := bson.M{"ueId": finding.UeId}
+			if collName == amDataColl && finding.Issue == configmodels.SubsReconciliationNoDeviceGroup {
+				filter["servingPlmnId"] = amDataDeviceGroupFilter["servingPlmnId"]
+			}
+			if err := dbadapter.CommonDBClient.RestfulAPIDeleteManyWithContext(sc, collName, filter); err != nil {
+				return fmt.Errorf("failed to delete %s data: %w", collName, err)
+			}
+		}
+		logger.WebUILog.Infof("deleted orphaned records of %s from %v", finding.UeId, finding.Collections)
+		return nil
+	})
+}
+
+func distinctUeIds(client dbadapter.DBInterface, collName string, filter bson.M) (map[string]bool, error) {
+	values, err := client.RestfulAPIDistinct(collName, "ueId", filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the UE IDs of %s: %w", collName, err)
+	}
+	ueIds := make(map[string]bool, len(values))
+	for _, value := range values {
+		if ueId, ok := value.(string); ok {
+			ueIds[ueId] = true
+		}
+	}
+	return ueIds, nil
+}
+
+// getDeviceGroupByImsi returns the device group of every IMSI listed individually, and
+// the IMSI ranges of all device groups. The ranges are not expanded.
+func getDeviceGroupByImsi() (map[string]string, []deviceGroupImsiRange, error) {
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch device groups: %w", err)
+	}
+	deviceGroupByImsi := make(map[string]string)
+	var imsiRanges []deviceGroupImsiRange
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		var deviceGroup configmodels.DeviceGroups
+		if err = json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal device group: %w", err)
+		}
+		for _, imsi := range deviceGroup.Imsis {
+			deviceGroupByImsi[imsi] = deviceGroup.DeviceGroupName
+		}
+		imsiRanges = appendDeviceGroupImsiRanges(imsiRanges, &deviceGroup)
+	}
+	return deviceGroupByImsi, imsiRanges, nil
+}
+
+func sortedUeIds(ueIdsByColl map[string]map[string]bool) []string {
+	union := make(map[string]bool)
+	for _, ueIds := range ueIdsByColl {
+		for ueId := range ueIds {
+			union[ueId] = true
+		}
+	}
+	sorted := make([]string, 0, len(union))
+	for ueId := range union {
+		sorted = append(sorted, ueId)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
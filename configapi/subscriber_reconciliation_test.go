@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ReconciliationMockDBClient struct {
+	dbadapter.DBInterface
+	ueIds          map[string][]any
+	deviceGroups   []configmodels.DeviceGroups
+	deleteErr      error
+	receivedDelete []map[string]any
+}
+
+func (db *ReconciliationMockDBClient) RestfulAPIDistinct(collName string, fieldName string, filter bson.M) ([]any, error) {
+	if collName == amDataColl && len(filter) > 0 {
+		return db.ueIds[amDataColl+".servingPlmnId"], nil
+	}
+	return db.ueIds[collName], nil
+}
+
+func (db *ReconciliationMockDBClient) RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	for _, deviceGroup := range db.deviceGroups {
+		results = append(results, configmodels.ToBsonM(deviceGroup))
+	}
+	return results, nil
+}
+
+func (db *ReconciliationMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return &MockSession{}, nil
+}
+
+func (db *ReconciliationMockDBClient) RestfulAPIDeleteManyWithContext(ctx context.Context, collName string, filter bson.M) error {
+	if db.deleteErr != nil {
+		return db.deleteErr
+	}
+	db.receivedDelete = append(db.receivedDelete, map[string]any{"coll": collName, "filter": filter})
+	return nil
+}
+
+func setupReconciliationMocks(t *testing.T, deleteErr error) *ReconciliationMockDBClient {
+	t.Helper()
+	origCommonDB := dbadapter.CommonDBClient
+	origAuthDB := dbadapter.AuthDBClient
+	t.Cleanup(func() {
+		dbadapter.CommonDBClient = origCommonDB
+		dbadapter.AuthDBClient = origAuthDB
+	})
+	commonDb := &ReconciliationMockDBClient{
+		ueIds: map[string][]any{
+			// imsi-208930100007487 is provisioned and belongs to group1
+			// imsi-208930100007488 has no authentication subscription
+			// imsi-208930100007489 was removed from its device group
+			amDataColl:                    {"imsi-208930100007487", "imsi-208930100007488", "imsi-208930100007489"},
+			amDataColl + ".servingPlmnId": {"imsi-208930100007487", "imsi-208930100007489"},
+			smDataColl:                    {"imsi-208930100007487", "imsi-208930100007489"},
+			smfSelDataColl:                {"imsi-208930100007487"},
+			amPolicyDataColl:              {"imsi-208930100007487", "imsi-208930100007488"},
+			smPolicyDataColl:              {"imsi-208930100007487"},
+		},
+		deviceGroups: []configmodels.DeviceGroups{
+			deviceGroupWithImsis("group1", []string{"208930100007487", "208930100007490"}),
+		},
+		deleteErr: deleteErr,
+	}
+	dbadapter.CommonDBClient = commonDb
+	dbadapter.AuthDBClient = &ReconciliationMockDBClient{
+		ueIds: map[string][]any{authSubsDataColl: {"imsi-208930100007487", "imsi-208930100007489"}},
+	}
+	return commonDb
+}
+
+func TestReconcileSubscriberData_ReportOnly(t *testing.T) {
+	commonDb := setupReconciliationMocks(t, nil)
+
+	report, err := ReconcileSubscriberData(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []configmodels.SubsReconciliationFinding{
+		{UeId: "imsi-208930100007488", Issue: configmodels.SubsReconciliationNoAuthentication, Collections: []string{amDataColl, amPolicyDataColl}},
+		{UeId: "imsi-208930100007489", Issue: configmodels.SubsReconciliationNoDeviceGroup, Collections: []string{amDataColl, smDataColl}},
+		{UeId: "imsi-208930100007490", Issue: configmodels.SubsReconciliationDeviceGroupWithoutAuthentication, DeviceGroup: "group1"},
+	}
+	if !reflect.DeepEqual(report.Findings, expected) {
+		t.Errorf("expected findings %+v, got %+v", expected, report.Findings)
+	}
+	if report.Repair || report.Repaired != 0 || len(commonDb.receivedDelete) != 0 {
+		t.Errorf("expected nothing to be repaired in report-only mode, got %d deletions", len(commonDb.receivedDelete))
+	}
+	if getLastReconciliationReport() != report {
+		t.Errorf("expected the report to be kept as the last report")
+	}
+}
+
+func TestReconcileSubscriberData_ImsiRange(t *testing.T) {
+	commonDb := setupReconciliationMocks(t, nil)
+	rangeGroup := deviceGroupWithImsis("group2", []string{})
+	rangeGroup.ImsiRanges = []configmodels.ImsiRange{{Start: "208930100007489", Count: 1000}}
+	commonDb.deviceGroups = append(commonDb.deviceGroups, rangeGroup)
+
+	report, err := ReconcileSubscriberData(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, finding := range report.Findings {
+		if finding.UeId == "imsi-208930100007489" {
+			t.Errorf("expected an IMSI of a device group range not to be reported, got %+v", finding)
+		}
+	}
+	if len(report.Findings) != 2 {
+		t.Errorf("expected the unprovisioned IMSIs of the range not to be reported, got %+v", report.Findings)
+	}
+}
+
+func TestReconcileSubscriberData_Repair(t *testing.T) {
+	commonDb := setupReconciliationMocks(t, nil)
+
+	report, err := ReconcileSubscriberData(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Repaired != 2 {
+		t.Fatalf("expected 2 repaired findings, got %d", report.Repaired)
+	}
+	if report.Findings[2].Repaired {
+		t.Errorf("expected a device group IMSI without authentication subscription not to be repaired")
+	}
+	expected := []map[string]any{
+		{"coll": amDataColl, "filter": bson.M{"ueId": "imsi-208930100007488"}},
+		{"coll": amPolicyDataColl, "filter": bson.M{"ueId": "imsi-208930100007488"}},
+		{"coll": amDataColl, "filter": bson.M{"ueId": "imsi-208930100007489", "servingPlmnId": bson.M{"$exists": true}}},
+		{"coll": smDataColl, "filter": bson.M{"ueId": "imsi-208930100007489"}},
+	}
+	if !reflect.DeepEqual(commonDb.receivedDelete, expected) {
+		t.Errorf("expected deletions %v, got %v", expected, commonDb.receivedDelete)
+	}
+}
+
+func TestReconcileSubscriberData_RepairFailure(t *testing.T) {
+	setupReconciliationMocks(t, fmt.Errorf("mock error"))
+
+	report, err := ReconcileSubscriberData(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Repaired != 0 {
+		t.Errorf("expected no repaired finding, got %d", report.Repaired)
+	}
+	if report.Findings[0].Repaired || report.Findings[0].Error == "" {
+		t.Errorf("expected the repair error to be reported, got %+v", report.Findings[0])
+	}
+}
+
+func TestReconcileSubscribersHandler(t *testing.T) {
+	origConfig := factory.WebUIConfig
+	defer func() { factory.WebUIConfig = origConfig }()
+	factory.WebUIConfig = &factory.Config{Configuration: &factory.Configuration{EnableAuthentication: true}}
+	setupReconciliationMocks(t, nil)
+
+	tests := []struct {
+		name         string
+		method       string
+		query        string
+		expectedCode int
+	}{
+		{name: "Report only", method: http.MethodPost, expectedCode: http.StatusOK},
+		{name: "Repair by a non admin user", method: http.MethodPost, query: "?repair=true", expectedCode: http.StatusForbidden},
+		{name: "Invalid repair value", method: http.MethodPost, query: "?repair=maybe", expectedCode: http.StatusBadRequest},
+		{name: "Last report", method: http.MethodGet, expectedCode: http.StatusOK},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddApiService(router)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "/api/subscriber-reconciliation"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var report configmodels.SubsReconciliationReport
+			if err = json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to unmarshal report: %v", err)
+			}
+			if len(report.Findings) != 3 {
+				t.Errorf("expected 3 findings, got %+v", report.Findings)
+			}
+		})
+	}
+}
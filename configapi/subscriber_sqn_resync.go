@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/util/milenage"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const sqnResyncDataColl = "webconsoleData.audit.sqnResyncData"
+
+// errSqnResyncMacMismatch is returned when the MAC-S of an AUTS does not match the subscriber keys
+var errSqnResyncMacMismatch = errors.New("AUTS MAC-S verification failed")
+
+// validateSqnResync checks that resync holds either a sequence number or a RAND and AUTS pair,
+// and returns the resynchronisation method.
+func validateSqnResync(resync *configmodels.SubsSqnResync) (string, error) {
+	resync.SequenceNumber = strings.ToLower(resync.SequenceNumber)
+	resync.Rand = strings.ToLower(resync.Rand)
+	resync.Auts = strings.ToLower(resync.Auts)
+	switch {
+	case resync.SequenceNumber != "" && (resync.Rand != "" || resync.Auts != ""):
+		return "", errors.New("sequenceNumber cannot be combined with rand and auts")
+	case resync.SequenceNumber != "":
+		if !isValidSqn(resync.SequenceNumber) {
+			return "", fmt.Errorf("sequenceNumber must be %d hexadecimal characters", SQN_HEX_LENGTH)
+		}
+		return configmodels.SubsSqnResyncMethodSqn, nil
+	case resync.Rand != "" || resync.Auts != "":
+		if !isValidHexString(resync.Rand, RAND_HEX_LENGTH) {
+			return "", fmt.Errorf("rand must be %d hexadecimal characters", RAND_HEX_LENGTH)
+		}
+		if !isValidHexString(resync.Auts, AUTS_HEX_LENGTH) {
+			return "", fmt.Errorf("auts must be %d hexadecimal characters", AUTS_HEX_LENGTH)
+		}
+		return configmodels.SubsSqnResyncMethodAuts, nil
+	default:
+		return "", errors.New("either sequenceNumber or rand and auts must be provided")
+	}
+}
+
+// sqnFromAuts recovers SQN_MS from an AUTS reported by the UE, as specified in TS 33.102
+// section 6.3.5, and returns the next sequence number to use for the subscriber.
+// AUTS = (SQN_MS XOR AK*) || MAC-S, where AK* = f5*(RAND) and MAC-S = f1*(SQN_MS || RAND || AMF)
+// with a dummy AMF of 0000.
+func sqnFromAuts(authSubData *models.AuthenticationSubscription, randHex, autsHex string) (string, error) {
+	if authSubData.EncPermanentKey == nil || authSubData.EncOpcKey == nil {
+		return "", errors.New("subscriber has no K or OPc")
+	}
+	k, err := hex.DecodeString(*authSubData.EncPermanentKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid K: %w", err)
+	}
+	opc, err := hex.DecodeString(*authSubData.EncOpcKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid OPc: %w", err)
+	}
+	rand, err := hex.DecodeString(randHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid RAND: %w", err)
+	}
+	auts, err := hex.DecodeString(autsHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid AUTS: %w", err)
+	}
+
+	akStar := make([]byte, 6)
+	if err = milenage.F2345(opc, k, rand, nil, nil, nil, nil, akStar); err != nil {
+		return "", err
+	}
+	sqnMs := make([]byte, 6)
+	for i := range sqnMs {
+		sqnMs[i] = auts[i] ^ akStar[i]
+	}
+	macS := make([]byte, 8)
+	if err = milenage.F1(opc, k, rand, sqnMs, []byte{0x00, 0x00}, nil, macS); err != nil {
+		return "", err
+	}
+	if subtle.ConstantTimeCompare(macS, auts[6:]) != 1 {
+		return "", errSqnResyncMacMismatch
+	}
+	return nextSqn(sqnMs), nil
+}
+
+// nextSqn returns the hexadecimal encoding of the 48-bit sequence number following sqn
+func nextSqn(sqn []byte) string {
+	next := make([]byte, len(sqn))
+	copy(next, sqn)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return hex.EncodeToString(next)
+}
+
+// subscriberSequenceNumberResync updates the sequence number of a subscriber without changing
+// its K and OPc, and records the resynchronisation in the same transaction. resync must have
+// been validated with validateSqnResync, which returned method.
+func subscriberSequenceNumberResync(imsi, method string, resync configmodels.SubsSqnResync, username string) (*configmodels.SubsSqnResyncRecord, error) {
+	authSubsDataInterface, err := dbadapter.AuthDBClient.RestfulAPIGetOne(authSubsDataColl, bson.M{"ueId": imsi})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch authentication subscription: %w", err)
+	}
+	if authSubsDataInterface == nil {
+		return nil, errSubscriberNotFound
+	}
+	var authSubData models.AuthenticationSubscription
+	if err = json.Unmarshal(configmodels.MapToByte(authSubsDataInterface), &authSubData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authentication subscription: %w", err)
+	}
+
+	newSqn := resync.SequenceNumber
+	if method == configmodels.SubsSqnResyncMethodAuts {
+		// the stored copy keeps its encrypted secrets, only the SQN is replaced
+		decrypted := authSubData
+		if err = decryptAuthenticationSubscription(&decrypted); err != nil {
+			return nil, fmt.Errorf("failed to decrypt authentication subscription: %w", err)
+		}
+		newSqn, err = sqnFromAuts(&decrypted, resync.Rand, resync.Auts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record := &configmodels.SubsSqnResyncRecord{
+		UeId:           imsi,
+		SequenceNumber: newSqn,
+		Method:         method,
+		Username:       username,
+		Timestamp:      time.Now().UTC(),
+	}
+	if authSubData.SequenceNumber != nil && authSubData.SequenceNumber.Sqn != nil {
+		record.PreviousSequenceNumber = *authSubData.SequenceNumber.Sqn
+	}
+	sequenceNumber := models.SequenceNumber{}
+	if authSubData.SequenceNumber != nil {
+		sequenceNumber = *authSubData.SequenceNumber
+	}
+	sequenceNumber.Sqn = &newSqn
+	authSubData.SequenceNumber = &sequenceNumber
+
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	err = sessionRunner(context.TODO(), func(sc context.Context) error {
+		if err := subscriberAuthenticationDataUpdateWithContext(sc, imsi, &authSubData); err != nil {
+			return err
+		}
+		if err := dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(sc, sqnResyncDataColl, bson.M{}, []any{configmodels.ToBsonM(record)}); err != nil {
+			logger.DbLog.Errorf("failed to record sequence number resynchronisation error: %+v", err)
+			return err
+		}
+		logger.WebUILog.Debugf("recorded sequence number resynchronisation of %s by %q", imsi, username)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/util/milenage"
+	"github.com/omec-project/webconsole/backend/auth"
+	"github.com/omec-project/webconsole/backend/encryption"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const testRand = "23553cbe9637a89d218ae64dae47bf35"
+
+// generateAuts computes the AUTS a UE with the test keys would report for sqnMs
+func generateAuts(t *testing.T, sqnMs string) string {
+	t.Helper()
+	k, _ := hex.DecodeString(testPermanentKey)
+	opc, _ := hex.DecodeString(testOpcKey)
+	rand, _ := hex.DecodeString(testRand)
+	sqn, _ := hex.DecodeString(sqnMs)
+	akStar := make([]byte, 6)
+	if err := milenage.F2345(opc, k, rand, nil, nil, nil, nil, akStar); err != nil {
+		t.Fatalf("failed to compute AK*: %v", err)
+	}
+	macS := make([]byte, 8)
+	if err := milenage.F1(opc, k, rand, sqn, []byte{0x00, 0x00}, nil, macS); err != nil {
+		t.Fatalf("failed to compute MAC-S: %v", err)
+	}
+	auts := make([]byte, 0, 14)
+	for i := range sqn {
+		auts = append(auts, sqn[i]^akStar[i])
+	}
+	return hex.EncodeToString(append(auts, macS...))
+}
+
+type SqnResyncMockDBClient struct {
+	dbadapter.DBInterface
+	authData map[string]any
+	getErr   error
+}
+
+func (db *SqnResyncMockDBClient) RestfulAPIGetOne(collName string, filter bson.M) (map[string]any, error) {
+	if db.getErr != nil {
+		return nil, db.getErr
+	}
+	return db.authData, nil
+}
+
+func Test_validateSqnResync(t *testing.T) {
+	tests := []struct {
+		name           string
+		resync         configmodels.SubsSqnResync
+		expectedMethod string
+		expectedError  string
+	}{
+		{name: "Sequence number", resync: configmodels.SubsSqnResync{SequenceNumber: "16F3B3F70FC3"}, expectedMethod: configmodels.SubsSqnResyncMethodSqn},
+		{name: "RAND and AUTS", resync: configmodels.SubsSqnResync{Rand: testRand, Auts: "0123456789abcdef0123456789ab"}, expectedMethod: configmodels.SubsSqnResyncMethodAuts},
+		{name: "Empty", resync: configmodels.SubsSqnResync{}, expectedError: "either sequenceNumber or rand and auts"},
+		{name: "Both", resync: configmodels.SubsSqnResync{SequenceNumber: "16f3b3f70fc3", Auts: "0123456789abcdef0123456789ab"}, expectedError: "cannot be combined"},
+		{name: "Invalid sequence number", resync: configmodels.SubsSqnResync{SequenceNumber: "16f3b3f70fc"}, expectedError: "sequenceNumber must be"},
+		{name: "Missing RAND", resync: configmodels.SubsSqnResync{Auts: "0123456789abcdef0123456789ab"}, expectedError: "rand must be"},
+		{name: "Invalid AUTS", resync: configmodels.SubsSqnResync{Rand: testRand, Auts: "0123456789abcdef0123456789az"}, expectedError: "auts must be"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			method, err := validateSqnResync(&tc.resync)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if method != tc.expectedMethod {
+				t.Errorf("expected method %s, got %s", tc.expectedMethod, method)
+			}
+		})
+	}
+}
+
+func Test_sqnFromAuts(t *testing.T) {
+	authSubData := authenticationSubscription()
+	sqn, err := sqnFromAuts(authSubData, testRand, generateAuts(t, "0000000012ff"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sqn != "000000001300" {
+		t.Errorf("expected SQN 000000001300, got %s", sqn)
+	}
+
+	auts := generateAuts(t, "0000000012ff")
+	tampered := auts[:len(auts)-2] + "00"
+	if tampered == auts {
+		tampered = auts[:len(auts)-2] + "01"
+	}
+	if _, err = sqnFromAuts(authSubData, testRand, tampered); !errors.Is(err, errSqnResyncMacMismatch) {
+		t.Errorf("expected MAC-S mismatch, got %v", err)
+	}
+}
+
+func Test_nextSqn(t *testing.T) {
+	tests := map[string]string{
+		"000000000000": "000000000001",
+		"16f3b3f70fc2": "16f3b3f70fc3",
+		"0000000000ff": "000000000100",
+		"ffffffffffff": "000000000000",
+	}
+	for sqn, expected := range tests {
+		value, _ := hex.DecodeString(sqn)
+		if next := nextSqn(value); next != expected {
+			t.Errorf("expected next SQN of %s to be %s, got %s", sqn, expected, next)
+		}
+	}
+}
+
+func TestResyncSubscriberSequenceNumber(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+	gin.SetMode(gin.TestMode)
+
+	storedAuthData := func() map[string]any {
+		authData := configmodels.ToBsonM(authenticationSubscription())
+		authData["ueId"] = "imsi-208930100007487"
+		return authData
+	}
+	tests := []struct {
+		name          string
+		authDb        *SqnResyncMockDBClient
+		commonDb      *txMockDB
+		body          string
+		expectedCode  int
+		expectedSqn   string
+		expectedError string
+	}{
+		{
+			name:         "New sequence number",
+			authDb:       &SqnResyncMockDBClient{authData: storedAuthData()},
+			commonDb:     &txMockDB{},
+			body:         `{"sequenceNumber": "0000000000A0"}`,
+			expectedCode: http.StatusOK,
+			expectedSqn:  "0000000000a0",
+		},
+		{
+			name:         "AUTS",
+			authDb:       &SqnResyncMockDBClient{authData: storedAuthData()},
+			commonDb:     &txMockDB{},
+			body:         `{"rand": "` + testRand + `", "auts": "` + generateAuts(t, "16f3b3f70fd0") + `"}`,
+			expectedCode: http.StatusOK,
+			expectedSqn:  "16f3b3f70fd1",
+		},
+		{
+			name:          "AUTS with invalid MAC-S",
+			authDb:        &SqnResyncMockDBClient{authData: storedAuthData()},
+			commonDb:      &txMockDB{},
+			body:          `{"rand": "` + testRand + `", "auts": "0123456789abcdef0123456789ab"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "MAC-S verification failed",
+		},
+		{
+			name:          "Invalid request",
+			authDb:        &SqnResyncMockDBClient{authData: storedAuthData()},
+			commonDb:      &txMockDB{},
+			body:          `{"sequenceNumber": "xyz"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "sequenceNumber must be",
+		},
+		{
+			name:          "Subscriber not found",
+			authDb:        &SqnResyncMockDBClient{},
+			commonDb:      &txMockDB{},
+			body:          `{"sequenceNumber": "0000000000a0"}`,
+			expectedCode:  http.StatusNotFound,
+			expectedError: "does not exist",
+		},
+		{
+			name:          "DB failure",
+			authDb:        &SqnResyncMockDBClient{getErr: errors.New("mock error")},
+			commonDb:      &txMockDB{},
+			body:          `{"sequenceNumber": "0000000000a0"}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to resynchronise",
+		},
+		{
+			name:          "Record failure",
+			authDb:        &SqnResyncMockDBClient{authData: storedAuthData()},
+			commonDb:      &txMockDB{postManyWithCtxErr: errors.New("mock error")},
+			body:          `{"sequenceNumber": "0000000000a0"}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to resynchronise",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			origAuthDB := dbadapter.AuthDBClient
+			origCommonDB := dbadapter.CommonDBClient
+			defer func() {
+				dbadapter.AuthDBClient = origAuthDB
+				dbadapter.CommonDBClient = origCommonDB
+			}()
+			dbadapter.AuthDBClient = tc.authDb
+			dbadapter.CommonDBClient = tc.commonDb
+
+			router := gin.Default()
+			router.Use(func(c *gin.Context) { c.Set(auth.UsernameContextKey, "janedoe") })
+			AddApiService(router)
+			req, err := http.NewRequest(http.MethodPost, "/api/subscriber/imsi-208930100007487/sqn-resync", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedError != "" {
+				if !strings.Contains(w.Body.String(), tc.expectedError) {
+					t.Errorf("expected error containing %q, got %s", tc.expectedError, w.Body.String())
+				}
+				if len(tc.commonDb.receivedPutOneOnDB) != 0 && tc.commonDb.postManyWithCtxErr == nil {
+					t.Errorf("expected no update, got %v", tc.commonDb.receivedPutOneOnDB)
+				}
+				return
+			}
+
+			var record configmodels.SubsSqnResyncRecord
+			if err = json.Unmarshal(w.Body.Bytes(), &record); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if record.SequenceNumber != tc.expectedSqn || record.PreviousSequenceNumber != "16f3b3f70fc2" || record.Username != "janedoe" {
+				t.Errorf("unexpected resync record %+v", record)
+			}
+			if len(tc.commonDb.receivedPutOneOnDB) != 1 {
+				t.Fatalf("expected 1 PutOneOnDB call, got %d", len(tc.commonDb.receivedPutOneOnDB))
+			}
+			data := tc.commonDb.receivedPutOneOnDB[0]["data"].(map[string]any)
+			if data["encPermanentKey"] != testPermanentKey || data["encOpcKey"] != testOpcKey {
+				t.Errorf("expected K and OPc to be unchanged, got %v", data)
+			}
+			if sqn := data["sequenceNumber"].(map[string]any)["sqn"]; sqn != tc.expectedSqn {
+				t.Errorf("expected SQN %s, got %v", tc.expectedSqn, sqn)
+			}
+			if len(tc.commonDb.receivedPostManyCtx) != 1 || tc.commonDb.receivedPostManyCtx[0]["coll"] != sqnResyncDataColl {
+				t.Errorf("expected the resynchronisation to be recorded, got %v", tc.commonDb.receivedPostManyCtx)
+			}
+		})
+	}
+}
+
+func TestSubscriberSequenceNumberResync_KeepsEncryptedSecrets(t *testing.T) {
+	cleanupFactory := setupTestFactory()
+	defer cleanupFactory()
+	setupTestKeyRing(t, "key1")
+
+	encrypted, err := encryptAuthenticationSubscription(authenticationSubscription())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authData := configmodels.ToBsonM(encrypted)
+	authData["ueId"] = "imsi-208930100007487"
+	origAuthDB := dbadapter.AuthDBClient
+	origCommonDB := dbadapter.CommonDBClient
+	defer func() {
+		dbadapter.AuthDBClient = origAuthDB
+		dbadapter.CommonDBClient = origCommonDB
+	}()
+	dbadapter.AuthDBClient = &SqnResyncMockDBClient{authData: authData}
+	commonDb := &txMockDB{}
+	dbadapter.CommonDBClient = commonDb
+
+	resync := configmodels.SubsSqnResync{Rand: testRand, Auts: generateAuts(t, "16f3b3f70fd0")}
+	record, err := subscriberSequenceNumberResync("imsi-208930100007487", configmodels.SubsSqnResyncMethodAuts, resync, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.SequenceNumber != "16f3b3f70fd1" {
+		t.Errorf("expected SQN 16f3b3f70fd1, got %s", record.SequenceNumber)
+	}
+	data := commonDb.receivedPutOneOnDB[0]["data"].(map[string]any)
+	if data["encPermanentKey"] != *encrypted.EncPermanentKey || !encryption.IsEncrypted(data["encOpcKey"].(string)) {
+		t.Errorf("expected the stored encrypted K and OPc, got %v", data)
+	}
+}
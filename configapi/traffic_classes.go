@@ -0,0 +1,470 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const trafficClassDataColl = "webconsoleData.snapshots.trafficClassData"
+
+var (
+	errTrafficClassNotFound         = errors.New("traffic class not found")
+	errTrafficClassExists           = errors.New("traffic class already exists")
+	errTrafficClassInUse            = errors.New("traffic class is referenced")
+	errInvalidTrafficClassReference = errors.New("invalid traffic class reference")
+)
+
+// fetchTrafficClass returns the traffic class named name, or nil if it does not exist
+func fetchTrafficClass(name string) (*configmodels.TrafficClassInfo, error) {
+	rawTrafficClass, err := dbadapter.CommonDBClient.RestfulAPIGetOne(trafficClassDataColl, bson.M{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch traffic class %s: %w", name, err)
+	}
+	if len(rawTrafficClass) == 0 {
+		return nil, nil
+	}
+	var trafficClass configmodels.TrafficClassInfo
+	if err = json.Unmarshal(configmodels.MapToByte(rawTrafficClass), &trafficClass); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal traffic class %s: %w", name, err)
+	}
+	return &trafficClass, nil
+}
+
+// validateTrafficClass checks the 5QI, ARP, packet delay budget and packet error loss rate
+// of trafficClass
+func validateTrafficClass(trafficClass *configmodels.TrafficClassInfo) error {
+	if !isValid5qi(trafficClass.Qci) {
+		return fmt.Errorf("invalid 5QI %d: expected a standardized 5QI or an operator-specific 5QI from %d to %d", trafficClass.Qci, MIN_OPERATOR_5QI, MAX_OPERATOR_5QI)
+	}
+	if !isValidArp(trafficClass.Arp) {
+		return fmt.Errorf("invalid ARP %d: expected an integer from %d to %d", trafficClass.Arp, MIN_ARP, MAX_ARP)
+	}
+	if trafficClass.Pdb < 0 {
+		return fmt.Errorf("invalid packet delay budget %d", trafficClass.Pdb)
+	}
+	if trafficClass.Pelr < 0 {
+		return fmt.Errorf("invalid packet error loss rate %d", trafficClass.Pelr)
+	}
+	return nil
+}
+
+// isTrafficClassReference returns whether trafficClass only gives the name of a traffic class
+func isTrafficClassReference(trafficClass configmodels.TrafficClassInfo) bool {
+	return trafficClass.Name != "" && trafficClass == configmodels.TrafficClassInfo{Name: trafficClass.Name}
+}
+
+// resolveTrafficClass replaces trafficClass, when it only references a traffic class by name,
+// by the definition of that traffic class. A traffic class given with its values must match
+// the definition of the traffic class of the same name, if there is one.
+func resolveTrafficClass(trafficClass *configmodels.TrafficClassInfo) error {
+	if trafficClass.Name == "" {
+		return nil
+	}
+	definition, err := fetchTrafficClass(trafficClass.Name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case definition == nil && isTrafficClassReference(*trafficClass):
+		return fmt.Errorf("%w: traffic class %s does not exist", errInvalidTrafficClassReference, trafficClass.Name)
+	case definition == nil:
+		return nil
+	case isTrafficClassReference(*trafficClass):
+		*trafficClass = *definition
+		return nil
+	case *trafficClass != *definition:
+		return fmt.Errorf("%w: traffic class %s differs from its definition %+v", errInvalidTrafficClassReference, trafficClass.Name, *definition)
+	}
+	return nil
+}
+
+// resolveIpDomainTrafficClasses resolves the traffic classes of the QoS of ipDomains
+func resolveIpDomainTrafficClasses(ipDomains []configmodels.DeviceGroupsIpDomainExpanded) error {
+	for _, trafficClass := range ipDomainTrafficClasses(ipDomains) {
+		if err := resolveTrafficClass(trafficClass); err != nil {
+			return err
+		}
+		if !isValid5qi(trafficClass.Qci) {
+			return fmt.Errorf("%w: invalid 5QI %d of traffic class %s: expected a standardized 5QI or an operator-specific 5QI from %d to %d", errInvalidTrafficClassReference, trafficClass.Qci, trafficClass.Name, MIN_OPERATOR_5QI, MAX_OPERATOR_5QI)
+		}
+	}
+	return nil
+}
+
+// ipDomainTrafficClasses returns the traffic classes of the QoS of ipDomains
+func ipDomainTrafficClasses(ipDomains []configmodels.DeviceGroupsIpDomainExpanded) []*configmodels.TrafficClassInfo {
+	var trafficClasses []*configmodels.TrafficClassInfo
+	for _, ipDomain := range ipDomains {
+		if ipDomain.UeDnnQos != nil && ipDomain.UeDnnQos.TrafficClass != nil {
+			trafficClasses = append(trafficClasses, ipDomain.UeDnnQos.TrafficClass)
+		}
+	}
+	return trafficClasses
+}
+
+// sliceTrafficClasses returns the traffic classes of the application filtering rules and of
+// the default QoS of slice
+func sliceTrafficClasses(slice *configmodels.Slice) []*configmodels.TrafficClassInfo {
+	var trafficClasses []*configmodels.TrafficClassInfo
+	for _, rule := range slice.ApplicationFilteringRules {
+		if rule.TrafficClass != nil {
+			trafficClasses = append(trafficClasses, rule.TrafficClass)
+		}
+	}
+	if slice.Qos != nil && slice.Qos.TrafficClass != nil {
+		trafficClasses = append(trafficClasses, slice.Qos.TrafficClass)
+	}
+	return trafficClasses
+}
+
+// setTrafficClass replaces the traffic classes of trafficClasses named like definition by
+// definition, and returns whether one of them changed
+func setTrafficClass(trafficClasses []*configmodels.TrafficClassInfo, definition configmodels.TrafficClassInfo) bool {
+	changed := false
+	for _, trafficClass := range trafficClasses {
+		if trafficClass.Name == definition.Name && *trafficClass != definition {
+			*trafficClass = definition
+			changed = true
+		}
+	}
+	return changed
+}
+
+// usesTrafficClass returns whether one of trafficClasses is named name
+func usesTrafficClass(trafficClasses []*configmodels.TrafficClassInfo, name string) bool {
+	for _, trafficClass := range trafficClasses {
+		if trafficClass.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func getDeviceGroupTemplates() ([]configmodels.DeviceGroupTemplate, error) {
+	rawTemplates, err := dbadapter.CommonDBClient.RestfulAPIGetMany(deviceGroupTemplateDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch device group templates: %w", err)
+	}
+	templates := make([]configmodels.DeviceGroupTemplate, 0, len(rawTemplates))
+	for _, rawTemplate := range rawTemplates {
+		var template configmodels.DeviceGroupTemplate
+		if err = json.Unmarshal(configmodels.MapToByte(rawTemplate), &template); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal device group template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// trafficClassUsers returns the network slices, device groups and device group templates
+// using the traffic class named name
+func trafficClassUsers(name string) ([]string, error) {
+	var users []string
+	for _, slice := range getSlices() {
+		if usesTrafficClass(sliceTrafficClasses(slice), name) {
+			users = append(users, "network slice "+slice.SliceName)
+		}
+	}
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		return nil, err
+	}
+	for _, devGroup := range devGroups {
+		if usesTrafficClass(ipDomainTrafficClasses(devGroup.IpDomainsExpanded), name) {
+			users = append(users, "device group "+devGroup.DeviceGroupName)
+		}
+	}
+	templates, err := getDeviceGroupTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		if usesTrafficClass(ipDomainTrafficClasses(template.IpDomainsExpanded), name) {
+			users = append(users, "device group template "+template.TemplateName)
+		}
+	}
+	return users, nil
+}
+
+// propagateTrafficClass updates the copies of trafficClass held by the network slices,
+// device groups and device group templates using it, and the data of the subscribers of the
+// network slices and device groups. A network slice or device group failing to be updated
+// does not prevent the others from being updated.
+func propagateTrafficClass(trafficClass configmodels.TrafficClassInfo) error {
+	var failed []string
+	for _, slice := range getSlices() {
+		if !setTrafficClass(sliceTrafficClasses(slice), trafficClass) {
+			continue
+		}
+		prevSlice := getSliceByName(slice.SliceName)
+		if prevSlice == nil {
+			failed = append(failed, "network slice "+slice.SliceName)
+			continue
+		}
+		if _, err := updateNS(*slice, *prevSlice); err != nil {
+			logger.ConfigLog.Errorf("failed to update traffic class %s of network slice %s: %+v", trafficClass.Name, slice.SliceName, err)
+			failed = append(failed, "network slice "+slice.SliceName)
+		}
+	}
+	devGroups, err := getDeviceGroups("")
+	if err != nil {
+		return err
+	}
+	for _, devGroup := range devGroups {
+		if !setTrafficClass(ipDomainTrafficClasses(devGroup.IpDomainsExpanded), trafficClass) {
+			continue
+		}
+		if _, err = updateDG(&devGroup, getDeviceGroupByName(devGroup.DeviceGroupName)); err != nil {
+			logger.ConfigLog.Errorf("failed to update traffic class %s of device group %s: %+v", trafficClass.Name, devGroup.DeviceGroupName, err)
+			failed = append(failed, "device group "+devGroup.DeviceGroupName)
+		}
+	}
+	templates, err := getDeviceGroupTemplates()
+	if err != nil {
+		return err
+	}
+	for _, template := range templates {
+		if !setTrafficClass(ipDomainTrafficClasses(template.IpDomainsExpanded), trafficClass) {
+			continue
+		}
+		filter := bson.M{"template-name": template.TemplateName}
+		if _, err = dbadapter.CommonDBClient.RestfulAPIPost(deviceGroupTemplateDataColl, filter, configmodels.ToBsonM(template)); err != nil {
+			logger.ConfigLog.Errorf("failed to update traffic class %s of device group template %s: %+v", trafficClass.Name, template.TemplateName, err)
+			failed = append(failed, "device group template "+template.TemplateName)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// trafficClassPostHelper validates and stores trafficClass, and updates the network slices,
+// device groups and device group templates using it. An existing traffic class is rejected
+// unless overwrite is set.
+func trafficClassPostHelper(trafficClass configmodels.TrafficClassInfo, name string, overwrite bool) (int, error) {
+	if !isValidName(name) {
+		return http.StatusBadRequest, fmt.Errorf("invalid traffic class name %s. Name needs to match regular expression: %s", name, NAME_PATTERN)
+	}
+	trafficClass.Name = name
+	if err := validateTrafficClass(&trafficClass); err != nil {
+		return http.StatusBadRequest, err
+	}
+	statusCode, err := storeTrafficClass(trafficClass, overwrite)
+	if err != nil {
+		return statusCode, err
+	}
+	if err = propagateTrafficClass(trafficClass); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func storeTrafficClass(trafficClass configmodels.TrafficClassInfo, overwrite bool) (int, error) {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	if !overwrite {
+		existing, err := fetchTrafficClass(trafficClass.Name)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if existing != nil {
+			return http.StatusConflict, errTrafficClassExists
+		}
+	}
+	filter := bson.M{"name": trafficClass.Name}
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPost(trafficClassDataColl, filter, configmodels.ToBsonM(trafficClass)); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to store traffic class %s: %w", trafficClass.Name, err)
+	}
+	return http.StatusOK, nil
+}
+
+// trafficClassDeleteHelper deletes the traffic class named name, unless network slices,
+// device groups or device group templates use it
+func trafficClassDeleteHelper(name string) error {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	trafficClass, err := fetchTrafficClass(name)
+	if err != nil {
+		return err
+	}
+	if trafficClass == nil {
+		return errTrafficClassNotFound
+	}
+	users, err := trafficClassUsers(name)
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("%w: %s", errTrafficClassInUse, strings.Join(users, ", "))
+	}
+	if err = dbadapter.CommonDBClient.RestfulAPIDeleteOne(trafficClassDataColl, bson.M{"name": name}); err != nil {
+		return fmt.Errorf("failed to delete traffic class %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetTrafficClasses godoc
+//
+// @Description  Return the list of traffic classes
+// @Tags         Traffic Classes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   string  "List of traffic class names"
+// @Failure      401  {object}  nil     "Authorization failed"
+// @Failure      403  {object}  nil     "Forbidden"
+// @Failure      500  {object}  nil     "Error retrieving traffic classes"
+// @Router       /config/v1/traffic-class  [get]
+func GetTrafficClasses(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get all Traffic Classes")
+	rawTrafficClasses, err := dbadapter.CommonDBClient.RestfulAPIGetMany(trafficClassDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s failed to retrieve traffic classes: %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve traffic classes", "request_id": requestID})
+		return
+	}
+	names := make([]string, 0, len(rawTrafficClasses))
+	for _, rawTrafficClass := range rawTrafficClasses {
+		if name, ok := rawTrafficClass["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	c.JSON(http.StatusOK, names)
+}
+
+// GetTrafficClassByName godoc
+//
+// @Description  Return the traffic class
+// @Tags         Traffic Classes
+// @Param        trafficClassName    path    string    true    " "
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.TrafficClassInfo  "Traffic class"
+// @Failure      401  {object}  nil                            "Authorization failed"
+// @Failure      403  {object}  nil                            "Forbidden"
+// @Failure      404  {object}  nil                            "Traffic class not found"
+// @Failure      500  {object}  nil                            "Error retrieving traffic class"
+// @Router       /config/v1/traffic-class/{trafficClassName}  [get]
+func GetTrafficClassByName(c *gin.Context) {
+	setCorsHeader(c)
+	requestID := uuid.New().String()
+	logger.WebUILog.Infoln("Get Traffic Class by name")
+	trafficClass, err := fetchTrafficClass(c.Param("traffic-class-name"))
+	if err != nil {
+		logger.DbLog.Errorf("Request ID: %s %+v", requestID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve traffic class", "request_id": requestID})
+		return
+	}
+	if trafficClass == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errTrafficClassNotFound.Error(), "request_id": requestID})
+		return
+	}
+	c.JSON(http.StatusOK, trafficClass)
+}
+
+// TrafficClassPost godoc
+//
+// @Description  Create a new traffic class. The network slices and device groups holding a traffic class of the same name are updated to its definition.
+// @Tags         Traffic Classes
+// @Param        trafficClassName    path    string                           true    " "
+// @Param        content             body    configmodels.TrafficClassInfo    true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Traffic class created"
+// @Failure      400  {object}  nil  "Invalid traffic class content"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "Traffic class already exists"
+// @Failure      500  {object}  nil  "Error creating traffic class"
+// @Router       /config/v1/traffic-class/{trafficClassName}  [post]
+func TrafficClassPost(c *gin.Context) {
+	trafficClassWrite(c, false)
+}
+
+// TrafficClassPut godoc
+//
+// @Description  Create or update a traffic class. The network slices, device groups and device group templates using it, and the data of their subscribers, are updated.
+// @Tags         Traffic Classes
+// @Param        trafficClassName    path    string                           true    " "
+// @Param        content             body    configmodels.TrafficClassInfo    true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Traffic class stored"
+// @Failure      400  {object}  nil  "Invalid traffic class content"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      500  {object}  nil  "Error storing traffic class or updating its users"
+// @Router       /config/v1/traffic-class/{trafficClassName}  [put]
+func TrafficClassPut(c *gin.Context) {
+	trafficClassWrite(c, true)
+}
+
+func trafficClassWrite(c *gin.Context, overwrite bool) {
+	requestID := uuid.New().String()
+	name := c.Param("traffic-class-name")
+	logger.WebUILog.Debugf("Request ID: %s store traffic class %s", requestID, name)
+	var trafficClass configmodels.TrafficClassInfo
+	if err := c.ShouldBindJSON(&trafficClass); err != nil {
+		err = fmt.Errorf("JSON bind error: %w", err)
+		logger.ConfigLog.Errorln(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "request_id": requestID})
+		return
+	}
+	if statusCode, err := trafficClassPostHelper(trafficClass, name, overwrite); err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to store traffic class %s: %+v", requestID, name, err)
+		c.JSON(statusCode, gin.H{
+			"error":      fmt.Sprintf("Failed to store traffic class %s with error: %+v.", name, err),
+			"request_id": requestID,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// TrafficClassDelete godoc
+//
+// @Description  Delete a traffic class no network slice, device group or device group template uses
+// @Tags         Traffic Classes
+// @Param        trafficClassName    path    string    true    " "
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Traffic class deleted"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Traffic class not found"
+// @Failure      409  {object}  nil  "Traffic class is used"
+// @Failure      500  {object}  nil  "Error deleting traffic class"
+// @Router       /config/v1/traffic-class/{trafficClassName}  [delete]
+func TrafficClassDelete(c *gin.Context) {
+	requestID := uuid.New().String()
+	name := c.Param("traffic-class-name")
+	logger.WebUILog.Debugf("Request ID: %s delete traffic class %s", requestID, name)
+	if err := trafficClassDeleteHelper(name); err != nil {
+		logger.WebUILog.Errorf("Request ID: %s failed to delete traffic class %s: %+v", requestID, name, err)
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, errTrafficClassNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, errTrafficClassInUse):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error":      fmt.Sprintf("Failed to delete traffic class %s with error: %+v.", name, err),
+			"request_id": requestID,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type TrafficClassMockDBClient struct {
+	dbadapter.DBInterface
+	trafficClasses []configmodels.TrafficClassInfo
+	slices         []configmodels.Slice
+	deviceGroups   []configmodels.DeviceGroups
+	templates      []configmodels.DeviceGroupTemplate
+	storedSlices   []configmodels.Slice
+	storedGroups   []configmodels.DeviceGroups
+	deletedColls   []string
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	switch coll {
+	case trafficClassDataColl:
+		for _, trafficClass := range db.trafficClasses {
+			if trafficClass.Name == filter["name"] {
+				return configmodels.ToBsonM(trafficClass), nil
+			}
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			if slice.SliceName == filter["slice-name"] {
+				return configmodels.ToBsonM(slice), nil
+			}
+		}
+	case devGroupDataColl:
+		for _, devGroup := range db.deviceGroups {
+			if devGroup.DeviceGroupName == filter["group-name"] {
+				return configmodels.ToBsonM(devGroup), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	switch coll {
+	case trafficClassDataColl:
+		for _, trafficClass := range db.trafficClasses {
+			results = append(results, configmodels.ToBsonM(trafficClass))
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	case devGroupDataColl:
+		for _, devGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(devGroup))
+		}
+	case deviceGroupTemplateDataColl:
+		for _, template := range db.templates {
+			results = append(results, configmodels.ToBsonM(template))
+		}
+	}
+	return results, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIPost(coll string, filter bson.M, postData map[string]any) (bool, error) {
+	switch coll {
+	case sliceDataColl:
+		var slice configmodels.Slice
+		if err := json.Unmarshal(configmodels.MapToByte(postData), &slice); err != nil {
+			return false, err
+		}
+		db.storedSlices = append(db.storedSlices, slice)
+	case devGroupDataColl:
+		var devGroup configmodels.DeviceGroups
+		if err := json.Unmarshal(configmodels.MapToByte(postData), &devGroup); err != nil {
+			return false, err
+		}
+		db.storedGroups = append(db.storedGroups, devGroup)
+	}
+	return true, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIDeleteOne(coll string, filter bson.M) error {
+	db.deletedColls = append(db.deletedColls, coll)
+	return nil
+}
+
+func platinumTrafficClass() configmodels.TrafficClassInfo {
+	return configmodels.TrafficClassInfo{Name: "platinum", Qci: 8, Arp: 6, Pdb: 300, Pelr: 6}
+}
+
+func TestValidateTrafficClass(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(*configmodels.TrafficClassInfo)
+		expectedError string
+	}{
+		{name: "Valid traffic class", modify: func(*configmodels.TrafficClassInfo) {}},
+		{name: "Non standardized 5QI", modify: func(tc *configmodels.TrafficClassInfo) { tc.Qci = 11 }, expectedError: "invalid 5QI 11"},
+		{name: "Operator-specific 5QI", modify: func(tc *configmodels.TrafficClassInfo) { tc.Qci = 128 }},
+		{name: "Out of range 5QI", modify: func(tc *configmodels.TrafficClassInfo) { tc.Qci = 255 }, expectedError: "invalid 5QI 255"},
+		{name: "Invalid ARP", modify: func(tc *configmodels.TrafficClassInfo) { tc.Arp = 16 }, expectedError: "invalid ARP 16"},
+		{name: "Negative packet delay budget", modify: func(tc *configmodels.TrafficClassInfo) { tc.Pdb = -1 }, expectedError: "invalid packet delay budget"},
+		{name: "Negative packet error loss rate", modify: func(tc *configmodels.TrafficClassInfo) { tc.Pelr = -1 }, expectedError: "invalid packet error loss rate"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trafficClass := platinumTrafficClass()
+			tc.modify(&trafficClass)
+			err := validateTrafficClass(&trafficClass)
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResolveTrafficClass(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass()}}
+
+	testCases := []struct {
+		name          string
+		trafficClass  configmodels.TrafficClassInfo
+		expected      configmodels.TrafficClassInfo
+		expectedError bool
+	}{
+		{name: "Reference", trafficClass: configmodels.TrafficClassInfo{Name: "platinum"}, expected: platinumTrafficClass()},
+		{name: "Matching definition", trafficClass: platinumTrafficClass(), expected: platinumTrafficClass()},
+		{
+			name:          "Conflicting definition",
+			trafficClass:  configmodels.TrafficClassInfo{Name: "platinum", Qci: 9, Arp: 6, Pdb: 300, Pelr: 6},
+			expectedError: true,
+		},
+		{name: "Unknown reference", trafficClass: configmodels.TrafficClassInfo{Name: "gold"}, expectedError: true},
+		{
+			name:         "Inline traffic class",
+			trafficClass: configmodels.TrafficClassInfo{Name: "gold", Qci: 9, Arp: 1},
+			expected:     configmodels.TrafficClassInfo{Name: "gold", Qci: 9, Arp: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trafficClass := tc.trafficClass
+			err := resolveTrafficClass(&trafficClass)
+			if tc.expectedError {
+				if !errors.Is(err, errInvalidTrafficClassReference) {
+					t.Fatalf("expected an invalid reference error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if trafficClass != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, trafficClass)
+			}
+		})
+	}
+}
+
+func TestResolveSliceTrafficClasses(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass()}}
+
+	slice := networkSlice("slice1")
+	slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{
+		{RuleName: "rule1", TrafficClass: &configmodels.TrafficClassInfo{Name: "platinum"}},
+		{RuleName: "rule2", TrafficClass: &configmodels.TrafficClassInfo{Name: "gold"}},
+	}
+	slice.Qos = &configmodels.SliceQos{TrafficClass: &configmodels.TrafficClassInfo{Name: "platinum"}}
+	problems, err := resolveSliceTrafficClasses(&slice)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "application-filtering-rules[1].traffic-class" {
+		t.Errorf("expected a problem with the traffic class of the second rule, got %+v", problems)
+	}
+	if *slice.ApplicationFilteringRules[0].TrafficClass != platinumTrafficClass() || *slice.Qos.TrafficClass != platinumTrafficClass() {
+		t.Errorf("expected the references to be resolved, got %+v and %+v", *slice.ApplicationFilteringRules[0].TrafficClass, *slice.Qos.TrafficClass)
+	}
+}
+
+func TestTrafficClassPutHandler_UpdatesUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+
+	slice := networkSlice("slice1")
+	slice.SiteDeviceGroup = nil
+	slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{
+		{RuleName: "rule1", TrafficClass: &configmodels.TrafficClassInfo{Name: "platinum", Qci: 8, Arp: 6, Pdb: 300, Pelr: 6}},
+	}
+	otherSlice := networkSlice("slice2")
+	otherSlice.SiteDeviceGroup = nil
+	mock := &TrafficClassMockDBClient{
+		trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass()},
+		slices:         []configmodels.Slice{slice, otherSlice},
+		deviceGroups:   []configmodels.DeviceGroups{deviceGroup("group1")},
+	}
+	dbadapter.CommonDBClient = mock
+
+	updated := configmodels.TrafficClassInfo{Qci: 9, Arp: 2, Pdb: 300, Pelr: 6}
+	body, err := json.Marshal(updated)
+	if err != nil {
+		t.Fatalf("failed to marshal traffic class: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, "/config/v1/traffic-class/platinum", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	updated.Name = "platinum"
+	if len(mock.storedSlices) != 1 || mock.storedSlices[0].SliceName != "slice1" {
+		t.Fatalf("expected network slice slice1 to be stored, got %+v", mock.storedSlices)
+	}
+	if got := *mock.storedSlices[0].ApplicationFilteringRules[0].TrafficClass; got != updated {
+		t.Errorf("expected traffic class %+v in network slice, got %+v", updated, got)
+	}
+	if len(mock.storedGroups) != 1 || mock.storedGroups[0].DeviceGroupName != "group1" {
+		t.Fatalf("expected device group group1 to be stored, got %+v", mock.storedGroups)
+	}
+	if got := *mock.storedGroups[0].IpDomainsExpanded[0].UeDnnQos.TrafficClass; got != updated {
+		t.Errorf("expected traffic class %+v in device group, got %+v", updated, got)
+	}
+}
+
+func TestTrafficClassPostHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		url          string
+		trafficClass configmodels.TrafficClassInfo
+		expectedCode int
+	}{
+		{name: "New traffic class", url: "/config/v1/traffic-class/gold", trafficClass: configmodels.TrafficClassInfo{Qci: 9, Arp: 1}, expectedCode: http.StatusOK},
+		{name: "Existing traffic class", url: "/config/v1/traffic-class/platinum", trafficClass: platinumTrafficClass(), expectedCode: http.StatusConflict},
+		{name: "Non standardized 5QI", url: "/config/v1/traffic-class/gold", trafficClass: configmodels.TrafficClassInfo{Qci: 11, Arp: 1}, expectedCode: http.StatusBadRequest},
+		{name: "Operator-specific 5QI", url: "/config/v1/traffic-class/gold", trafficClass: configmodels.TrafficClassInfo{Qci: 200, Arp: 1}, expectedCode: http.StatusOK},
+		{name: "Invalid name", url: "/config/v1/traffic-class/gold%20class", trafficClass: configmodels.TrafficClassInfo{Qci: 9, Arp: 1}, expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass()}}
+
+			body, err := json.Marshal(tc.trafficClass)
+			if err != nil {
+				t.Fatalf("failed to marshal traffic class: %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTrafficClassDeleteHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		url          string
+		deviceGroups []configmodels.DeviceGroups
+		expectedCode int
+	}{
+		{name: "Unused traffic class", url: "/config/v1/traffic-class/platinum", expectedCode: http.StatusOK},
+		{name: "Traffic class used by a device group", url: "/config/v1/traffic-class/platinum", deviceGroups: []configmodels.DeviceGroups{deviceGroup("group1")}, expectedCode: http.StatusConflict},
+		{name: "Unknown traffic class", url: "/config/v1/traffic-class/gold", expectedCode: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &TrafficClassMockDBClient{
+				trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass()},
+				deviceGroups:   tc.deviceGroups,
+			}
+			dbadapter.CommonDBClient = mock
+
+			req, err := http.NewRequest(http.MethodDelete, tc.url, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected `%v`, got `%v`: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			deleted := len(mock.deletedColls) == 1 && mock.deletedColls[0] == trafficClassDataColl
+			if deleted != (tc.expectedCode == http.StatusOK) {
+				t.Errorf("unexpected deletions %v", mock.deletedColls)
+			}
+		})
+	}
+}
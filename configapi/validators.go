@@ -5,12 +5,42 @@ package configapi
 
 import (
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/models"
+)
+
+const (
+	NAME_PATTERN    = "^[a-zA-Z][a-zA-Z0-9-_]{1,255}$"
+	FQDN_PATTERN    = "^([a-zA-Z0-9][a-zA-Z0-9-]+\\.){2,}([a-zA-Z]{2,6})$"
+	HEX_PATTERN     = "^[A-Fa-f0-9]+$"
+	PLMN_ID_PATTERN = "^[0-9]{5,6}$"
+	MCC_PATTERN     = "^[0-9]{3}$"
+	MNC_PATTERN     = "^[0-9]{2,3}$"
 )
 
 const (
-	NAME_PATTERN = "^[a-zA-Z][a-zA-Z0-9-_]{1,255}$"
-	FQDN_PATTERN = "^([a-zA-Z0-9][a-zA-Z0-9-]+\\.){2,}([a-zA-Z]{2,6})$"
+	AUTH_KEY_HEX_LENGTH = 32
+	SQN_HEX_LENGTH      = 12
+	AMF_HEX_LENGTH      = 4
+	RAND_HEX_LENGTH     = 32
+	AUTS_HEX_LENGTH     = 28
+	SD_HEX_LENGTH       = 6
+)
+
+const (
+	MAX_SST          = 255
+	MAX_PORT         = 65535
+	MAX_PROTOCOL     = 255
+	MAX_UPF_PRIORITY = 65535
+	MAX_UPF_WEIGHT   = 65535
+	MIN_ARP          = 1
+	MAX_ARP          = 15
+	// operator-specific 5QIs, 3GPP TS 23.501 clause 5.7.2.1
+	MIN_OPERATOR_5QI = 128
+	MAX_OPERATOR_5QI = 254
 )
 
 func isValidName(name string) bool {
@@ -40,3 +70,103 @@ func isValidUpfPort(port string) bool {
 func isValidGnbTac(tac int32) bool {
 	return tac >= 1 && tac <= 16777215
 }
+
+func isValidPlmnId(plmnId string) bool {
+	plmnIdMatch, err := regexp.MatchString(PLMN_ID_PATTERN, plmnId)
+	if err != nil {
+		return false
+	}
+	return plmnIdMatch
+}
+
+func isValidMcc(mcc string) bool {
+	mccMatch, err := regexp.MatchString(MCC_PATTERN, mcc)
+	if err != nil {
+		return false
+	}
+	return mccMatch
+}
+
+func isValidMnc(mnc string) bool {
+	mncMatch, err := regexp.MatchString(MNC_PATTERN, mnc)
+	if err != nil {
+		return false
+	}
+	return mncMatch
+}
+
+func isValidSst(sst string) bool {
+	sstNum, err := strconv.Atoi(sst)
+	if err != nil {
+		return false
+	}
+	return sstNum >= 0 && sstNum <= MAX_SST
+}
+
+func isValidSd(sd string) bool {
+	return isValidHexString(sd, SD_HEX_LENGTH)
+}
+
+func isValidPort(port int32) bool {
+	return port >= 0 && port <= MAX_PORT
+}
+
+func isValidProtocol(protocol int32) bool {
+	return protocol >= 0 && protocol <= MAX_PROTOCOL
+}
+
+// standardized5qis are the 5QIs of the standardized 5QI to QoS characteristics mapping of
+// 3GPP TS 23.501 table 5.7.4-1: GBR, non-GBR and delay-critical GBR
+var standardized5qis = []int32{
+	1, 2, 3, 4, 65, 66, 67, 71, 72, 73, 74, 76,
+	5, 6, 7, 8, 9, 10, 69, 70, 79, 80,
+	82, 83, 84, 85, 86, 87, 88, 89, 90,
+}
+
+// isValid5qi accepts the standardized 5QIs and the operator-specific 5QIs. The other
+// values up to 127 are reserved.
+func isValid5qi(fiveQi int32) bool {
+	if fiveQi >= MIN_OPERATOR_5QI && fiveQi <= MAX_OPERATOR_5QI {
+		return true
+	}
+	return slices.Contains(standardized5qis, fiveQi)
+}
+
+func isValidArp(arp int32) bool {
+	return arp >= MIN_ARP && arp <= MAX_ARP
+}
+
+func isValidBitrateUnit(unit string) bool {
+	switch strings.ToLower(unit) {
+	case "bps", "kbps", "mbps", "gbps":
+		return true
+	}
+	return false
+}
+
+func isValidHexString(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	hexMatch, err := regexp.MatchString(HEX_PATTERN, value)
+	if err != nil {
+		return false
+	}
+	return hexMatch
+}
+
+func isValidAuthKey(key string) bool {
+	return isValidHexString(key, AUTH_KEY_HEX_LENGTH)
+}
+
+func isValidSqn(sqn string) bool {
+	return isValidHexString(sqn, SQN_HEX_LENGTH)
+}
+
+func isValidAmf(amf string) bool {
+	return isValidHexString(amf, AMF_HEX_LENGTH)
+}
+
+func isValidAuthenticationMethod(method string) bool {
+	return method == string(models.AUTHMETHOD__5_G_AKA) || method == string(models.AUTHMETHOD_EAP_AKA_PRIME)
+}
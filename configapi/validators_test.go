@@ -106,6 +106,64 @@ func TestValidateGnbTac(t *testing.T) {
 	}
 }
 
+func TestValidateSstAndSd(t *testing.T) {
+	if !isValidSst("1") || !isValidSst("0") || !isValidSst("255") || isValidSst("256") || isValidSst("-1") || isValidSst("") {
+		t.Errorf("unexpected SST validation result")
+	}
+	if !isValidSd("010203") || !isValidSd("ABCDEF") || isValidSd("01020") || isValidSd("01020g") {
+		t.Errorf("unexpected SD validation result")
+	}
+}
+
+func TestValidateMccAndMnc(t *testing.T) {
+	if !isValidMcc("208") || isValidMcc("20") || isValidMcc("2080") || isValidMcc("20a") {
+		t.Errorf("unexpected MCC validation result")
+	}
+	if !isValidMnc("93") || !isValidMnc("093") || isValidMnc("9") || isValidMnc("9a") {
+		t.Errorf("unexpected MNC validation result")
+	}
+}
+
+func TestValidate5qiAndArp(t *testing.T) {
+	if !isValid5qi(9) || !isValid5qi(1) || !isValid5qi(90) || !isValid5qi(128) || !isValid5qi(254) ||
+		isValid5qi(0) || isValid5qi(11) || isValid5qi(127) || isValid5qi(255) {
+		t.Errorf("unexpected 5QI validation result")
+	}
+	if !isValidArp(1) || !isValidArp(15) || isValidArp(0) || isValidArp(16) {
+		t.Errorf("unexpected ARP validation result")
+	}
+}
+
+func TestValidateAuthKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected bool
+	}{
+		{"8baf473f2f8fd09487cccbd7097c6862", true},
+		{"8BAF473F2F8FD09487CCCBD7097C6862", true},
+		{"8baf473f2f8fd09487cccbd7097c686", false},
+		{"8baf473f2f8fd09487cccbd7097c68620", false},
+		{"8baf473f2f8fd09487cccbd7097c686g", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidAuthKey(tc.key)
+		if r != tc.expected {
+			t.Errorf("%s", tc.key)
+		}
+	}
+}
+
+func TestValidateSqnAndAmf(t *testing.T) {
+	if !isValidSqn("16f3b3f70fc2") || isValidSqn("16f3b3f70fc") || isValidSqn("16f3b3f70fcz") {
+		t.Errorf("unexpected SQN validation result")
+	}
+	if !isValidAmf("8000") || isValidAmf("800") || isValidAmf("80000") {
+		t.Errorf("unexpected AMF validation result")
+	}
+}
+
 func genLongString(length int) string {
 	return strings.Repeat("a", length)
 }
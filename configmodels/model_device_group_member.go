@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+// DeviceGroupMember is an IMSI added individually to a device group, with its optional MSISDN
+type DeviceGroupMember struct {
+	Imsi   string `json:"imsi"`
+	Msisdn string `json:"msisdn,omitempty"`
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+// DeviceGroupTemplate holds the IP domain settings (DNN, QoS, traffic class, DNS, P-CSCF
+// and MTU) shared by the device groups derived from it. The UE IP pools are set on each
+// derived device group.
+type DeviceGroupTemplate struct {
+	TemplateName string `json:"template-name"`
+
+	IpDomainsExpanded []DeviceGroupsIpDomainExpanded `json:"ip-domains,omitempty"`
+}
+
+// DeviceGroupTemplateRef references the template a device group was derived from. The
+// overrides are matched to the IP domains of the template by DNN, and their non-empty
+// fields replace the fields of the template.
+type DeviceGroupTemplateRef struct {
+	Name string `json:"name"`
+
+	IpDomainOverrides []DeviceGroupsIpDomainExpanded `json:"ip-domain-overrides,omitempty"`
+}
+
+// DeviceGroupInstantiation is the content of a device group derived from a template
+type DeviceGroupInstantiation struct {
+	Imsis []string `json:"imsis"`
+
+	ImsiRanges []ImsiRange `json:"imsi-ranges,omitempty"`
+
+	ImsiMsisdns map[string]string `json:"imsi-msisdns,omitempty"`
+
+	SiteInfo string `json:"site-info,omitempty"`
+
+	IpDomainOverrides []DeviceGroupsIpDomainExpanded `json:"ip-domain-overrides,omitempty"`
+}
+
+// DeviceGroupTemplatePreview compares the IP domains of a derived device group with the
+// IP domains the current template would give it
+type DeviceGroupTemplatePreview struct {
+	DeviceGroup string `json:"device-group"`
+
+	Changed bool `json:"changed"`
+
+	Current []DeviceGroupsIpDomainExpanded `json:"current"`
+
+	Updated []DeviceGroupsIpDomainExpanded `json:"updated,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
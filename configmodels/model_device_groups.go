@@ -14,16 +14,76 @@
 
 package configmodels
 
+import (
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/identity"
+)
+
 type DeviceGroups struct {
 	DeviceGroupName string `json:"group-name"`
 
 	Imsis []string `json:"imsis"`
 
+	// ImsiRanges are contiguous ranges of IMSIs belonging to the group in addition to Imsis
+	ImsiRanges []ImsiRange `json:"imsi-ranges,omitempty"`
+
+	// Deprecated: Msisdns were matched to Imsis by position. They are converted to
+	// ImsiMsisdns when the device group is stored.
 	Msisdns []string `json:"msisdns,omitempty"`
 
+	// ImsiMsisdns maps IMSIs of the group to their MSISDN, as a GPSI of the form msisdn-<digits>
+	ImsiMsisdns map[string]string `json:"imsi-msisdns,omitempty"`
+
 	SiteInfo string `json:"site-info,omitempty"`
 
 	IpDomainName string `json:"ip-domain-name,omitempty"`
 
 	IpDomainsExpanded []DeviceGroupsIpDomainExpanded `json:"ip-domains,omitempty"`
+
+	// Template is the template the IP domains of the group are derived from, if any
+	Template *DeviceGroupTemplateRef `json:"template,omitempty"`
+}
+
+// ImsiRange is a contiguous range of IMSIs given by its first IMSI and either its last
+// IMSI or its number of IMSIs
+type ImsiRange struct {
+	Start string `json:"start"`
+	End   string `json:"end,omitempty"`
+	Count uint64 `json:"count,omitempty"`
+}
+
+// Parse validates the range
+func (imsiRange ImsiRange) Parse() (identity.ImsiRange, error) {
+	return identity.NewImsiRange(imsiRange.Start, imsiRange.End, imsiRange.Count)
+}
+
+// ContainsImsi reports whether imsi belongs to the device group, without expanding its IMSI ranges
+func (deviceGroup *DeviceGroups) ContainsImsi(imsi string) bool {
+	if slices.Contains(deviceGroup.Imsis, imsi) {
+		return true
+	}
+	for _, imsiRange := range deviceGroup.ImsiRanges {
+		if parsedRange, err := imsiRange.Parse(); err == nil && parsedRange.Contains(imsi) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertLegacyMsisdns moves the MSISDNs matched to Imsis by position to ImsiMsisdns.
+// MSISDNs already mapped to their IMSI are kept.
+func (deviceGroup *DeviceGroups) ConvertLegacyMsisdns() {
+	for i, msisdn := range deviceGroup.Msisdns {
+		if msisdn == "" || i >= len(deviceGroup.Imsis) {
+			continue
+		}
+		if deviceGroup.ImsiMsisdns == nil {
+			deviceGroup.ImsiMsisdns = make(map[string]string)
+		}
+		if _, exists := deviceGroup.ImsiMsisdns[deviceGroup.Imsis[i]]; !exists {
+			deviceGroup.ImsiMsisdns[deviceGroup.Imsis[i]] = msisdn
+		}
+	}
+	deviceGroup.Msisdns = nil
 }
@@ -14,19 +14,42 @@
 
 package configmodels
 
+import "github.com/omec-project/openapi/v2/models"
+
 // DeviceGroupsIpDomainExpanded - This is APN for device
 type DeviceGroupsIpDomainExpanded struct {
 	Dnn string `json:"dnn,omitempty"`
 
 	UeIpPool string `json:"ue-ip-pool,omitempty"`
 
+	// UeIpv6Pool is the IPv6 prefix from which a /64 prefix is delegated to each UE
+	UeIpv6Pool string `json:"ue-ipv6-pool,omitempty"`
+
 	DnsPrimary string `json:"dns-primary,omitempty"`
 
+	DnsPrimaryIpv6 string `json:"dns-primary-ipv6,omitempty"`
+
 	PcscfPrimary string `json:"pcscf-primary,omitempty"`
 
+	PcscfPrimaryIpv6 string `json:"pcscf-primary-ipv6,omitempty"`
+
 	DnsSecondary string `json:"dns-secondary,omitempty"`
 
+	DnsSecondaryIpv6 string `json:"dns-secondary-ipv6,omitempty"`
+
+	// PduSessionTypes are the PDU session types allowed on the DNN, the first one being
+	// the default. Only IPv4 is allowed when it is empty.
+	PduSessionTypes []models.PduSessionType `json:"pdu-session-types,omitempty"`
+
 	Mtu int32 `json:"mtu,omitempty"`
 
 	UeDnnQos *DeviceGroupsIpDomainExpandedUeDnnQos `json:"ue-dnn-qos,omitempty"`
 }
+
+// AllowedPduSessionTypes returns the PDU session types allowed on the DNN
+func (ipDomain *DeviceGroupsIpDomainExpanded) AllowedPduSessionTypes() []models.PduSessionType {
+	if len(ipDomain.PduSessionTypes) == 0 {
+		return []models.PduSessionType{models.PDUSESSIONTYPE_IPV4}
+	}
+	return ipDomain.PduSessionTypes
+}
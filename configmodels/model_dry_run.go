@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+const (
+	// DryRunCreate reports a subscriber document that would be created
+	DryRunCreate = "create"
+	// DryRunUpdate reports a stored subscriber document that would be replaced
+	DryRunUpdate = "update"
+	// DryRunDelete reports a stored subscriber document that would be deleted
+	DryRunDelete = "delete"
+)
+
+// DryRunReport is the effect a change of the network slices, device groups or inventory
+// would have, computed without applying it
+type DryRunReport struct {
+	SubscriberChanges []DryRunSubscriberChange `json:"subscriber-changes"`
+
+	// NfConfigChanges lists the nfconfig endpoints whose configuration would change
+	NfConfigChanges []DryRunNfConfigChange `json:"nfconfig-changes"`
+
+	ImsiConflicts []ImsiConflict `json:"imsi-conflicts,omitempty"`
+}
+
+// DryRunSubscriberChange is a policy or provisioned data document of a subscriber that
+// would be created, updated or deleted
+type DryRunSubscriberChange struct {
+	Collection string `json:"collection"`
+
+	UeId string `json:"ue-id"`
+
+	Operation string `json:"operation"`
+
+	Current map[string]interface{} `json:"current,omitempty"`
+
+	Updated map[string]interface{} `json:"updated,omitempty"`
+}
+
+// DryRunNfConfigChange is the configuration an nfconfig endpoint serves and would serve
+type DryRunNfConfigChange struct {
+	Endpoint string `json:"endpoint"`
+
+	Current interface{} `json:"current"`
+
+	Updated interface{} `json:"updated"`
+}
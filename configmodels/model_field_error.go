@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+// FieldError is a problem with the value of a field of a request. Field is the JSON path of
+// the field, e.g. site-info.gNodeBs[0].tac.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+// ImsiConflict reports the IMSIs from Start to End that belong to every device group of
+// DeviceGroups. Start and End are equal when a single IMSI is shared.
+type ImsiConflict struct {
+	Start        string   `json:"start"`
+	End          string   `json:"end"`
+	DeviceGroups []string `json:"device-groups"`
+}
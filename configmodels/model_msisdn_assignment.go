@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+// MsisdnAssignment is the IMSI, and its device group, an MSISDN is assigned to
+type MsisdnAssignment struct {
+	Msisdn      string `json:"msisdn"`
+	UeId        string `json:"ueId"`
+	DeviceGroup string `json:"device-group"`
+}
@@ -24,4 +24,7 @@ type Slice struct {
 	SiteInfo SliceSiteInfo `json:"site-info,omitempty"`
 
 	ApplicationFilteringRules []SliceApplicationFilteringRules `json:"application-filtering-rules,omitempty"`
+
+	// Session AMBR and 5QI/ARP of the DNNs without a QoS in the device groups of the slice
+	Qos *SliceQos `json:"qos,omitempty"`
 }
@@ -14,16 +14,28 @@
 
 package configmodels
 
+// SliceQos is the default QoS of the sessions of a network slice, applied to the DNNs of its
+// device groups which have no QoS of their own
 type SliceQos struct {
-	// uplink data rate in bps
-	Uplink int32 `json:"uplink,omitempty"`
+	// session AMBR uplink data rate
+	Uplink int64 `json:"uplink,omitempty"`
 
-	// downlink data rate in bps
-	Downlink int32 `json:"downlink,omitempty"`
+	// session AMBR downlink data rate
+	Downlink int64 `json:"downlink,omitempty"`
 
 	// data rate unit for uplink and downlink
 	BitrateUnit string `json:"bitrate-unit,omitempty"`
 
-	// QCI/QFI for the traffic
-	TrafficClass string `json:"traffic-class,omitempty"`
+	// default 5QI and ARP of the sessions
+	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
+}
+
+// UeDnnQos returns qos as the QoS of a DNN of a device group
+func (qos SliceQos) UeDnnQos() DeviceGroupsIpDomainExpandedUeDnnQos {
+	return DeviceGroupsIpDomainExpandedUeDnnQos{
+		DnnMbrUplink:   qos.Uplink,
+		DnnMbrDownlink: qos.Downlink,
+		BitrateUnit:    qos.BitrateUnit,
+		TrafficClass:   qos.TrafficClass,
+	}
 }
@@ -21,8 +21,19 @@ type SliceSiteInfo struct {
 
 	Plmn SliceSiteInfoPlmn `json:"plmn,omitempty"`
 
+	// Other PLMNs broadcast by the site when its RAN is shared. The slice is served in
+	// Plmn and in each of them.
+	Plmns []SliceSiteInfoPlmn `json:"plmns,omitempty"`
+
+	// PLMNs the UEs of the slice treat as equivalent to the PLMN they registered in
+	EquivalentPlmns []SliceSiteInfoPlmn `json:"equivalent-plmns,omitempty"`
+
 	GNodeBs []SliceSiteInfoGNodeBs `json:"gNodeBs"`
 
 	// UPF which belong to this slice
-	Upf map[string]interface{} `json:"upf,omitempty"`
+	Upf *SliceSiteInfoUpfReference `json:"upf,omitempty"`
+
+	// UPFs which belong to this slice, with their selection priority and weight. Replaces
+	// Upf when set.
+	Upfs []SliceSiteInfoUpf `json:"upfs,omitempty"`
 }
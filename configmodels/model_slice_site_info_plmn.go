@@ -14,9 +14,23 @@
 
 package configmodels
 
+import "slices"
+
 // SliceSiteInfoPlmn - Fixed supported plmn at the site.
 type SliceSiteInfoPlmn struct {
 	Mcc string `json:"mcc,omitempty"`
 
 	Mnc string `json:"mnc,omitempty"`
 }
+
+// PlmnList returns the PLMNs the slice is served in: Plmn followed by the distinct
+// shared RAN PLMNs of Plmns
+func (siteInfo SliceSiteInfo) PlmnList() []SliceSiteInfoPlmn {
+	plmns := []SliceSiteInfoPlmn{siteInfo.Plmn}
+	for _, plmn := range siteInfo.Plmns {
+		if !slices.Contains(plmns, plmn) {
+			plmns = append(plmns, plmn)
+		}
+	}
+	return plmns
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// SliceSiteInfoUpfReference references the UPF of the inventory serving a network slice
+type SliceSiteInfoUpfReference struct {
+	// Hostname of a UPF of the inventory
+	UpfName string `json:"upf-name"`
+
+	UpfPort UpfPort `json:"upf-port,omitempty"`
+}
+
+// UpfPort is the port of a UPF. It is also read from a JSON number, as network slices used
+// to be stored with either.
+type UpfPort string
+
+func (p *UpfPort) UnmarshalJSON(data []byte) error {
+	var port any
+	if err := json.Unmarshal(data, &port); err != nil {
+		return err
+	}
+	switch v := port.(type) {
+	case nil:
+		*p = ""
+	case string:
+		*p = UpfPort(v)
+	case float64:
+		*p = UpfPort(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("invalid UPF port %s: expected a string or a number", data)
+	}
+	return nil
+}
+
+// SliceSiteInfoUpf is a UPF of the inventory serving a network slice. The SMF selects among
+// the UPFs with the lowest priority value, sharing the sessions according to their weights.
+type SliceSiteInfoUpf struct {
+	// Hostname of a UPF of the inventory
+	UpfName string `json:"upf-name"`
+
+	UpfPort string `json:"upf-port,omitempty"`
+
+	Priority int32 `json:"priority,omitempty"`
+
+	Weight int32 `json:"weight,omitempty"`
+
+	// DNNs the UPF serves, every DNN of the network slice when empty
+	Dnns []string `json:"dnns,omitempty"`
+}
+
+// UpfList returns the UPFs serving the network slice: the upfs list, or the single UPF of
+// the upf field when the list is empty
+func (siteInfo SliceSiteInfo) UpfList() []SliceSiteInfoUpf {
+	if len(siteInfo.Upfs) > 0 {
+		return siteInfo.Upfs
+	}
+	if siteInfo.Upf == nil || siteInfo.Upf.UpfName == "" {
+		return nil
+	}
+	return []SliceSiteInfoUpf{{UpfName: siteInfo.Upf.UpfName, UpfPort: string(siteInfo.Upf.UpfPort)}}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+type SubsBulkImportRow struct {
+	UeId           string `json:"ueId"`
+	OPc            string `json:"opc"`
+	Key            string `json:"key"`
+	SequenceNumber string `json:"sqn"`
+	PlmnID         string `json:"plmnID"`
+}
+
+type SubsBulkImportRowResult struct {
+	Row    int    `json:"row"`
+	UeId   string `json:"ueId"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type SubsBulkImportReport struct {
+	DryRun  bool                      `json:"dryRun"`
+	Total   int                       `json:"total"`
+	Created int                       `json:"created"`
+	Valid   int                       `json:"valid"`
+	Failed  int                       `json:"failed"`
+	Results []SubsBulkImportRowResult `json:"results"`
+}
+
+const (
+	SubsBulkImportStatusCreated = "created"
+	SubsBulkImportStatusValid   = "valid"
+	SubsBulkImportStatusInvalid = "invalid"
+	SubsBulkImportStatusFailed  = "failed"
+)
@@ -22,8 +22,14 @@ type SubsData struct {
 }
 
 type SubsOverrideData struct {
-	PlmnID         string `json:"plmnID"`
-	OPc            string `json:"opc"`
+	PlmnID string `json:"plmnID"`
+	OPc    string `json:"opc"`
+	// OP is the operator variant algorithm configuration field. It is only used to derive the OPc when the OPc is not provided.
+	OP             string `json:"op,omitempty"`
 	Key            string `json:"key"`
 	SequenceNumber string `json:"sequenceNumber"`
+	// AuthenticationMethod is either 5G_AKA (default) or EAP_AKA_PRIME.
+	AuthenticationMethod string `json:"authenticationMethod,omitempty"`
+	// AuthenticationManagementField is the AMF as 4 hexadecimal characters, 8000 by default.
+	AuthenticationManagementField string `json:"authenticationManagementField,omitempty"`
 }
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+import (
+	"github.com/omec-project/openapi/v2/models"
+)
+
+type SubsExportData struct {
+	PlmnID                            string                                     `json:"plmnID"`
+	UeId                              string                                     `json:"ueId"`
+	DeviceGroup                       string                                     `json:"deviceGroup,omitempty"`
+	NetworkSlice                      string                                     `json:"networkSlice,omitempty"`
+	AuthenticationSubscription        *models.AuthenticationSubscription         `json:"AuthenticationSubscription,omitempty"`
+	AccessAndMobilitySubscriptionData models.AccessAndMobilitySubscriptionData   `json:"AccessAndMobilitySubscriptionData"`
+	SessionManagementSubscriptionData []models.SessionManagementSubscriptionData `json:"SessionManagementSubscriptionData"`
+	SmfSelectionSubscriptionData      models.SmfSelectionSubscriptionData        `json:"SmfSelectionSubscriptionData"`
+	AmPolicyData                      models.AmPolicyData                        `json:"AmPolicyData"`
+	SmPolicyData                      models.SmPolicyData                        `json:"SmPolicyData"`
+}
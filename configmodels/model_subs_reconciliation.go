@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+import "time"
+
+const (
+	// SubsReconciliationNoAuthentication reports provisioned or policy data of a UE ID
+	// without authentication subscription
+	SubsReconciliationNoAuthentication = "noAuthenticationSubscription"
+	// SubsReconciliationNoDeviceGroup reports device group related data of an IMSI
+	// that does not belong to any device group
+	SubsReconciliationNoDeviceGroup = "noDeviceGroup"
+	// SubsReconciliationDeviceGroupWithoutAuthentication reports a device group IMSI
+	// without authentication subscription. It is never repaired.
+	SubsReconciliationDeviceGroupWithoutAuthentication = "deviceGroupImsiWithoutAuthentication"
+)
+
+// SubsReconciliationFinding is an orphaned record or an inconsistency found by a reconciliation
+type SubsReconciliationFinding struct {
+	UeId        string   `json:"ueId"`
+	Issue       string   `json:"issue"`
+	Collections []string `json:"collections,omitempty"`
+	DeviceGroup string   `json:"deviceGroup,omitempty"`
+	Repaired    bool     `json:"repaired"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// SubsReconciliationReport is the result of a reconciliation of the subscription data
+type SubsReconciliationReport struct {
+	StartedAt  time.Time                   `json:"startedAt"`
+	FinishedAt time.Time                   `json:"finishedAt"`
+	Repair     bool                        `json:"repair"`
+	Findings   []SubsReconciliationFinding `json:"findings"`
+	Repaired   int                         `json:"repaired"`
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2025 Canonical Ltd.
+
+package configmodels
+
+import "time"
+
+// SubsSqnResync requests the resynchronisation of the sequence number of a subscriber.
+// Either the new sequence number or the RAND and AUTS of a synchronisation failure
+// reported by the UE must be provided.
+type SubsSqnResync struct {
+	SequenceNumber string `json:"sequenceNumber,omitempty"`
+	Rand           string `json:"rand,omitempty"`
+	Auts           string `json:"auts,omitempty"`
+}
+
+// SubsSqnResyncRecord records a sequence number resynchronisation
+type SubsSqnResyncRecord struct {
+	UeId                   string    `json:"ueId"`
+	PreviousSequenceNumber string    `json:"previousSequenceNumber"`
+	SequenceNumber         string    `json:"sequenceNumber"`
+	Method                 string    `json:"method"`
+	Username               string    `json:"username"`
+	Timestamp              time.Time `json:"timestamp"`
+}
+
+const (
+	SubsSqnResyncMethodSqn  = "sqn"
+	SubsSqnResyncMethodAuts = "auts"
+)
@@ -61,6 +61,8 @@ func (s *MongoDBSession) WithSession(ctx context.Context, fn func(context.Contex
 type DBInterface interface {
 	RestfulAPIGetOne(collName string, filter bson.M) (map[string]interface{}, error)
 	RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]interface{}, error)
+	RestfulAPIGetManyPaged(collName string, filter bson.M, sort bson.D, limit int64) ([]map[string]interface{}, error)
+	RestfulAPIDistinct(collName string, fieldName string, filter bson.M) ([]interface{}, error)
 	RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool
 	RestfulAPIPutOne(collName string, filter bson.M, putData map[string]interface{}) (bool, error)
 	RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]interface{}) (bool, error)
@@ -69,6 +71,7 @@ type DBInterface interface {
 	RestfulAPIDeleteOne(collName string, filter bson.M) error
 	RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error
 	RestfulAPIDeleteMany(collName string, filter bson.M) error
+	RestfulAPIDeleteManyWithContext(context context.Context, collName string, filter bson.M) error
 	RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]interface{}) error
 	RestfulAPIJSONPatch(collName string, filter bson.M, patchJSON []byte) error
 	RestfulAPIJSONPatchWithContext(context context.Context, collName string, filter bson.M, patchJSON []byte) error
@@ -338,6 +341,52 @@ func (db *MongoDBClient) RestfulAPIGetMany(collName string, filter bson.M) ([]ma
 	return db.MongoClient.RestfulAPIGetMany(collName, filter)
 }
 
+// RestfulAPIGetManyPaged returns at most limit documents (all of them when limit is 0)
+// matching the filter, ordered by sort. Combined with a filter on the sort keys it can
+// be used for cursor based pagination.
+func (db *MongoDBClient) RestfulAPIGetManyPaged(collName string, filter bson.M, sort bson.D, limit int64) ([]map[string]interface{}, error) {
+	collection := db.MongoClient.GetCollection(collName)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	findOptions := options.Find().SetSort(sort)
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetManyPaged err: %w", err)
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			logger.DbLog.Warnf("failed to close cursor: %+v", err)
+		}
+	}()
+	resultArray := make([]map[string]interface{}, 0)
+	for cur.Next(ctx) {
+		var result map[string]interface{}
+		if err := cur.Decode(&result); err != nil {
+			return nil, fmt.Errorf("RestfulAPIGetManyPaged err: %w", err)
+		}
+		delete(result, "_id")
+		resultArray = append(resultArray, result)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetManyPaged err: %w", err)
+	}
+	return resultArray, nil
+}
+
+func (db *MongoDBClient) RestfulAPIDistinct(collName string, fieldName string, filter bson.M) ([]interface{}, error) {
+	collection := db.MongoClient.GetCollection(collName)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var values []interface{}
+	if err := collection.Distinct(ctx, fieldName, filter).Decode(&values); err != nil {
+		return nil, fmt.Errorf("RestfulAPIDistinct err: %w", err)
+	}
+	return values, nil
+}
+
 func (db *MongoDBClient) RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool {
 	return db.MongoClient.RestfulAPIPutOneTimeout(collName, filter, putData, timeout, timeField)
 }
@@ -370,6 +419,14 @@ func (db *MongoDBClient) RestfulAPIDeleteMany(collName string, filter bson.M) er
 	return db.MongoClient.RestfulAPIDeleteMany(collName, filter)
 }
 
+func (db *MongoDBClient) RestfulAPIDeleteManyWithContext(context context.Context, collName string, filter bson.M) error {
+	collection := db.MongoClient.GetCollection(collName)
+	if _, err := collection.DeleteMany(context, filter); err != nil {
+		return fmt.Errorf("RestfulAPIDeleteManyWithContext err: %w", err)
+	}
+	return nil
+}
+
 func (db *MongoDBClient) RestfulAPIMergePatch(collName string, filter bson.M, patchData map[string]interface{}) error {
 	return db.MongoClient.RestfulAPIMergePatch(collName, filter, patchData)
 }
@@ -12,18 +12,24 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/omec-project/webconsole/backend/encryption"
 	"github.com/omec-project/webconsole/backend/factory"
 	"github.com/omec-project/webconsole/backend/logger"
 	"github.com/omec-project/webconsole/backend/nfconfig"
 	"github.com/omec-project/webconsole/backend/webui_service"
+	"github.com/omec-project/webconsole/configapi"
 	"github.com/omec-project/webconsole/dbadapter"
 	"github.com/urfave/cli/v3"
 )
 
 var (
-	initMongoDB       = dbadapter.InitMongoDB
-	newNFConfigServer = nfconfig.NewNFConfigServer
-	runServer         = runWebUIAndNFConfig
+	initMongoDB            = dbadapter.InitMongoDB
+	initSubscriberKeyRing  = encryption.InitSubscriberKeyRing
+	newNFConfigServer      = nfconfig.NewNFConfigServer
+	runServer              = runWebUIAndNFConfig
+	reencryptSubscriberKey = configapi.ReencryptSubscriberKeys
+	migrateDeviceGroups    = configapi.MigrateDeviceGroupMsisdns
+	migrateSlices          = configapi.MigrateSliceUpfs
 )
 
 func main() {
@@ -34,6 +40,15 @@ func main() {
 	app.UsageText = "webconsole -cfg <webui_config_file.yaml>"
 	app.Flags = factory.GetCliFlags()
 	app.Action = action
+	app.Commands = []*cli.Command{
+		{
+			Name:      "reencrypt-subscriber-keys",
+			Usage:     "Encrypt every subscriber K and OPc with the active subscriber key encryption key. The UDR and UDM cannot decrypt them",
+			UsageText: "webconsole reencrypt-subscriber-keys -cfg <webui_config_file.yaml>",
+			Flags:     factory.GetCliFlags(),
+			Action:    reencryptSubscriberKeysAction,
+		},
+	}
 
 	if err := app.Run(context.Background(), os.Args); err != nil {
 		logger.AppLog.Fatalf("error args: %v", err)
@@ -41,27 +56,64 @@ func main() {
 }
 
 func action(ctx context.Context, c *cli.Command) error {
+	config, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	return startApplication(config)
+}
+
+func loadConfig(c *cli.Command) (*factory.Config, error) {
 	cfgPath := c.String("cfg")
 	if cfgPath == "" {
-		return fmt.Errorf("required flag cfg not set")
+		return nil, fmt.Errorf("required flag cfg not set")
 	}
 
 	absPath, err := filepath.Abs(cfgPath)
 	if err != nil {
-		return fmt.Errorf("failed to resolve config path: %w", err)
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
 	}
 
 	if err := factory.InitConfigFactory(absPath); err != nil {
-		return fmt.Errorf("failed to init config: %w", err)
+		return nil, fmt.Errorf("failed to init config: %w", err)
 	}
 
 	config := factory.WebUIConfig
 	if config == nil {
-		return fmt.Errorf("configuration not properly initialized")
+		return nil, fmt.Errorf("configuration not properly initialized")
 	}
 	factory.SetLogLevelsFromConfig(config)
+	return config, nil
+}
 
-	return startApplication(config)
+func reencryptSubscriberKeysAction(ctx context.Context, c *cli.Command) error {
+	config, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	return reencryptSubscriberKeys(config)
+}
+
+func reencryptSubscriberKeys(config *factory.Config) error {
+	if config == nil || config.Configuration == nil {
+		return fmt.Errorf("configuration section is nil")
+	}
+	if config.Configuration.SubscriberKeyEncryption == nil {
+		return fmt.Errorf("subscriberKeyEncryption is not configured")
+	}
+	if err := initMongoDB(); err != nil {
+		logger.InitLog.Errorf("failed to initialize MongoDB: %v", err)
+		return err
+	}
+	if err := initSubscriberKeyRing(); err != nil {
+		return fmt.Errorf("failed to initialize subscriber key encryption: %w", err)
+	}
+	updated, err := reencryptSubscriberKey()
+	logger.AppLog.Infof("re-encrypted the keys of %d subscribers", updated)
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt subscriber keys: %w", err)
+	}
+	return nil
 }
 
 func startApplication(config *factory.Config) error {
@@ -72,7 +124,17 @@ func startApplication(config *factory.Config) error {
 		logger.InitLog.Errorf("failed to initialize MongoDB: %v", err)
 		return err
 	}
+	if err := initSubscriberKeyRing(); err != nil {
+		return fmt.Errorf("failed to initialize subscriber key encryption: %w", err)
+	}
+	if _, err := migrateDeviceGroups(); err != nil {
+		return fmt.Errorf("failed to migrate device group MSISDNs: %w", err)
+	}
+	if _, err := migrateSlices(); err != nil {
+		return fmt.Errorf("failed to migrate network slice UPFs: %w", err)
+	}
 	webui := &webui_service.WEBUI{}
+	configapi.SetNfConfigRenderer(nfconfig.RenderConfig)
 	nfConfigServer, err := newNFConfigServer(config)
 	if err != nil {
 		return fmt.Errorf("failed to initialize NFConfig: %w", err)
@@ -148,11 +148,17 @@ func TestStartApplication(t *testing.T) {
 	originalInit := initMongoDB
 	originalNewNF := newNFConfigServer
 	originalRun := runServer
+	originalMigrate := migrateDeviceGroups
+	originalMigrateSlices := migrateSlices
 	defer func() {
 		initMongoDB = originalInit
 		newNFConfigServer = originalNewNF
 		runServer = originalRun
+		migrateDeviceGroups = originalMigrate
+		migrateSlices = originalMigrateSlices
 	}()
+	migrateDeviceGroups = func() (int, error) { return 0, nil }
+	migrateSlices = func() (int, error) { return 0, nil }
 
 	t.Run("nil config", func(t *testing.T) {
 		err := startApplication(nil)
@@ -171,6 +177,30 @@ func TestStartApplication(t *testing.T) {
 		}
 	})
 
+	t.Run("device group migration failure", func(t *testing.T) {
+		initMongoDB = func() error { return nil }
+		migrateDeviceGroups = func() (int, error) {
+			return 0, fmt.Errorf("migration failed")
+		}
+		defer func() { migrateDeviceGroups = func() (int, error) { return 0, nil } }()
+		err := startApplication(&factory.Config{Configuration: &factory.Configuration{}})
+		if err == nil || !strings.Contains(err.Error(), "migration failed") {
+			t.Errorf("expected migration error, got: %v", err)
+		}
+	})
+
+	t.Run("network slice migration failure", func(t *testing.T) {
+		initMongoDB = func() error { return nil }
+		migrateSlices = func() (int, error) {
+			return 0, fmt.Errorf("slice migration failed")
+		}
+		defer func() { migrateSlices = func() (int, error) { return 0, nil } }()
+		err := startApplication(&factory.Config{Configuration: &factory.Configuration{}})
+		if err == nil || !strings.Contains(err.Error(), "slice migration failed") {
+			t.Errorf("expected migration error, got: %v", err)
+		}
+	})
+
 	t.Run("nfconfig init failure", func(t *testing.T) {
 		initMongoDB = func() error { return nil }
 		newNFConfigServer = func(config *factory.Config) (nfconfig.NFConfigInterface, error) {
@@ -210,3 +240,53 @@ func TestStartApplication(t *testing.T) {
 		}
 	})
 }
+
+func TestReencryptSubscriberKeys(t *testing.T) {
+	originalInit := initMongoDB
+	originalInitKeyRing := initSubscriberKeyRing
+	originalReencrypt := reencryptSubscriberKey
+	defer func() {
+		initMongoDB = originalInit
+		initSubscriberKeyRing = originalInitKeyRing
+		reencryptSubscriberKey = originalReencrypt
+	}()
+	encryptionConfig := &factory.Config{
+		Configuration: &factory.Configuration{
+			SubscriberKeyEncryption: &factory.SubscriberKeyEncryption{ActiveKeyId: "key1"},
+		},
+	}
+
+	t.Run("encryption not configured", func(t *testing.T) {
+		err := reencryptSubscriberKeys(&factory.Config{Configuration: &factory.Configuration{}})
+		if err == nil || !strings.Contains(err.Error(), "not configured") {
+			t.Errorf("expected configuration error, got: %v", err)
+		}
+	})
+
+	t.Run("key ring init failure", func(t *testing.T) {
+		initMongoDB = func() error { return nil }
+		initSubscriberKeyRing = func() error {
+			return fmt.Errorf("missing key")
+		}
+		err := reencryptSubscriberKeys(encryptionConfig)
+		if err == nil || !strings.Contains(err.Error(), "missing key") {
+			t.Errorf("expected key ring init error, got: %v", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		initMongoDB = func() error { return nil }
+		initSubscriberKeyRing = func() error { return nil }
+		called := false
+		reencryptSubscriberKey = func() (int, error) {
+			called = true
+			return 3, nil
+		}
+		if err := reencryptSubscriberKeys(encryptionConfig); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+		if !called {
+			t.Error("expected subscriber keys to be re-encrypted")
+		}
+	})
+}